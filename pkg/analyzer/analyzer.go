@@ -17,30 +17,47 @@
 //	├── cond.SubAnalyzer ───────┤                                 │
 //	├── pool.SubAnalyzer ───────┤                                 │
 //	├── channel.SubAnalyzer ────┤                                 │
+//	├── ctxcancel.SubAnalyzer ──┤                                 │
+//	├── syncmap.SubAnalyzer ────┤                                 │
 //	└── copycheck.Analyzer ─────┘                                 └── requires inspect.Analyzer
 //
 // "A requires B" means B runs first and exposes its Result through
 // pass.ResultOf[B]. Each sub-analyzer returns its diagnostic slice as a
 // Result instead of calling pass.Report directly. The umbrella below
 // re-emits them on its own pass so analysistest and any other consumer
-// that targets the umbrella sees the complete diagnostic set.
+// that targets the umbrella sees the complete diagnostic set. The reported
+// diagnostics are also returned as the umbrella's own Result (Findings), so
+// downstream analyzers can require Analyzer and build on its findings.
 package analyzer
 
 import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/report"
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/cond"
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/channel"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/config"
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/copycheck"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/ctxcancel"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/driver"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/filesetup"
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/mutex"
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/once"
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/pool"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/primitives"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/syncmap"
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/waitgroup"
 	"golang.org/x/tools/go/analysis"
 )
 
 var Analyzer = &analysis.Analyzer{
-	Name: "goconcurrencylint",
-	Doc:  "Detects misuse of sync.Mutex, sync.RWMutex, sync.WaitGroup, sync.Once, sync.Cond, sync.Pool and channels, plus copy-by-value of sync primitives.",
-	Run:  run,
+	Name:       "goconcurrencylint",
+	Doc:        "Detects misuse of sync.Mutex, sync.RWMutex, sync.WaitGroup, sync.Once, sync.Cond, sync.Pool and channels, context cancel functions that are never called and non-atomic sync.Map updates, plus copy-by-value of sync primitives.",
+	Run:        run,
+	ResultType: reflect.TypeFor[Findings](),
 	Requires: []*analysis.Analyzer{
 		mutex.SubAnalyzer,
 		waitgroup.SubAnalyzer,
@@ -48,11 +65,136 @@ var Analyzer = &analysis.Analyzer{
 		cond.SubAnalyzer,
 		pool.SubAnalyzer,
 		channel.SubAnalyzer,
+		ctxcancel.SubAnalyzer,
+		syncmap.SubAnalyzer,
 		copycheck.Analyzer,
 	},
 }
 
+// enableFlag holds the -enable value: a comma-separated list of opt-in check
+// codes or slugs to report in addition to the default set.
+var enableFlag string
+
+// errorCategoriesFlag holds the -error-categories value: a comma-separated
+// list of check codes or slugs that stay at error severity while every other
+// check is lowered to warning.
+var errorCategoriesFlag string
+
+func init() {
+	Analyzer.Flags.BoolVar(&driver.ExportedOnly, "exported-only", false,
+		"only analyze exported functions and exported methods of exported types")
+	Analyzer.Flags.BoolVar(&primitives.TrackLockers, "track-lockers", false,
+		"check Lock/Unlock balance on sync.Locker interface values as on sync.Mutex")
+	Analyzer.Flags.StringVar(&primitives.IgnoreNames, "ignore-names", "",
+		"comma-separated name globs of primitives never to track (e.g. 'legacyMu,*.mu')")
+	Analyzer.Flags.IntVar(&filesetup.MaxStmts, "max-stmts", 0,
+		"skip functions with more than this many statements (0 = no limit)")
+	Analyzer.Flags.StringVar(&primitives.GoWrappers, "go-wrappers", "",
+		"comma-separated functions whose func-literal argument runs as a goroutine (e.g. 'pool.Go')")
+	Analyzer.Flags.StringVar(&enableFlag, "enable", "",
+		"comma-separated opt-in checks to report, by code or slug (e.g. GCL5002)")
+	Analyzer.Flags.StringVar(&errorCategoriesFlag, "error-categories", "",
+		"comma-separated checks, by code or slug, reported as errors; every other check is reported as a warning")
+	Analyzer.Flags.StringVar(&filesetup.Exclude, "exclude", "",
+		"comma-separated path globs of files to skip (e.g. '*_gen.go,vendor/*/*.go')")
+	Analyzer.Flags.BoolVar(&filesetup.SkipTests, "skip-tests", false,
+		"skip _test.go files entirely")
+	Analyzer.Flags.StringVar(&waitgroup.AddAttribution, "add-attribution", waitgroup.AttributeFirst,
+		"which Add an unmatched-Add report points at when there are several: 'first' or 'last'")
+	Analyzer.Flags.BoolVar(&mutex.MethodPairs, "method-pairs", false,
+		"treat a mutex field locked in one method and unlocked in another method of the same type as balanced")
+	Analyzer.Flags.BoolVar(&waitgroup.NoBlockingHeuristic, "no-blocking-heuristic", false,
+		"drop the guess that a worker receiving from a local channel nothing sends on never reaches Done")
+	Analyzer.Flags.Var(&report.OnlyLines, "only-lines",
+		"only report findings in this file:start-end line range (repeatable)")
+	Analyzer.Flags.BoolVar(&firstOnlyFlag, "first-only", false,
+		"report at most one diagnostic per function, the earliest in it")
+	Analyzer.Flags.Var(&report.MessageTemplates, "message-templates",
+		"JSON file mapping message ids (e.g. mutex.locked_not_unlocked) to replacement templates; other messages keep their wording")
+}
+
+// enabledOptIn parses the -enable list into the set of opt-in codes to
+// report. Unknown ids and checks that are already on by default are rejected
+// so a typo does not silently disable nothing.
+func enabledOptIn(list string) (map[category.Category]bool, error) {
+	enabled := make(map[category.Category]bool)
+	for id := range strings.SplitSeq(list, ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		code, ok := category.Canonical(id)
+		if !ok {
+			return nil, fmt.Errorf("-enable: unknown check %q", id)
+		}
+		if !category.IsOptIn(code) {
+			return nil, fmt.Errorf("-enable: check %s is not opt-in, it is always enabled", code)
+		}
+		enabled[code] = true
+	}
+	return enabled, nil
+}
+
+// errorCategories parses the -error-categories list into the set of checks
+// kept at error severity, or nil when the list is empty so the configured
+// severities apply. Unknown ids are rejected like in -enable.
+func errorCategories(list string) (map[category.Category]bool, error) {
+	var errs map[category.Category]bool
+	for id := range strings.SplitSeq(list, ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		code, ok := category.Canonical(id)
+		if !ok {
+			return nil, fmt.Errorf("-error-categories: unknown check %q", id)
+		}
+		if errs == nil {
+			errs = make(map[category.Category]bool)
+		}
+		errs[code] = true
+	}
+	return errs, nil
+}
+
+// severityOf returns the level code is reported at: with -error-categories
+// set, error for the listed checks and warning for the rest; otherwise the
+// level .goconcurrencylint.yaml gives it.
+func severityOf(opts *config.Options, errs map[category.Category]bool, code category.Category) string {
+	if errs == nil {
+		return opts.SeverityOf(code)
+	}
+	if errs[code] {
+		return config.SeverityError
+	}
+	return config.SeverityWarning
+}
+
 func run(pass *analysis.Pass) (any, error) {
+	// .goconcurrencylint.yaml supplies defaults; a flag given on the command
+	// line replaces the matching setting.
+	opts, err := config.ForPass(pass)
+	if err != nil {
+		return nil, err
+	}
+	enableList := enableFlag
+	if enableList == "" && opts != nil {
+		enableList = strings.Join(opts.Enable, ",")
+	}
+	enabled, err := enabledOptIn(enableList)
+	if err != nil {
+		return nil, err
+	}
+	errs, err := errorCategories(errorCategoriesFlag)
+	if err != nil {
+		return nil, err
+	}
+	disabled := opts.Disabled()
+	if enableFlag != "" {
+		for code := range enabled {
+			delete(disabled, code)
+		}
+	}
 	subs := []*analysis.Analyzer{
 		mutex.SubAnalyzer,
 		waitgroup.SubAnalyzer,
@@ -60,23 +202,43 @@ func run(pass *analysis.Pass) (any, error) {
 		cond.SubAnalyzer,
 		pool.SubAnalyzer,
 		channel.SubAnalyzer,
+		ctxcancel.SubAnalyzer,
+		syncmap.SubAnalyzer,
 		copycheck.Analyzer,
 	}
+	var reported []analysis.Diagnostic
 	for _, sub := range subs {
 		diags, ok := pass.ResultOf[sub].([]analysis.Diagnostic)
 		if !ok {
 			continue
 		}
 		for _, d := range diags {
-			// Surface the check code in the message itself (e.g.
-			// "GCL1001: ...") so it is visible in plain CLI output, which
-			// otherwise prints only file:line:col + message. The Category
-			// field already carries the same code for tooling.
-			if d.Category != "" {
-				d.Message = d.Category + ": " + d.Message
+			code := category.Category(d.Category)
+			if category.IsOptIn(code) && !enabled[code] || disabled[code] {
+				continue
 			}
-			pass.Report(d)
+			reported = append(reported, d)
+		}
+	}
+	if firstOnlyFlag {
+		reported = firstPerFunction(pass.Files, reported)
+	}
+	var findings Findings
+	for _, d := range reported {
+		severity := severityOf(opts, errs, category.Category(d.Category))
+		findings = append(findings, Finding{Pos: d.Pos, Position: pass.Fset.Position(d.Pos), Category: d.Category, Message: d.Message, Severity: severity})
+		// Surface the check code in the message itself (e.g.
+		// "GCL1001: ...") so it is visible in plain CLI output, which
+		// otherwise prints only file:line:col + message. The Category
+		// field already carries the same code for tooling. A lowered
+		// severity is shown next to the code.
+		switch {
+		case d.Category != "" && severity != config.SeverityError:
+			d.Message = d.Category + " [" + severity + "]: " + d.Message
+		case d.Category != "":
+			d.Message = d.Category + ": " + d.Message
 		}
+		pass.Report(d)
 	}
-	return nil, nil
+	return findings, nil
 }
@@ -16,6 +16,8 @@ func TestAnalyzerFixtures(t *testing.T) {
 		{name: "cond", packages: []string{"cond"}},
 		{name: "pool", packages: []string{"pool"}},
 		{name: "channel", packages: []string{"channel"}},
+		{name: "contextcancel", packages: []string{"contextcancel"}},
+		{name: "syncmap", packages: []string{"syncmap"}},
 		{name: "synccopy", packages: []string{"synccopy"}},
 		{name: "packagelevel", packages: []string{"packagelevel"}},
 		{name: "ignoredirective", packages: []string{"ignoredirective"}},
@@ -23,6 +23,9 @@ type Check struct {
 	Bad string
 	// Good is the corrected version of Bad that the check accepts.
 	Good string
+	// OptIn reports whether the check is off by default and only runs when
+	// named in the -enable flag.
+	OptIn bool
 }
 
 // Checks returns the full catalogue of checks the linter can report, ordered
@@ -60,5 +63,6 @@ func toCheck(c category.Check) Check {
 		Why:       c.Why,
 		Bad:       c.Bad,
 		Good:      c.Good,
+		OptIn:     category.IsOptIn(c.Code),
 	}
 }
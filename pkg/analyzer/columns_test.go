@@ -0,0 +1,34 @@
+package analyzer
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// TestChainedCallColumns checks diagnostics on chained selector calls such as
+// s.inner.mu.Lock() sit on the method name rather than on the s that starts
+// the chain.
+func TestChainedCallColumns(t *testing.T) {
+	results := analysistest.Run(t, analysistest.TestData(), Analyzer, "columns")
+	count := 0
+	for _, res := range results {
+		for _, d := range res.Diagnostics {
+			pos := res.Pass.Fset.Position(d.Pos)
+			src, err := os.ReadFile(pos.Filename)
+			require.NoError(t, err)
+			line := strings.Split(string(src), "\n")[pos.Line-1]
+			at := line[pos.Column-1:]
+			if !strings.HasPrefix(strings.TrimSpace(line), "s.inner.") {
+				continue
+			}
+			count++
+			require.Truef(t, strings.HasPrefix(at, "Lock(") || strings.HasPrefix(at, "Add("),
+				"%s: diagnostic %q at column %d, want the method name, got %q", pos, d.Message, pos.Column, at)
+		}
+	}
+	require.Equal(t, 2, count, "expected two diagnostics on chained calls")
+}
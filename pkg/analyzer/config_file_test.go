@@ -0,0 +1,47 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// configFileSource holds a loop-declared mutex (GCL1006), which the config
+// below turns off, and an unreleased lock (GCL1001), which it lowers to a
+// warning.
+const configFileSource = `package configfile
+
+import "sync"
+
+func LoopMutex() {
+	for i := 0; i < 3; i++ {
+		var mu sync.Mutex
+		mu.Lock()
+		mu.Unlock()
+	}
+}
+
+func Leak() {
+	var mu sync.Mutex
+	mu.Lock() // want "GCL1001 \\[warning\\]: mutex 'mu' is locked but not unlocked"
+}
+`
+
+// TestConfigFile runs a package whose directory holds a
+// .goconcurrencylint.yaml. Only the lowered GCL1001 carries a want marker,
+// so the disabled GCL1006 finding fails the run if it leaks.
+func TestConfigFile(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "src", "configfile")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "configfile.go"), []byte(configFileSource), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".goconcurrencylint.yaml"), []byte(`
+disable: [mutex-in-loop]
+severity:
+  GCL1001: warning
+`), 0o644))
+
+	analysistest.Run(t, filepath.Dir(filepath.Dir(dir)), Analyzer, "configfile")
+}
@@ -0,0 +1,14 @@
+package analyzer
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// TestCustomLockerTypes runs the customlocker fixtures, whose
+// .goconcurrencylint.yaml maps Semaphore's Acquire and Release onto Lock and
+// Unlock.
+func TestCustomLockerTypes(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "customlocker")
+}
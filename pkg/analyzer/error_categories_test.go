@@ -0,0 +1,35 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSeverityOf pins the category-to-severity mapping: -error-categories
+// overrides the configuration file when set, and defers to it otherwise.
+func TestSeverityOf(t *testing.T) {
+	opts := &config.Options{Severity: map[string]string{
+		string(category.LockWithoutUnlock): config.SeverityWarning,
+	}}
+
+	assert.Equal(t, config.SeverityWarning, severityOf(opts, nil, category.LockWithoutUnlock))
+	assert.Equal(t, config.SeverityError, severityOf(opts, nil, category.UnlockWithoutLock))
+	assert.Equal(t, config.SeverityError, severityOf(nil, nil, category.AddWithoutDone))
+
+	errs, err := errorCategories(" GCL1001, add-without-done ,")
+	require.NoError(t, err)
+	assert.Equal(t, config.SeverityError, severityOf(opts, errs, category.LockWithoutUnlock))
+	assert.Equal(t, config.SeverityError, severityOf(opts, errs, category.AddWithoutDone))
+	assert.Equal(t, config.SeverityWarning, severityOf(opts, errs, category.UnlockWithoutLock))
+
+	errs, err = errorCategories("")
+	require.NoError(t, err)
+	assert.Nil(t, errs)
+
+	_, err = errorCategories("GCL9999")
+	assert.ErrorContains(t, err, "unknown check")
+}
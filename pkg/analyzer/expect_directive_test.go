@@ -0,0 +1,89 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/expect"
+)
+
+// TestExpectDirectives exercises the analyzer on inline sources annotated
+// with goconcurrencylint:expect directives instead of `// want` strings.
+func TestExpectDirectives(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{
+			name: "mutex leak",
+			src: `package p
+
+import "sync"
+
+func leak() {
+	var mu sync.Mutex
+	mu.Lock() // goconcurrencylint:expect mutex-leak
+}
+`,
+		},
+		{
+			name: "rwmutex read lock leak and stray unlock",
+			src: `package p
+
+import "sync"
+
+func leak() {
+	var rw sync.RWMutex
+	rw.RLock() // goconcurrencylint:expect rwmutex-rlock-leak
+}
+
+func stray() {
+	var rw sync.RWMutex
+	rw.RUnlock() // goconcurrencylint:expect rwmutex-runlock-without-rlock
+}
+`,
+		},
+		{
+			name: "waitgroup Add without Done, by short code and by check",
+			src: `package p
+
+import "sync"
+
+func missingDone() {
+	var wg sync.WaitGroup
+	wg.Add(1) // goconcurrencylint:expect wg-add-without-done
+	go func() {}()
+	wg.Wait()
+}
+
+func missingDoneBySlug() {
+	var wg sync.WaitGroup
+	wg.Add(1) // goconcurrencylint:expect add-without-done
+	go func() {}()
+	wg.Wait()
+}
+`,
+		},
+		{
+			name: "clean code expects nothing",
+			src: `package p
+
+import "sync"
+
+func balanced() {
+	var mu sync.Mutex
+	mu.Lock()
+	defer mu.Unlock()
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []expect.Diagnostic
+			for _, f := range RunOnSource(t, tt.src) {
+				got = append(got, expect.Diagnostic{Line: f.Position.Line, Category: f.Category, Message: f.Message})
+			}
+			expect.Check(t, tt.src, got)
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package analyzer
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// TestExtraSyncPackages runs the extrasync fixtures, whose
+// .goconcurrencylint.yaml lists syncfork/sync as a drop-in for sync, and
+// then extrasyncoff, which has no configuration and so must not pick up the
+// fork from the earlier run.
+func TestExtraSyncPackages(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "extrasync")
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "extrasyncoff")
+}
@@ -0,0 +1,147 @@
+package analyzer
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+// ReportRelativePaths makes AnalyzeFiles and WriteSARIF write each file name
+// relative to the root of its module, the nearest directory at or above the
+// file holding a go.mod, instead of as the absolute path the loader resolved.
+// A file outside any module keeps its absolute path. The go/analysis drivers
+// print positions themselves and are not affected.
+var ReportRelativePaths bool
+
+// AnalyzeFiles runs the Analyzer outside the go/analysis command drivers, for
+// scripts such as pre-commit hooks that want a plain text report. Each file
+// is loaded with go/packages together with the rest of its package, so it
+// type-checks against its sibling files, and every diagnostic in one of the
+// given files is written to w as a "file:line:col: message" line, sorted by
+// position; diagnostics in the siblings are dropped. A file outside any
+// module has no package to load and is checked on its own. Flags already set
+// on Analyzer (e.g. -enable) apply as usual.
+//
+// Load and type errors are returned rather than reported, as is any error
+// from the analysis itself; w receives nothing in that case.
+func AnalyzeFiles(paths []string, w io.Writer) error {
+	var dirs []string
+	byDir := make(map[string][]string)
+	requested := make(map[string]bool)
+	for _, path := range paths {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+		dir := filepath.Dir(abs)
+		if _, ok := byDir[dir]; !ok {
+			dirs = append(dirs, dir)
+		}
+		byDir[dir] = append(byDir[dir], "file="+abs)
+		requested[abs] = true
+	}
+
+	type line struct {
+		pos token.Position
+		msg string
+	}
+	var lines []line
+	for _, dir := range dirs {
+		cfg := &packages.Config{Mode: packages.LoadAllSyntax, Dir: dir}
+		pkgs, err := packages.Load(cfg, byDir[dir]...)
+		if err != nil {
+			return err
+		}
+		if err := loadErrors(pkgs); err != nil {
+			return err
+		}
+		graph, err := checker.Analyze([]*analysis.Analyzer{Analyzer}, pkgs, nil)
+		if err != nil {
+			return err
+		}
+		for _, act := range graph.Roots {
+			if act.Err != nil {
+				return act.Err
+			}
+			for _, d := range act.Diagnostics {
+				pos := act.Package.Fset.Position(d.Pos)
+				if requested[pos.Filename] {
+					lines = append(lines, line{pos, d.Message})
+				}
+			}
+		}
+	}
+	slices.SortStableFunc(lines, func(a, b line) int {
+		return cmp.Or(
+			strings.Compare(a.pos.Filename, b.pos.Filename),
+			cmp.Compare(a.pos.Line, b.pos.Line),
+			cmp.Compare(a.pos.Column, b.pos.Column),
+		)
+	})
+	for _, l := range lines {
+		if _, err := fmt.Fprintf(w, "%s:%d:%d: %s\n", reportPath(l.pos.Filename), l.pos.Line, l.pos.Column, l.msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reportPath returns filename as the package's own output shows it: relative
+// to its module root when ReportRelativePaths is set.
+func reportPath(filename string) string {
+	if !ReportRelativePaths || filename == "" {
+		return filename
+	}
+	root := moduleRoot(filepath.Dir(filename))
+	if root == "" {
+		return filename
+	}
+	return relativeTo(root, filename)
+}
+
+// relativeTo returns filename relative to base, or filename unchanged when
+// it does not lie under base.
+func relativeTo(base, filename string) string {
+	rel, err := filepath.Rel(base, filename)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filename
+	}
+	return rel
+}
+
+// moduleRoot returns the nearest directory at or above dir holding a
+// go.mod, or "" when there is none.
+func moduleRoot(dir string) string {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// loadErrors joins the load, parse and type errors of pkgs and their
+// dependencies into one error, or returns nil when there are none.
+func loadErrors(pkgs []*packages.Package) error {
+	var errs []error
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		for _, err := range pkg.Errors {
+			errs = append(errs, err)
+		}
+	})
+	return errors.Join(errs...)
+}
@@ -0,0 +1,124 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAnalyzeFiles runs AnalyzeFiles on a temporary file and checks the
+// plain text report it writes.
+func TestAnalyzeFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "leak.go")
+	src := `package leak
+
+import "sync"
+
+func Leak(mu *sync.Mutex) {
+	mu.Lock()
+}
+
+func Fine(mu *sync.Mutex) {
+	mu.Lock()
+	mu.Unlock()
+}
+`
+	require.NoError(t, os.WriteFile(path, []byte(src), 0o644))
+
+	var out strings.Builder
+	require.NoError(t, AnalyzeFiles([]string{path}, &out))
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, lines, 1, "report:\n%s", out.String())
+	assert.True(t, strings.HasPrefix(lines[0], path+":6:2: GCL1001: "), "got %q", lines[0])
+	assert.Contains(t, lines[0], "mutex 'mu' is locked but not unlocked")
+}
+
+// TestAnalyzeFilesTypeError checks that a file that does not type-check is
+// returned as an error instead of being reported.
+func TestAnalyzeFilesTypeError(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "broken.go")
+	require.NoError(t, os.WriteFile(path, []byte("package broken\n\nvar x int = \"s\"\n"), 0o644))
+
+	var out strings.Builder
+	assert.Error(t, AnalyzeFiles([]string{path}, &out))
+	assert.Empty(t, out.String())
+}
+
+// TestRelativeTo checks paths are made relative to a base directory only
+// when they lie under it.
+func TestRelativeTo(t *testing.T) {
+	base := filepath.Join("home", "dev", "project")
+	assert.Equal(t, filepath.Join("pkg", "a.go"), relativeTo(base, filepath.Join(base, "pkg", "a.go")))
+	assert.Equal(t, "a.go", relativeTo(base, filepath.Join(base, "a.go")))
+	outside := filepath.Join("home", "dev", "other", "b.go")
+	assert.Equal(t, outside, relativeTo(base, outside))
+}
+
+// TestAnalyzeFilesRelativePaths checks that with ReportRelativePaths the
+// report names files relative to the directory holding go.mod.
+func TestAnalyzeFilesRelativePaths(t *testing.T) {
+	ReportRelativePaths = true
+	t.Cleanup(func() { ReportRelativePaths = false })
+
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/leak\n\ngo 1.22\n"), 0o644))
+	require.NoError(t, os.Mkdir(filepath.Join(root, "leak"), 0o755))
+	path := filepath.Join(root, "leak", "leak.go")
+	src := `package leak
+
+import "sync"
+
+func Leak(mu *sync.Mutex) {
+	mu.Lock()
+}
+`
+	require.NoError(t, os.WriteFile(path, []byte(src), 0o644))
+
+	var out strings.Builder
+	require.NoError(t, AnalyzeFiles([]string{path}, &out))
+	assert.True(t, strings.HasPrefix(out.String(), filepath.Join("leak", "leak.go")+":6:2: GCL1001: "), "got %q", out.String())
+}
+
+// TestAnalyzeFilesWholePackage checks that a file is type-checked with the
+// rest of its package and that only diagnostics in the given file are
+// reported.
+func TestAnalyzeFilesWholePackage(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/leak\n\ngo 1.22\n"), 0o644))
+	path := filepath.Join(root, "leak.go")
+	src := `package leak
+
+import "sync"
+
+func Leak(mu *sync.Mutex) {
+	mu.Lock()
+	helper()
+}
+`
+	helper := `package leak
+
+import "sync"
+
+func helper() {}
+
+func OtherLeak(mu *sync.Mutex) {
+	mu.Lock()
+}
+`
+	require.NoError(t, os.WriteFile(path, []byte(src), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "helper.go"), []byte(helper), 0o644))
+
+	var out strings.Builder
+	require.NoError(t, AnalyzeFiles([]string{path}, &out))
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, lines, 1, "report:\n%s", out.String())
+	assert.True(t, strings.HasPrefix(lines[0], path+":6:2: GCL1001: "), "got %q", lines[0])
+}
@@ -0,0 +1,31 @@
+package analyzer
+
+import "go/token"
+
+// Finding is one diagnostic the Analyzer reported on a package, in a form
+// other analyzers can consume.
+type Finding struct {
+	// Pos is the position the diagnostic is reported at.
+	Pos token.Pos
+	// Position is Pos resolved to a file, line and column, so the finding
+	// can be serialized without the package's FileSet.
+	Position token.Position
+	// Category is the check code, e.g. "GCL1001".
+	Category string
+	// Message is the diagnostic text without the "<code>: " prefix that the
+	// reported message carries.
+	Message string
+	// Severity is "error", or "warning" when .goconcurrencylint.yaml or
+	// -error-categories lowers the check.
+	Severity string
+}
+
+// Findings is the Analyzer's Result: every diagnostic it reported on the
+// package, after opt-in filtering, in report order. An analyzer that lists
+// Analyzer in its Requires reads it with
+//
+//	findings := pass.ResultOf[analyzer.Analyzer].(analyzer.Findings)
+//
+// Diagnostics suppressed by ignore directives, generated or excluded files are
+// not reported and so never appear here.
+type Findings []Finding
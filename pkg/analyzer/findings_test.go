@@ -0,0 +1,50 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// findingsConsumer is a downstream analyzer that requires Analyzer and
+// re-reports its findings, so the fixtures' `// want` markers prove the
+// Result carries the same set the umbrella reported.
+var findingsConsumer = &analysis.Analyzer{
+	Name:     "findingsconsumer",
+	Doc:      "re-reports goconcurrencylint findings read from pass.ResultOf",
+	Requires: []*analysis.Analyzer{Analyzer},
+	Run: func(pass *analysis.Pass) (any, error) {
+		findings := pass.ResultOf[Analyzer].(Findings)
+		for _, f := range findings {
+			pass.Report(analysis.Diagnostic{Pos: f.Pos, Category: f.Category, Message: f.Message})
+		}
+		return len(findings), nil
+	},
+	ResultType: reflect.TypeFor[int](),
+}
+
+// TestFindingsResultForRequiringAnalyzer composes a second analyzer on top of
+// Analyzer and checks it observes every finding through pass.ResultOf.
+func TestFindingsResultForRequiringAnalyzer(t *testing.T) {
+	results := analysistest.Run(t, analysistest.TestData(), findingsConsumer, "once")
+
+	total := 0
+	for _, res := range results {
+		require.NotNil(t, res.Pass)
+		findings, ok := res.Pass.ResultOf[Analyzer].(Findings)
+		require.True(t, ok, "Analyzer result must be Findings, got %T", res.Pass.ResultOf[Analyzer])
+		assert.Equal(t, len(findings), res.Result)
+		for _, f := range findings {
+			_, known := category.Lookup(category.Category(f.Category))
+			assert.True(t, known, "finding carries unknown category %q", f.Category)
+			assert.NotRegexp(t, `^GCL\d{4}: `, f.Message, "finding message must not carry the code prefix")
+		}
+		total += len(findings)
+	}
+	assert.Greater(t, total, 0, "expected the once fixtures to produce findings")
+}
@@ -0,0 +1,52 @@
+package analyzer
+
+import (
+	"go/ast"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// firstOnlyFlag holds the -first-only value: report at most one diagnostic
+// per function, so a large codebase can be triaged one finding at a time.
+var firstOnlyFlag bool
+
+// firstPerFunction keeps, for each top-level function declaration in files,
+// only the diagnostic reported at the earliest position inside it; among
+// diagnostics at the same position the first in diags wins. Diagnostics
+// outside any function, such as those on package-level declarations, are
+// all kept. The result preserves the order of diags.
+func firstPerFunction(files []*ast.File, diags []analysis.Diagnostic) []analysis.Diagnostic {
+	first := make(map[*ast.FuncDecl]int)
+	for i, d := range diags {
+		fn := enclosingFuncDecl(files, d)
+		if fn == nil {
+			continue
+		}
+		if j, ok := first[fn]; !ok || d.Pos < diags[j].Pos {
+			first[fn] = i
+		}
+	}
+	out := make([]analysis.Diagnostic, 0, len(diags))
+	for i, d := range diags {
+		if fn := enclosingFuncDecl(files, d); fn == nil || first[fn] == i {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// enclosingFuncDecl returns the top-level function declaration holding d,
+// or nil.
+func enclosingFuncDecl(files []*ast.File, d analysis.Diagnostic) *ast.FuncDecl {
+	for _, file := range files {
+		if d.Pos < file.Pos() || d.Pos >= file.End() {
+			continue
+		}
+		for _, decl := range file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Pos() <= d.Pos && d.Pos < fn.End() {
+				return fn
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package analyzer
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/report"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// TestFlags runs each flag's fixtures twice. With the flag set the fixtures'
+// `// want` markers must match exactly, so a finding the flag should drop,
+// or one it should add but does not, fails the run. Without the flag the
+// fixtures must yield off diagnostics, so the flagged run is not passing
+// vacuously.
+func TestFlags(t *testing.T) {
+	tests := []struct {
+		flag, value string
+		// pkg is the testdata package the flag is exercised on.
+		pkg string
+		// off is the number of diagnostics pkg yields without the flag.
+		off int
+		// logs is text the flagged run must log, if any.
+		logs string
+		// reset restores the flag's default; by default the value it held
+		// before the test is Set back.
+		reset func()
+	}{
+		// An Add shortfall is reported at the latest Adds instead of the
+		// first one the Dones cannot cover.
+		{flag: "add-attribution", value: "last", pkg: "addattribution", off: 5},
+		// Every opt-in check the fixtures exercise is reported.
+		{flag: "enable", value: optInChecks, pkg: "optin", off: 4},
+		// Only GCL1002 stays an error: the GCL1001 finding carries the
+		// warning tag.
+		{flag: "error-categories", value: "unlock-without-lock", pkg: "errorcategories", off: 2},
+		// Only the file not matched by the glob carries markers.
+		{flag: "exclude", value: "vendored_*.go", pkg: "exclude", off: 4},
+		// Only exported functions and methods of exported types carry
+		// markers.
+		{flag: "exported-only", value: "true", pkg: "exportedonly", off: 8},
+		// Only the earliest finding of each function carries a marker.
+		{flag: "first-only", value: "true", pkg: "firstonly", off: 4},
+		// The pool's Go method runs its literal as a goroutine; without it
+		// only the missing Dones are reported.
+		{flag: "go-wrappers", value: "Go", pkg: "gowrappers", off: 2},
+		// Only primitives not matched by a glob carry markers.
+		{flag: "ignore-names", value: "legacyMu,*.cacheMu", pkg: "ignorenames", off: 5},
+		// Only the small function is analyzed, and the skip is logged.
+		{flag: "max-stmts", value: "10", pkg: "maxstmts", off: 3, logs: "skipping HugeLeak"},
+		// One message id is reworded; the others keep their default.
+		{
+			flag: "message-templates", value: filepath.Join(analysistest.TestData(), "message_templates.json"),
+			pkg: "messagetemplates", off: 2, reset: func() { report.MessageTemplates = nil },
+		},
+		// Only the self-balancing sibling's leak carries a marker.
+		{flag: "method-pairs", value: "true", pkg: "methodpairs", off: 5},
+		// The Done behind a receive nothing sends on is only counted once
+		// the guess that it is skipped is off.
+		{flag: "no-blocking-heuristic", value: "true", pkg: "noblocking", off: 2},
+		// Only the non-test file carries markers.
+		{flag: "skip-tests", value: "true", pkg: "skiptests", off: 4},
+		// sync.Locker values are only tracked with the flag.
+		{flag: "track-lockers", value: "true", pkg: "lockers", off: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.flag, func(t *testing.T) {
+			results := analysistest.Run(discardErrors{}, analysistest.TestData(), Analyzer, tt.pkg)
+			count := 0
+			for _, res := range results {
+				count += len(res.Diagnostics)
+			}
+			require.Equal(t, tt.off, count, "diagnostics in %s without -%s", tt.pkg, tt.flag)
+
+			reset := tt.reset
+			if reset == nil {
+				prev := Analyzer.Flags.Lookup(tt.flag).Value.String()
+				reset = func() { require.NoError(t, Analyzer.Flags.Set(tt.flag, prev)) }
+			}
+			var buf bytes.Buffer
+			log.SetOutput(&buf)
+			t.Cleanup(func() {
+				log.SetOutput(os.Stderr)
+				reset()
+			})
+			require.NoError(t, Analyzer.Flags.Set(tt.flag, tt.value))
+
+			analysistest.Run(t, analysistest.TestData(), Analyzer, tt.pkg)
+			require.Contains(t, buf.String(), tt.logs)
+		})
+	}
+}
+
+// discardErrors satisfies analysistest.Testing while ignoring every report.
+type discardErrors struct{}
+
+func (discardErrors) Errorf(string, ...any) {}
@@ -60,7 +60,7 @@ func Run[C Checker](pass *analysis.Pass, cfg Config[C]) []analysis.Diagnostic {
 		if sel == nil || !cfg.Accept(sel.Sel.Name) {
 			return
 		}
-		if files.IsGenerated(pass.Fset.File(call.Pos())) {
+		if files.Skips(pass.Fset, call.Pos()) {
 			return
 		}
 		checker.Check(call, sel)
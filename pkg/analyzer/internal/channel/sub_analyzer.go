@@ -47,7 +47,7 @@ func run(pass *analysis.Pass) (any, error) {
 		if body == nil {
 			return
 		}
-		if files.IsGenerated(pass.Fset.File(body.Pos())) {
+		if files.Skips(pass.Fset, body.Pos()) {
 			return
 		}
 		newChecker(ec, pass.TypesInfo).analyzeBody(body)
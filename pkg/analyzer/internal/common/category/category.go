@@ -9,6 +9,8 @@
 //	GCL4xxx  sync.Cond
 //	GCL5xxx  sync.Pool
 //	GCL6xxx  channels (close/send/receive)
+//	GCL7xxx  context cancellation
+//	GCL8xxx  sync.Map
 //	GCL9xxx  cross-cutting (applies to several primitives)
 //
 // Every check also keeps a legacy kebab-case slug (e.g. lock-without-unlock).
@@ -24,36 +26,65 @@ type Category string
 
 const (
 	// Mutex / RWMutex checks (GCL1xxx).
-	LockWithoutUnlock      Category = "GCL1001"
-	UnlockWithoutLock      Category = "GCL1002"
-	DeferUnlockWithoutLock Category = "GCL1003"
-	UncheckedTryLock       Category = "GCL1004"
-	DeferLock              Category = "GCL1005"
-	MutexInLoop            Category = "GCL1006"
-	DeferUnlockInLoop      Category = "GCL1007"
-	RWMutexAPIMismatch     Category = "GCL1008"
-	GoroutineLockDeadlock  Category = "GCL1009"
-	PanicBeforeUnlock      Category = "GCL1010"
-	DoubleLock             Category = "GCL1011"
-	LockOrderCycle         Category = "GCL1012"
-	RWMutexRecursiveLock   Category = "GCL1013"
+	LockWithoutUnlock            Category = "GCL1001"
+	UnlockWithoutLock            Category = "GCL1002"
+	DeferUnlockWithoutLock       Category = "GCL1003"
+	UncheckedTryLock             Category = "GCL1004"
+	DeferLock                    Category = "GCL1005"
+	MutexInLoop                  Category = "GCL1006"
+	DeferUnlockInLoop            Category = "GCL1007"
+	RWMutexAPIMismatch           Category = "GCL1008"
+	GoroutineLockDeadlock        Category = "GCL1009"
+	PanicBeforeUnlock            Category = "GCL1010"
+	DoubleLock                   Category = "GCL1011"
+	LockOrderCycle               Category = "GCL1012"
+	RWMutexRecursiveLock         Category = "GCL1013"
+	EmptyCriticalSection         Category = "GCL1014"
+	AccessOutsideCriticalSection Category = "GCL1015"
+	NilMutexField                Category = "GCL1016"
+	LockOnValueReceiver          Category = "GCL1017"
+	WriteUnderRLock              Category = "GCL1018"
+	LockSplitAcrossGoroutines    Category = "GCL1019"
+	ReassignWhileLocked          Category = "GCL1020"
+	SelectWhileLocked            Category = "GCL1021"
+	LockWhereRLockSuffices       Category = "GCL1022"
+	NilMutexLocal                Category = "GCL1023"
+	SleepWhileLocked             Category = "GCL1024"
 
 	// WaitGroup checks (GCL2xxx).
-	AddWithoutDone          Category = "GCL2001"
-	DoneWithoutAdd          Category = "GCL2002"
-	AddAfterWait            Category = "GCL2003"
-	GoAfterWait             Category = "GCL2004"
-	AddInsideGoroutine      Category = "GCL2005"
-	DoneNotDeferred         Category = "GCL2006"
-	AddLoopCountMismatch    Category = "GCL2007"
-	AddZero                 Category = "GCL2008"
-	AddNegative             Category = "GCL2009"
-	WaitWithoutAdd          Category = "GCL2010"
-	WaitDeadlock            Category = "GCL2011"
-	MultipleDoneWorker      Category = "GCL2012"
-	NestedWaitGroupDeadlock Category = "GCL2013"
-	DoneOutsideGoroutine    Category = "GCL2014"
-	GoPanic                 Category = "GCL2015"
+	AddWithoutDone             Category = "GCL2001"
+	DoneWithoutAdd             Category = "GCL2002"
+	AddAfterWait               Category = "GCL2003"
+	GoAfterWait                Category = "GCL2004"
+	AddInsideGoroutine         Category = "GCL2005"
+	DoneNotDeferred            Category = "GCL2006"
+	AddLoopCountMismatch       Category = "GCL2007"
+	AddZero                    Category = "GCL2008"
+	AddNegative                Category = "GCL2009"
+	WaitWithoutAdd             Category = "GCL2010"
+	WaitDeadlock               Category = "GCL2011"
+	MultipleDoneWorker         Category = "GCL2012"
+	NestedWaitGroupDeadlock    Category = "GCL2013"
+	DoneOutsideGoroutine       Category = "GCL2014"
+	GoPanic                    Category = "GCL2015"
+	AddWithoutWait             Category = "GCL2016"
+	WaitWhileLocked            Category = "GCL2017"
+	AddAfterGoroutineStart     Category = "GCL2018"
+	FieldAddDoneImbalance      Category = "GCL2019"
+	WaitInLoopWithoutAdd       Category = "GCL2020"
+	VariableAddInLoop          Category = "GCL2021"
+	NoOpMainFlowPair           Category = "GCL2022"
+	WaitNotGuaranteed          Category = "GCL2023"
+	AddDoneSameGoroutine       Category = "GCL2024"
+	WaitBeforeAdd              Category = "GCL2025"
+	AddWaitSameIteration       Category = "GCL2026"
+	AddExceedsGoroutines       Category = "GCL2027"
+	DoneAfterConditionalAdd    Category = "GCL2028"
+	WaitUnreachable            Category = "GCL2029"
+	DoneAfterWait              Category = "GCL2030"
+	DoneSkippedOnCancel        Category = "GCL2031"
+	AddInDefer                 Category = "GCL2032"
+	WaitInGoroutineWithoutDone Category = "GCL2033"
 
 	// sync.Once checks (GCL3xxx).
 	OnceDoDeadlock     Category = "GCL3001"
@@ -65,6 +96,7 @@ const (
 
 	// sync.Pool checks (GCL5xxx).
 	PoolNonPointerValue Category = "GCL5001"
+	PoolGetWithoutPut   Category = "GCL5002"
 
 	// Channel checks (GCL6xxx).
 	CloseOfNilChannel    Category = "GCL6001"
@@ -72,6 +104,12 @@ const (
 	SendOnClosedChannel  Category = "GCL6003"
 	NilChannelOperation  Category = "GCL6004"
 
+	// Context checks (GCL7xxx).
+	CancelNotCalled Category = "GCL7001"
+
+	// sync.Map checks (GCL8xxx).
+	SyncMapNonAtomicUpdate Category = "GCL8001"
+
 	// Cross-cutting checks (GCL9xxx).
 	SyncPrimitiveCopy Category = "GCL9001"
 )
@@ -85,6 +123,8 @@ const (
 	primCond  = "sync.Cond"
 	primPool  = "sync.Pool"
 	primChan  = "channel"
+	primCtx   = "context.CancelFunc"
+	primMap   = "sync.Map"
 	primAll   = "sync.Mutex, sync.RWMutex, sync.WaitGroup, sync.Once, sync.Cond, sync.Pool, sync.Map"
 )
 
@@ -141,7 +181,7 @@ func critical(mu *sync.Mutex) {
 }`},
 	{DeferUnlockWithoutLock, "defer-unlock-without-lock", primMutex,
 		"A deferred Unlock()/RUnlock() can run while the mutex is unlocked.",
-		"If the deferred unlock runs without a matching lock — or after a return/panic between defer and lock — it panics.",
+		"If the deferred unlock runs without a matching lock — after a return/panic between defer and lock, or when the lock was only taken inside an if — it panics.",
 		`
 func work(mu *sync.Mutex) {
 	defer mu.Unlock() // deferred before the lock is taken
@@ -245,8 +285,8 @@ go func() {
 	defer mu.Unlock()
 }()`},
 	{PanicBeforeUnlock, "panic-before-unlock", primMutex,
-		"A statically-known out-of-range index can panic between Lock() and a non-deferred unlock.",
-		"If the indexing panics before the Unlock() runs, the mutex is never released and leaks locked.",
+		"A panic can occur between Lock() and a non-deferred unlock: a statically-known out-of-range index, or an explicit panic() or runtime.Goexit() with the unlock after it.",
+		"If the goroutine unwinds before the Unlock() runs, the mutex is never released; a caller that recovers, or any other goroutine, then blocks on it forever.",
 		`
 mu.Lock()
 v := s[10] // can panic before the Unlock below runs
@@ -294,6 +334,186 @@ _ = read()
 mu.RUnlock()
 mu.Lock() // take the write lock only after releasing the read lock
 mu.Unlock()`},
+	{EmptyCriticalSection, "empty-critical-section", primMutex,
+		"Lock()/RLock() is immediately followed by the matching Unlock()/RUnlock() with no statement in between (opt-in).",
+		"An empty critical section protects nothing; it usually means the guarded statements were moved or deleted, leaving the data they touch unsynchronized.",
+		`
+mu.Lock()
+mu.Unlock()
+counter++ // meant to be inside the critical section`,
+		`
+mu.Lock()
+counter++
+mu.Unlock()`},
+	{AccessOutsideCriticalSection, "access-outside-critical-section", primMutex,
+		"A variable written while holding a mutex is accessed again after the mutex was unlocked in the same function (opt-in).",
+		"Once the mutex is released another goroutine may be writing the same data, so the access races with it; read the value into a local while the lock is still held.",
+		`
+mu.Lock()
+shared = compute()
+mu.Unlock()
+return shared // read after the lock was released`,
+		`
+mu.Lock()
+shared = compute()
+v := shared
+mu.Unlock()
+return v`},
+	{NilMutexField, "nil-mutex-field", primMutex,
+		"An unexported *sync.Mutex/*sync.RWMutex struct field is locked but never assigned anywhere in the package.",
+		"The field is always nil, so the first Lock() dereferences a nil pointer and panics.",
+		`
+type server struct {
+	mu *sync.Mutex // never assigned
+}
+
+func (s *server) inc() {
+	s.mu.Lock() // nil pointer dereference
+	defer s.mu.Unlock()
+}`,
+		`
+type server struct {
+	mu sync.Mutex // the zero value is ready to use
+}
+
+func (s *server) inc() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+}`},
+
+	{LockOnValueReceiver, "lock-on-value-receiver", primMutex,
+		"A method with a value receiver locks a mutex stored by value in that receiver.",
+		"The receiver is a copy made for each call, and so is its mutex: every caller locks its own copy, so the lock excludes nobody and the fields it guards are only changed on the copy.",
+		`
+type counter struct {
+	mu sync.Mutex
+	n  int
+}
+
+func (c counter) inc() {
+	c.mu.Lock() // locks the copy
+	c.n++
+	c.mu.Unlock()
+}`,
+		`
+type counter struct {
+	mu sync.Mutex
+	n  int
+}
+
+func (c *counter) inc() {
+	c.mu.Lock()
+	c.n++
+	c.mu.Unlock()
+}`},
+
+	{WriteUnderRLock, "write-under-rlock", primRW,
+		"Shared state is assigned while only a read lock on an RWMutex is held.",
+		"RLock admits any number of readers at once, so two goroutines can run the write together, or one can write while others read: a data race the lock was meant to prevent.",
+		`
+func (c *cache) put(k, v string) {
+	c.mu.RLock()
+	c.items[k] = v // races with other readers
+	c.mu.RUnlock()
+}`,
+		`
+func (c *cache) put(k, v string) {
+	c.mu.Lock()
+	c.items[k] = v
+	c.mu.Unlock()
+}`},
+
+	{LockSplitAcrossGoroutines, "lock-split-across-goroutines", primMutex,
+		"A Lock()/RLock() held by the function is released only by a goroutine it launches (opt-in).",
+		"Go allows one goroutine to unlock a mutex another locked, but the handoff ties the critical section to the scheduling of a second goroutine: a missed signal or early return leaves the mutex held forever, and readers of the code cannot see where it ends.",
+		`
+mu.Lock()
+go func() {
+	<-ready
+	mu.Unlock() // released by another goroutine
+}()
+ready <- struct{}{}`,
+		`
+mu.Lock()
+update()
+mu.Unlock()
+go notify()`},
+
+	{ReassignWhileLocked, "reassign-while-locked", primMutex,
+		"A mutex variable is assigned a new value while it is locked.",
+		"The held lock belongs to the old value and can no longer be released through the variable, so it leaks; the Unlock that follows acts on the new, unlocked mutex and fails with a fatal error.",
+		`
+mu.Lock()
+mu = sync.Mutex{} // the locked mutex is overwritten
+mu.Unlock()       // fatal error: unlock of unlocked mutex`,
+		`
+mu.Lock()
+reset()
+mu.Unlock()`},
+
+	{SelectWhileLocked, "select-while-locked", primMutex,
+		"A select without a default case is entered while a mutex is held (opt-in).",
+		"The select blocks until one of its channels is ready. If the goroutine that would send or receive needs the same mutex first, it waits on Lock() while the select waits on it, and both block forever.",
+		`
+mu.Lock()
+defer mu.Unlock()
+select { // blocks holding mu
+case v := <-results:
+	total += v
+case <-ctx.Done():
+}`,
+		`
+var v int
+select {
+case v = <-results:
+case <-ctx.Done():
+	return
+}
+mu.Lock()
+total += v
+mu.Unlock()`},
+
+	{LockWhereRLockSuffices, "lock-where-rlock-suffices", primRW,
+		"An RWMutex is write-locked around a critical section that only reads (opt-in).",
+		"Lock() excludes every other reader as well as writers. A section that never writes could take RLock() and let concurrent readers proceed, which matters on hot read paths.",
+		`
+func (c *cache) get(k string) string {
+	c.mu.Lock() // only reads c.items
+	defer c.mu.Unlock()
+	return c.items[k]
+}`,
+		`
+func (c *cache) get(k string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.items[k]
+}`},
+
+	{NilMutexLocal, "nil-mutex-local", primMutex,
+		"A *sync.Mutex/*sync.RWMutex local declared without a value is locked, and the function never sets it.",
+		"The pointer is nil when Lock() runs, so the call dereferences nil and panics.",
+		`
+var mu *sync.Mutex
+mu.Lock() // nil pointer dereference
+defer mu.Unlock()`,
+		`
+var mu sync.Mutex // the zero value is ready to use
+mu.Lock()
+defer mu.Unlock()`},
+
+	{SleepWhileLocked, "sleep-while-locked", primMutex,
+		"time.Sleep is called while a mutex is held (opt-in).",
+		"Every goroutine that needs the mutex waits out the sleep as well, so the sleep serializes all of them and stalls unrelated work for its whole duration.",
+		`
+mu.Lock()
+defer mu.Unlock()
+time.Sleep(backoff) // every caller waits here
+retry()`,
+		`
+time.Sleep(backoff)
+mu.Lock()
+defer mu.Unlock()
+retry()`},
 
 	{AddWithoutDone, "add-without-done", primWG,
 		"wg.Add(n) has fewer guaranteed Done()s than its count, so the counter can never reach zero.",
@@ -516,6 +736,351 @@ wg.Go(func() {
 		log.Print(err) // handle the error instead of panicking
 	}
 })`},
+	{AddWithoutWait, "add-without-wait", primWG,
+		"A local WaitGroup has Add() and a worker goroutine calling Done(), but the function never calls Wait().",
+		"Without a Wait() the function returns while its workers are still running, so their results may be lost and the goroutines outlive the call that started them.",
+		`
+var wg sync.WaitGroup
+wg.Add(1)
+go func() {
+	defer wg.Done()
+	work()
+}()
+return // nothing waits for the worker`,
+		`
+var wg sync.WaitGroup
+wg.Add(1)
+go func() {
+	defer wg.Done()
+	work()
+}()
+wg.Wait()`},
+	{WaitWhileLocked, "wait-while-locked", primWG,
+		"wg.Wait() is called while the same function still holds a mutex.",
+		"Workers that need the mutex to finish block on Lock() while the waiter blocks on Wait() holding it, so neither side can make progress.",
+		`
+mu.Lock()
+defer mu.Unlock()
+wg.Add(1)
+go func() {
+	defer wg.Done()
+	mu.Lock()
+	shared++
+	mu.Unlock()
+}()
+wg.Wait() // mu is still held`,
+		`
+wg.Add(1)
+go func() {
+	defer wg.Done()
+	mu.Lock()
+	shared++
+	mu.Unlock()
+}()
+wg.Wait()
+mu.Lock()
+defer mu.Unlock()`},
+	{AddAfterGoroutineStart, "add-after-go", primWG,
+		"wg.Add() runs after the goroutine it counts was already started.",
+		"The goroutine may call Done() before the Add lands, driving the counter negative (a panic) or letting a concurrent Wait() return early.",
+		`
+var wg sync.WaitGroup
+go func() {
+	defer wg.Done()
+	work()
+}()
+wg.Add(1) // the goroutine may already be done
+wg.Wait()`,
+		`
+var wg sync.WaitGroup
+wg.Add(1)
+go func() {
+	defer wg.Done()
+	work()
+}()
+wg.Wait()`},
+	{FieldAddDoneImbalance, "field-add-done-imbalance", primWG,
+		"A WaitGroup struct field is Add()ed more times than it is Done()d, or only ever Done()d, across every function in the package (opt-in).",
+		"Add and Done on a shared field usually live in different methods, so per-function checks cannot pair them; when the Adds outnumber the Dones package-wide, Wait() blocks forever, and a Done with no Add drives the counter negative.",
+		`
+type server struct{ wg sync.WaitGroup }
+
+func (s *server) Start() {
+	s.wg.Add(1)
+	go s.loop()
+}
+
+func (s *server) loop() {
+	serve() // no s.wg.Done() anywhere in the package
+}
+
+func (s *server) Stop() { s.wg.Wait() }`,
+		`
+type server struct{ wg sync.WaitGroup }
+
+func (s *server) Start() {
+	s.wg.Add(1)
+	go s.loop()
+}
+
+func (s *server) loop() {
+	defer s.wg.Done()
+	serve()
+}
+
+func (s *server) Stop() { s.wg.Wait() }`},
+	{WaitInLoopWithoutAdd, "wait-in-loop-without-add", primWG,
+		"wg.Wait() is repeated by a loop whose body never calls Add() or Go() on the WaitGroup.",
+		"After the first iteration the counter is already zero, so every later Wait() returns immediately: the loop waits for nothing and usually spins.",
+		`
+var wg sync.WaitGroup
+wg.Add(1)
+go func() { defer wg.Done(); work() }()
+for {
+	wg.Wait() // returns at once from the second iteration on
+	handle()
+}`,
+		`
+for {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() { defer wg.Done(); work() }()
+	wg.Wait()
+	handle()
+}`},
+
+	{VariableAddInLoop, "variable-add-in-loop", primWG,
+		"wg.Add() is called in a loop with a non-constant count while the loop releases a fixed number of Done() per iteration (opt-in).",
+		"An argument such as the loop index adds a different amount on every iteration (0+1+...+n-1 in total), which almost never matches the one Done per iteration, so Wait() blocks forever or the counter goes negative.",
+		`
+var wg sync.WaitGroup
+for i := 0; i < n; i++ {
+	wg.Add(i) // adds 0, 1, 2, ... but each iteration releases one Done
+	go func() {
+		defer wg.Done()
+		work(i)
+	}()
+}
+wg.Wait()`,
+		`
+var wg sync.WaitGroup
+for i := 0; i < n; i++ {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		work(i)
+	}()
+}
+wg.Wait()`},
+
+	{NoOpMainFlowPair, "main-flow-add-done", primWG,
+		"wg.Add() and wg.Done() are both called in the function's own flow and no goroutine uses the WaitGroup (opt-in).",
+		"The Done runs on the same goroutine that called Add, so the counter is back to zero before anyone could wait on it: the WaitGroup synchronizes nothing. This usually means the work meant to run concurrently was left inline, or the go statement was dropped.",
+		`
+var wg sync.WaitGroup
+wg.Add(1)
+work()
+wg.Done() // same goroutine: Add and Done cancel out
+wg.Wait()`,
+		`
+var wg sync.WaitGroup
+wg.Add(1)
+go func() {
+	defer wg.Done()
+	work()
+}()
+wg.Wait()`},
+
+	{WaitNotGuaranteed, "wait-not-guaranteed", primWG,
+		"Workers are started with wg.Add() or wg.Go(), but some return path after them skips every wg.Wait().",
+		"The Wait usually sits inside an if, or an early return was added after the workers were launched. On that path the function returns while its workers are still running, so their results may be lost and they may outlive the state they use.",
+		`
+var wg sync.WaitGroup
+wg.Add(1)
+go func() {
+	defer wg.Done()
+	work()
+}()
+if wait {
+	wg.Wait() // skipped when wait is false
+}`,
+		`
+var wg sync.WaitGroup
+wg.Add(1)
+go func() {
+	defer wg.Done()
+	work()
+}()
+wg.Wait()`},
+
+	{AddDoneSameGoroutine, "add-done-in-goroutine", primWG,
+		"A goroutine calls both wg.Add() and wg.Done() on a WaitGroup of the enclosing function (opt-in).",
+		"The pair cancels out inside the goroutine, so whoever waits on the WaitGroup never waits for this work; it can return before the goroutine has even started. The Add almost always belongs in the parent, before the go statement.",
+		`
+go func() {
+	wg.Add(1) // balanced by the Done below, invisible to waiters
+	defer wg.Done()
+	work()
+}()`,
+		`
+wg.Add(1)
+go func() {
+	defer wg.Done()
+	work()
+}()`},
+
+	{WaitBeforeAdd, "wait-before-add", primWG,
+		"wg.Wait() runs before any Add(), Go() or Done() on a WaitGroup that is only added to later, so it returns immediately (opt-in).",
+		"Wait only blocks while the counter is above zero. Called first, it returns at once and waits for none of the work added afterwards, which is rarely what the caller expected.",
+		`
+var wg sync.WaitGroup
+wg.Wait() // counter is zero: returns immediately
+wg.Add(1)
+work()
+wg.Done()`,
+		`
+var wg sync.WaitGroup
+wg.Add(1)
+go func() {
+	defer wg.Done()
+	work()
+}()
+wg.Wait()`},
+
+	{AddWaitSameIteration, "add-wait-same-iteration", primWG,
+		"wg.Add() and wg.Wait() run in the same loop iteration on a WaitGroup declared outside the loop (opt-in).",
+		"Reusing one WaitGroup as a per-iteration barrier is easy to get wrong: a worker that outlives the iteration, or a Done that lands late, leaks into the next round's counter. When each iteration waits for its own batch, a WaitGroup scoped to the loop body states that intent.",
+		`
+var wg sync.WaitGroup
+for _, batch := range batches {
+	wg.Add(1)
+	go func() { defer wg.Done(); process(batch) }()
+	wg.Wait() // Add and Wait in the same iteration
+}`,
+		`
+for _, batch := range batches {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() { defer wg.Done(); process(batch) }()
+	wg.Wait()
+}`},
+
+	{AddExceedsGoroutines, "add-exceeds-goroutines", primWG,
+		"A literal Add(n) asks for more Done calls than the goroutines launched on the WaitGroup, plus any Done in the main flow, can make.",
+		"Each worker calls Done once, so the counter never drops to zero and Wait() blocks forever.",
+		`
+wg.Add(3) // only one goroutine calls Done
+go func() { defer wg.Done(); work() }()
+wg.Wait()`,
+		`
+wg.Add(1)
+go func() { defer wg.Done(); work() }()
+wg.Wait()`},
+
+	{DoneAfterConditionalAdd, "done-after-conditional-add", primWG,
+		"wg.Done() runs on every path while the Add it balances only runs under a condition.",
+		"When the condition is false the Add is skipped but the Done still runs, the counter goes negative and Done panics with \"sync: negative WaitGroup counter\".",
+		`
+if len(jobs) > 0 {
+	wg.Add(1)
+}
+go func() {
+	defer wg.Done() // runs even when Add was skipped
+	process(jobs)
+}()
+wg.Wait()`,
+		`
+if len(jobs) > 0 {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		process(jobs)
+	}()
+}
+wg.Wait()`},
+
+	{WaitUnreachable, "wait-unreachable", primWG,
+		"wg.Wait() follows a panic, return, break or goto that always runs, so it is never reached.",
+		"The goroutines started on the WaitGroup are never joined: they leak, or are cut off when the program exits, and the work after Wait never sees their results.",
+		`
+wg.Add(1)
+go func() { defer wg.Done(); work() }()
+panic("not implemented") // always runs
+wg.Wait()`,
+		`
+wg.Add(1)
+go func() { defer wg.Done(); work() }()
+wg.Wait()
+panic("not implemented")`},
+
+	{DoneAfterWait, "done-after-wait", primWG,
+		"wg.Done() runs in the same flow after wg.Wait(), with no Add in between.",
+		"Wait only returns once the counter is zero, so the Done that follows drives it negative and panics. If the Add was raised to cover that Done, the counter never reaches zero and Wait blocks forever.",
+		`
+wg.Add(2)
+go func() { defer wg.Done(); work() }()
+wg.Wait()
+wg.Done() // nothing left to release`,
+		`
+wg.Add(1)
+go func() { defer wg.Done(); work() }()
+wg.Wait()`},
+	{DoneSkippedOnCancel, "done-skipped-on-cancel", primWG,
+		"A goroutine's select returns on ctx.Done() without calling wg.Done(), while another path does call it.",
+		"When the context is cancelled the goroutine exits without releasing its share of the counter, so the wg.Wait() that joins it blocks forever — usually exactly when the caller is trying to shut down.",
+		`
+wg.Add(1)
+go func() {
+	select {
+	case <-ctx.Done():
+		return // wg.Done() skipped
+	case v := <-ch:
+		use(v)
+		wg.Done()
+	}
+}()
+wg.Wait()`,
+		`
+wg.Add(1)
+go func() {
+	defer wg.Done()
+	select {
+	case <-ctx.Done():
+		return
+	case v := <-ch:
+		use(v)
+	}
+}()
+wg.Wait()`},
+	{AddInDefer, "add-in-defer", primWG,
+		"wg.Add(n) is deferred, so the counter is only raised when the function returns.",
+		"The goroutines the Add was meant to cover have already started, and Wait has usually returned or is blocked by then. Their Done calls drive the counter negative and panic, or the late Add leaves it above zero for the next Wait.",
+		`
+defer wg.Add(1) // raises the counter on return
+go func() { defer wg.Done(); work() }()
+wg.Wait()`,
+		`
+wg.Add(1)
+go func() { defer wg.Done(); work() }()
+wg.Wait()`},
+
+	{WaitInGoroutineWithoutDone, "wait-in-goroutine-without-done", primWG,
+		"A goroutine calls wg.Wait() but never Done() while the launching function also Waits, and nothing calls Done.",
+		"The goroutine was meant to release the counter and waits on it instead. The counter never reaches zero, so both the goroutine and the launching function block forever.",
+		`
+wg.Add(1)
+go func() {
+	work()
+	wg.Wait() // meant to be wg.Done()
+}()
+wg.Wait()`,
+		`
+wg.Add(1)
+go func() {
+	defer wg.Done()
+	work()
+}()
+wg.Wait()`},
 
 	{OnceDoDeadlock, "once-do-deadlock", primOnce,
 		"once.Do(f) where f calls Do on the same Once again — Once.Do is not reentrant, so this deadlocks.",
@@ -573,6 +1138,20 @@ pool.Put(buf) // []byte is not pointer-shaped: this allocates on every Put`,
 var pool sync.Pool
 buf := make([]byte, 1024)
 pool.Put(&buf) // store a pointer so nothing is boxed`},
+	{PoolGetWithoutPut, "pool-get-without-put", primPool,
+		"A value obtained with p.Get() is never handed back with p.Put() in the same function (opt-in).",
+		"An object that is never returned to the pool is garbage collected instead of reused, so every call allocates a fresh one and the pool provides no benefit.",
+		`
+var pool sync.Pool
+buf := pool.Get().(*bytes.Buffer)
+buf.Reset()
+write(buf) // buf is never Put back`,
+		`
+var pool sync.Pool
+buf := pool.Get().(*bytes.Buffer)
+defer pool.Put(buf)
+buf.Reset()
+write(buf)`},
 
 	{CloseOfNilChannel, "close-of-nil-channel", primChan,
 		"close() is called on a channel that is nil on every path reaching the call, which panics at runtime.",
@@ -617,6 +1196,35 @@ ch <- 1 // ch is nil: this goroutine blocks forever`,
 ch := make(chan int, 1)
 ch <- 1`},
 
+	{CancelNotCalled, "cancel-not-called", primCtx,
+		"The cancel function returned by context.WithCancel, WithTimeout or WithDeadline is never called, deferred or handed off.",
+		"The derived context and its resources (a timer for WithTimeout and WithDeadline) are only released when the parent is cancelled, so every call leaks them until then.",
+		`
+ctx, cancel := context.WithTimeout(parent, time.Second)
+_ = cancel // never called: the timer leaks until parent is done
+return fetch(ctx)`,
+		`
+ctx, cancel := context.WithTimeout(parent, time.Second)
+defer cancel()
+return fetch(ctx)`},
+
+	{SyncMapNonAtomicUpdate, "syncmap-non-atomic-update", primMap,
+		"A sync.Map key is read with Load and then written back with Store, a read-modify-write that is not atomic.",
+		"Each call is atomic on its own, but another goroutine can Store the same key between the Load and the Store, and its update is silently overwritten. LoadOrStore, CompareAndSwap or a mutex make the update atomic.",
+		`
+var counts sync.Map
+v, _ := counts.Load(key)
+n, _ := v.(int)
+counts.Store(key, n+1) // a concurrent Store between Load and here is lost`,
+		`
+var counts sync.Map
+for {
+	v, loaded := counts.LoadOrStore(key, 1)
+	if !loaded || counts.CompareAndSwap(key, v, v.(int)+1) {
+		break
+	}
+}`},
+
 	{SyncPrimitiveCopy, "sync-primitive-copy", primAll,
 		"A sync primitive (or a struct embedding one) is copied by value.",
 		"Copying duplicates the internal state, so the copy and the original stop synchronising — causing races, lost wakeups or panics.",
@@ -644,6 +1252,24 @@ func (c *Counter) Inc() { // pointer receiver: no copy
 }`},
 }
 
+// optIn lists the heuristic checks that are off by default. The umbrella
+// analyzer only reports them when they are named in its -enable flag.
+var optIn = map[Category]bool{
+	EmptyCriticalSection:         true,
+	AccessOutsideCriticalSection: true,
+	LockSplitAcrossGoroutines:    true,
+	SelectWhileLocked:            true,
+	LockWhereRLockSuffices:       true,
+	SleepWhileLocked:             true,
+	FieldAddDoneImbalance:        true,
+	VariableAddInLoop:            true,
+	NoOpMainFlowPair:             true,
+	AddDoneSameGoroutine:         true,
+	WaitBeforeAdd:                true,
+	AddWaitSameIteration:         true,
+	PoolGetWithoutPut:            true,
+}
+
 // Lookup tables built once from the registry. byCode and bySlug map either
 // identifier form to its full Check.
 var (
@@ -708,3 +1334,9 @@ func Lookup(code Category) (Check, bool) {
 	c, ok := byCode[code]
 	return c, ok
 }
+
+// IsOptIn reports whether code is off by default and must be enabled
+// explicitly.
+func IsOptIn(code Category) bool {
+	return optIn[code]
+}
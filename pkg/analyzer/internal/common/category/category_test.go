@@ -66,3 +66,13 @@ func TestCanonicalAndLookup(t *testing.T) {
 	assert.False(t, ok, "unknown slug must not canonicalise")
 	assert.False(t, IsKnown(""), "empty id is unknown")
 }
+
+// TestOptInChecksAreKnown guards the opt-in set against drifting from the
+// registry: every opt-in code must be a catalogued check.
+func TestOptInChecksAreKnown(t *testing.T) {
+	for code := range optIn {
+		assert.True(t, IsKnown(string(code)), "opt-in code %s is not in the registry", code)
+		assert.True(t, IsOptIn(code))
+	}
+	assert.False(t, IsOptIn(LockWithoutUnlock), "checks are on by default unless listed as opt-in")
+}
@@ -0,0 +1,41 @@
+package commentfilter
+
+import (
+	"go/ast"
+	"strings"
+)
+
+const locksDirective = "goconcurrencylint:locks"
+
+// HeldOnReturn returns the mutexes that fn's doc comment declares it returns
+// holding, via a `//goconcurrencylint:locks` line listing them by the name
+// used inside the function, separated by spaces or commas:
+//
+//	// acquire takes s.mu for the caller, who must Unlock it.
+//	//goconcurrencylint:locks s.mu
+//	func (s *store) acquire() { s.mu.Lock() }
+//
+// The raw comment text is scanned because go/ast drops directive-style
+// comments from CommentGroup.Text. It returns nil when fn has no annotation.
+func HeldOnReturn(fn *ast.FuncDecl) map[string]bool {
+	if fn == nil || fn.Doc == nil {
+		return nil
+	}
+	var held map[string]bool
+	for _, comment := range fn.Doc.List {
+		text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+		names, ok := strings.CutPrefix(text, locksDirective)
+		if !ok || names != "" && names[0] != ' ' && names[0] != '\t' {
+			continue
+		}
+		for _, name := range strings.FieldsFunc(names, func(r rune) bool {
+			return r == ' ' || r == '\t' || r == ','
+		}) {
+			if held == nil {
+				held = make(map[string]bool)
+			}
+			held[name] = true
+		}
+	}
+	return held
+}
@@ -600,3 +600,38 @@ func main() {
 	assert.True(t, cf.IsCategoryIgnored(line, "defer-lock"))
 	assert.False(t, cf.IsCategoryIgnored(line, "wait-without-add"))
 }
+
+func TestHeldOnReturn(t *testing.T) {
+	src := `package p
+
+// acquire returns holding both locks.
+//
+//goconcurrencylint:locks s.mu, s.rw
+func acquire() {}
+
+// goconcurrencylint:locks mu
+func spaced() {}
+
+//goconcurrencylint:lockstep mu
+func otherDirective() {}
+
+// plain has a doc comment but no annotation.
+func plain() {}
+
+func undocumented() {}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", src, parser.ParseComments)
+	require.NoError(t, err)
+
+	got := make(map[string]map[string]bool)
+	for _, decl := range file.Decls {
+		fn := decl.(*ast.FuncDecl)
+		got[fn.Name.Name] = HeldOnReturn(fn)
+	}
+	assert.Equal(t, map[string]bool{"s.mu": true, "s.rw": true}, got["acquire"])
+	assert.Equal(t, map[string]bool{"mu": true}, got["spaced"])
+	assert.Nil(t, got["otherDirective"])
+	assert.Nil(t, got["plain"])
+	assert.Nil(t, got["undocumented"])
+}
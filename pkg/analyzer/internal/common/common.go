@@ -33,44 +33,119 @@ func DerefOnce(typ types.Type) types.Type {
 	return typ
 }
 
-// IsMutex returns true if the given type is sync.Mutex or *sync.Mutex.
-func IsMutex(typ types.Type) bool {
-	typ = DerefOnce(typ)
-	return MatchesPkgAndName(typ, "sync", "Mutex")
+// CoreType returns the single type a type parameter's constraint admits
+// (e.g. *sync.Mutex for [L interface{ *sync.Mutex; sync.Locker }]), so a
+// generic function's primitive parameters classify like their concrete
+// counterparts. typ is returned unchanged when it is not a type parameter or
+// its constraint admits more than one type.
+func CoreType(typ types.Type) types.Type {
+	tp, ok := types.Unalias(typ).(*types.TypeParam)
+	if !ok {
+		return typ
+	}
+	iface, ok := tp.Constraint().Underlying().(*types.Interface)
+	if !ok {
+		return typ
+	}
+
+	var core types.Type
+	for embedded := range iface.EmbeddedTypes() {
+		term := embedded
+		if union, ok := embedded.(*types.Union); ok {
+			if union.Len() != 1 {
+				return typ
+			}
+			term = union.Term(0).Type()
+		}
+		// Embedded method-set interfaces (sync.Locker) constrain behaviour,
+		// not the type set, so they do not compete for the core type.
+		if _, ok := term.Underlying().(*types.Interface); ok {
+			continue
+		}
+		if core != nil && !types.Identical(core, term) {
+			return typ
+		}
+		core = term
+	}
+	if core == nil {
+		return typ
+	}
+	return core
 }
 
-// IsRWMutex returns true if the given type is sync.RWMutex or *sync.RWMutex.
-func IsRWMutex(typ types.Type) bool {
-	typ = DerefOnce(typ)
-	return MatchesPkgAndName(typ, "sync", "RWMutex")
+// primitiveType reduces typ to the candidate sync type: type parameters are
+// replaced by their core type on either side of a single pointer indirection.
+func primitiveType(typ types.Type) types.Type {
+	return CoreType(DerefOnce(CoreType(typ)))
 }
 
-// IsWaitGroup returns true if the given type is sync.WaitGroup or *sync.WaitGroup.
-func IsWaitGroup(typ types.Type) bool {
-	typ = DerefOnce(typ)
-	return MatchesPkgAndName(typ, "sync", "WaitGroup")
+// matchesSyncType reports whether typ is the named type name declared in
+// sync or in one of extra, the import paths of forks and internal mirrors
+// of sync configured for the package being analyzed.
+func matchesSyncType(typ types.Type, name string, extra []string) bool {
+	if MatchesPkgAndName(typ, "sync", name) {
+		return true
+	}
+	for _, path := range extra {
+		if MatchesPkgAndName(typ, path, name) {
+			return true
+		}
+	}
+	return false
 }
 
-// IsOnce returns true if the given type is sync.Once or *sync.Once.
-func IsOnce(typ types.Type) bool {
-	typ = DerefOnce(typ)
-	return MatchesPkgAndName(typ, "sync", "Once")
+// IsMutex returns true if the given type is sync.Mutex or *sync.Mutex, or
+// the same-named type of one of the extra sync packages.
+func IsMutex(typ types.Type, extra ...string) bool {
+	return matchesSyncType(primitiveType(typ), "Mutex", extra)
+}
+
+// IsRWMutex returns true if the given type is sync.RWMutex or *sync.RWMutex,
+// or the same-named type of one of the extra sync packages.
+func IsRWMutex(typ types.Type, extra ...string) bool {
+	return matchesSyncType(primitiveType(typ), "RWMutex", extra)
+}
+
+// IsWaitGroup returns true if the given type is sync.WaitGroup or
+// *sync.WaitGroup, or the same-named type of one of the extra sync packages.
+func IsWaitGroup(typ types.Type, extra ...string) bool {
+	return matchesSyncType(primitiveType(typ), "WaitGroup", extra)
+}
+
+// IsOnce returns true if the given type is sync.Once or *sync.Once, or the
+// same-named type of one of the extra sync packages.
+func IsOnce(typ types.Type, extra ...string) bool {
+	return matchesSyncType(primitiveType(typ), "Once", extra)
 }
 
 // IsCond returns true if the given type is sync.Cond or *sync.Cond. In
 // practice a Cond is almost always used through the *sync.Cond returned by
 // sync.NewCond, so the pointer form is the common one.
-func IsCond(typ types.Type) bool {
-	typ = DerefOnce(typ)
-	return MatchesPkgAndName(typ, "sync", "Cond")
+func IsCond(typ types.Type, extra ...string) bool {
+	return matchesSyncType(primitiveType(typ), "Cond", extra)
 }
 
 // IsPool returns true if the given type is sync.Pool or *sync.Pool. Pool
 // methods have pointer receivers, so a value is auto-addressed at the call
 // site; both the value and pointer forms appear in practice.
-func IsPool(typ types.Type) bool {
-	typ = DerefOnce(typ)
-	return MatchesPkgAndName(typ, "sync", "Pool")
+func IsPool(typ types.Type, extra ...string) bool {
+	return matchesSyncType(primitiveType(typ), "Pool", extra)
+}
+
+// IsSyncMap returns true if the given type is sync.Map or *sync.Map, or the
+// same-named type of one of the extra sync packages.
+func IsSyncMap(typ types.Type, extra ...string) bool {
+	return matchesSyncType(primitiveType(typ), "Map", extra)
+}
+
+// IsLocker returns true if the given type is the sync.Locker interface
+// itself. Concrete types that implement it (sync.Mutex, *sync.RWMutex) are
+// matched by IsMutex and IsRWMutex instead.
+func IsLocker(typ types.Type, extra ...string) bool {
+	if typ == nil {
+		return false
+	}
+	return matchesSyncType(types.Unalias(typ), "Locker", extra)
 }
 
 // IsChannel returns true if the given type is a channel type (chan T,
@@ -86,6 +161,16 @@ func IsChannel(typ types.Type) bool {
 	return ok
 }
 
+// IsCancelFunc returns true if the given type is context.CancelFunc or
+// context.CancelCauseFunc, the function returned by context.WithCancel,
+// WithTimeout, WithDeadline and their Cause variants.
+func IsCancelFunc(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+	return MatchesPkgAndName(types.Unalias(typ), "context", "CancelFunc", "CancelCauseFunc")
+}
+
 // MatchesPkgAndName reports whether typ is a named type declared in pkg
 // whose name matches any of names.
 //
@@ -121,6 +206,21 @@ func MatchPkgAndName(typ types.Type, pkg string, names ...string) (string, bool)
 	return "", false
 }
 
+// MethodPos returns the position a diagnostic about call is reported at:
+// the method name when the method is selected through a field chain, as in
+// s.inner.mu.Lock(), where call.Pos() would point at the s that starts the
+// chain, and the start of the call otherwise.
+func MethodPos(call *ast.CallExpr) token.Pos {
+	sel, ok := UnwrapParenExpr(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return call.Pos()
+	}
+	if _, chained := UnwrapParenExpr(sel.X).(*ast.SelectorExpr); !chained {
+		return call.Pos()
+	}
+	return sel.Sel.Pos()
+}
+
 // GetVarName returns the variable name if the expression is an identifier,
 // or a compound name for selector expressions (e.g., "s.mu" for struct field access).
 // Returns "?" if the expression cannot be reduced to a name.
@@ -138,6 +238,25 @@ func GetVarName(expr ast.Expr) string {
 	return "?"
 }
 
+// VarAliases maps a local declared as the address of a variable, `p := &mu`,
+// to the name of that variable. A nil VarAliases has no aliases.
+type VarAliases map[string]string
+
+// VarName is GetVarName with an alias resolved to the variable it points at,
+// so `p.Unlock()` and `mu.Unlock()` name the same mutex.
+func (a VarAliases) VarName(expr ast.Expr) string {
+	return a.Resolve(GetVarName(expr))
+}
+
+// Resolve returns the variable name stands for: its alias target, or name
+// itself when it is not an alias.
+func (a VarAliases) Resolve(name string) string {
+	if target, ok := a[name]; ok {
+		return target
+	}
+	return name
+}
+
 // UnwrapParenExpr returns the underlying expression stripped of any surrounding parentheses.
 //
 // It iteratively removes all layers of *ast.ParenExpr until a non-parenthesized
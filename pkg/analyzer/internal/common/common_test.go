@@ -3,6 +3,7 @@ package common
 import (
 	"go/ast"
 	"go/constant"
+	"go/parser"
 	"go/token"
 	"go/types"
 	"testing"
@@ -34,6 +35,62 @@ func TestIsWaitGroup(t *testing.T) {
 	assert.False(t, IsWaitGroup(nil))
 }
 
+func TestIsSyncMap(t *testing.T) {
+	assert.True(t, IsSyncMap(makeNamedType("sync", "Map", false)))
+	assert.True(t, IsSyncMap(makeNamedType("sync", "Map", true)))
+	assert.False(t, IsSyncMap(makeNamedType("sync", "Mutex", false)))
+	assert.False(t, IsSyncMap(makeNamedType("maps", "Map", false)))
+	assert.False(t, IsSyncMap(nil))
+}
+
+func TestIsCancelFunc(t *testing.T) {
+	assert.True(t, IsCancelFunc(makeNamedType("context", "CancelFunc", false)))
+	assert.True(t, IsCancelFunc(makeNamedType("context", "CancelCauseFunc", false)))
+	assert.False(t, IsCancelFunc(makeNamedType("context", "CancelFunc", true)))
+	assert.False(t, IsCancelFunc(makeNamedType("context", "Context", false)))
+	assert.False(t, IsCancelFunc(nil))
+}
+
+func TestIsLocker(t *testing.T) {
+	assert.True(t, IsLocker(makeNamedType("sync", "Locker", false)))
+	assert.False(t, IsLocker(makeNamedType("sync", "Locker", true)))
+	assert.False(t, IsLocker(makeNamedType("sync", "Mutex", false)))
+	assert.False(t, IsLocker(nil))
+}
+
+func TestCoreType(t *testing.T) {
+	mutexPtr := makeNamedType("sync", "Mutex", true)
+	locker := types.NewNamed(
+		types.NewTypeName(0, types.NewPackage("sync", "sync"), "Locker", nil),
+		types.NewInterfaceType(nil, nil).Complete(), nil,
+	)
+
+	newTypeParam := func(embedded ...types.Type) *types.TypeParam {
+		constraint := types.NewInterfaceType(nil, embedded).Complete()
+		return types.NewTypeParam(types.NewTypeName(0, nil, "L", nil), constraint)
+	}
+
+	pinned := newTypeParam(mutexPtr, locker)
+	assert.Same(t, mutexPtr, CoreType(pinned))
+	assert.True(t, IsMutex(pinned))
+	assert.False(t, IsRWMutex(pinned))
+
+	tilde := newTypeParam(types.NewUnion([]*types.Term{types.NewTerm(false, mutexPtr)}))
+	assert.True(t, IsMutex(tilde))
+
+	lockerOnly := newTypeParam(locker)
+	assert.Same(t, lockerOnly, CoreType(lockerOnly))
+	assert.False(t, IsMutex(lockerOnly))
+
+	multi := newTypeParam(types.NewUnion([]*types.Term{
+		types.NewTerm(false, mutexPtr),
+		types.NewTerm(false, makeNamedType("sync", "RWMutex", true)),
+	}))
+	assert.False(t, IsMutex(multi))
+
+	assert.Same(t, mutexPtr, CoreType(mutexPtr))
+}
+
 func TestGetVarName(t *testing.T) {
 	ident := &ast.Ident{Name: "foo"}
 	assert.Equal(t, "foo", GetVarName(ident))
@@ -228,6 +285,20 @@ func TestConstantBoolValue(t *testing.T) {
 	assert.False(t, ok)
 }
 
+func TestExtraSyncPackages(t *testing.T) {
+	const fork = "example.com/fork/sync"
+	assert.False(t, IsMutex(makeNamedType(fork, "Mutex", false)), "paths not passed are not sync")
+
+	extra := []string{fork}
+	assert.True(t, IsMutex(makeNamedType(fork, "Mutex", false), extra...))
+	assert.True(t, IsMutex(makeNamedType(fork, "Mutex", true), extra...))
+	assert.True(t, IsRWMutex(makeNamedType(fork, "RWMutex", false), extra...))
+	assert.True(t, IsWaitGroup(makeNamedType(fork, "WaitGroup", true), extra...))
+	assert.False(t, IsMutex(makeNamedType(fork, "WaitGroup", false), extra...))
+	assert.False(t, IsMutex(makeNamedType("example.com/other/sync", "Mutex", false), extra...))
+	assert.True(t, IsMutex(makeNamedType("sync", "Mutex", false), extra...), "sync itself still matches")
+}
+
 func makeNamedType(pkgPath, name string, isPtr bool) types.Type {
 	pkg := types.NewPackage(pkgPath, "")
 	named := types.NewNamed(types.NewTypeName(0, pkg, name, nil), nil, nil)
@@ -237,6 +308,19 @@ func makeNamedType(pkgPath, name string, isPtr bool) types.Type {
 	return named
 }
 
+func TestMethodPos(t *testing.T) {
+	src := "package p\nfunc f() { mu.Lock(); s.inner.mu.Lock(); unlock() }"
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, 0)
+	assert.NoError(t, err)
+	stmts := file.Decls[0].(*ast.FuncDecl).Body.List
+	call := func(i int) *ast.CallExpr { return stmts[i].(*ast.ExprStmt).X.(*ast.CallExpr) }
+
+	assert.Equal(t, call(0).Pos(), MethodPos(call(0)), "a direct method call keeps the call start")
+	assert.Equal(t, call(1).Fun.(*ast.SelectorExpr).Sel.Pos(), MethodPos(call(1)), "a chained call moves to the method name")
+	assert.Equal(t, call(2).Pos(), MethodPos(call(2)), "a plain function call keeps the call start")
+}
+
 func TestUnwrapParenExpr(t *testing.T) {
 	identMu := &ast.Ident{Name: "mu"}
 
@@ -0,0 +1,78 @@
+package report
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// OnlyLines restricts every ErrorCollector to diagnostics that fall inside
+// one of its ranges; when it is empty nothing is dropped. It is bound to the
+// umbrella analyzer's repeatable -only-lines flag so a diff wrapper can lint
+// only the lines a change touches.
+var OnlyLines LineRanges
+
+// LineRange is an inclusive range of lines in one file.
+type LineRange struct {
+	File       string
+	Start, End int
+}
+
+// LineRanges is a flag.Value collecting file:start-end ranges, one per Set.
+type LineRanges []LineRange
+
+// String renders the ranges in the form Set accepts, comma-separated.
+func (r *LineRanges) String() string {
+	if r == nil {
+		return ""
+	}
+	parts := make([]string, len(*r))
+	for i, lr := range *r {
+		parts[i] = lr.File + ":" + strconv.Itoa(lr.Start) + "-" + strconv.Itoa(lr.End)
+	}
+	return strings.Join(parts, ",")
+}
+
+// Set parses one file:start-end range (file:line for a single line) and
+// appends it. The file is matched against a diagnostic's filename as written
+// or as a trailing part of its path, so paths relative to the repository root
+// work.
+func (r *LineRanges) Set(value string) error {
+	i := strings.LastIndex(value, ":")
+	if i <= 0 {
+		return fmt.Errorf("%q is not file:start-end", value)
+	}
+	file, span := value[:i], value[i+1:]
+	startText, endText, isRange := strings.Cut(span, "-")
+	if !isRange {
+		endText = startText
+	}
+	start, err := strconv.Atoi(startText)
+	if err != nil {
+		return fmt.Errorf("invalid start line in %q", value)
+	}
+	end, err := strconv.Atoi(endText)
+	if err != nil {
+		return fmt.Errorf("invalid end line in %q", value)
+	}
+	if start < 1 || end < start {
+		return fmt.Errorf("empty line range in %q", value)
+	}
+	*r = append(*r, LineRange{File: filepath.ToSlash(filepath.Clean(file)), Start: start, End: end})
+	return nil
+}
+
+// Contains reports whether line of filename falls inside any range.
+func (r LineRanges) Contains(filename string, line int) bool {
+	filename = filepath.ToSlash(filename)
+	for _, lr := range r {
+		if line < lr.Start || line > lr.End {
+			continue
+		}
+		if filename == lr.File || strings.HasSuffix(filename, "/"+lr.File) {
+			return true
+		}
+	}
+	return false
+}
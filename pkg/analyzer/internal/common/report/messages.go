@@ -0,0 +1,112 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// MessageTemplates replaces the wording of diagnostics by message id; ids
+// it does not name keep their default text. Only the messages listed in
+// defaultTemplates, the most common mutex and WaitGroup findings, have an id;
+// every other check keeps its built-in wording. It is bound to the umbrella
+// analyzer's -message-templates flag, which loads it from a JSON object so
+// teams can reword or translate messages.
+var MessageTemplates Templates
+
+// Templates is a flag.Value mapping message ids to fmt templates. Each Set
+// reads a JSON file and merges its entries, later files winning.
+type Templates map[string]string
+
+// defaultTemplates holds the built-in wording of every templated message.
+// Arguments are substituted in order with %s; an override may reorder them
+// with explicit indexes such as %[2]s.
+var defaultTemplates = map[string]string{
+	"mutex.locked_not_unlocked":        "%s '%s' is locked but not unlocked",
+	"mutex.locked_not_unlocked_in":     "%s '%s' is locked but not unlocked in %s",
+	"mutex.unlocked_not_locked":        "%s '%s' is unlocked but not locked",
+	"rwmutex.rlocked_not_runlocked":    "rwmutex '%s' is rlocked but not runlocked",
+	"rwmutex.rlocked_not_runlocked_in": "rwmutex '%s' is rlocked but not runlocked in %s",
+	"rwmutex.runlocked_not_rlocked":    "rwmutex '%s' is runlocked but not rlocked",
+	"rwmutex.rlock_in_loop":            "rwmutex '%s' RLock in loop without RUnlock",
+	"waitgroup.add_without_done":       "waitgroup '%s' has Add without corresponding Done",
+	"waitgroup.add_without_done_first": "waitgroup '%s' has Add without corresponding Done (%s added, %s done; reported at the first Add the Dones cannot cover)",
+	"waitgroup.add_without_done_last":  "waitgroup '%s' has Add without corresponding Done (%s added, %s done; reported at the last Adds the Dones cannot cover)",
+	"waitgroup.done_without_add":       "waitgroup '%s' has Done without corresponding Add",
+	"waitgroup.wait_without_add":       "waitgroup '%s' Wait called without any Add",
+	"waitgroup.add_without_wait":       "waitgroup '%s' has Add but no Wait (goroutine result may be lost)",
+	"waitgroup.main_flow_add_done":     "waitgroup '%s' Add/Done in main flow with no goroutine (no-op synchronization)",
+	"waitgroup.variable_add_in_loop":   "waitgroup '%s' variable Add count in loop unlikely to balance Done",
+	"waitgroup.wait_not_guaranteed":    "waitgroup '%s' Wait not guaranteed on all return paths",
+}
+
+// FormatMessage renders the message id with args, using the override from
+// MessageTemplates when there is one. An unknown id is a programming error.
+func FormatMessage(id string, args ...any) string {
+	tmpl, ok := MessageTemplates[id]
+	if !ok {
+		tmpl, ok = defaultTemplates[id]
+	}
+	if !ok {
+		panic("report: unknown message id " + id)
+	}
+	return fmt.Sprintf(tmpl, args...)
+}
+
+// MessageArgs returns the number of arguments the message id takes. An
+// unknown id is a programming error.
+func MessageArgs(id string) int {
+	def, ok := defaultTemplates[id]
+	if !ok {
+		panic("report: unknown message id " + id)
+	}
+	return strings.Count(def, "%s")
+}
+
+// String lists the overridden ids.
+func (t *Templates) String() string {
+	if t == nil {
+		return ""
+	}
+	ids := make([]string, 0, len(*t))
+	for id := range *t {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return strings.Join(ids, ",")
+}
+
+// Set loads a JSON object of id to template from the file at path. Every id
+// must be known and every template must consume the same number of arguments
+// as the default it replaces.
+func (t *Templates) Set(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var overrides map[string]string
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	for id, tmpl := range overrides {
+		if _, ok := defaultTemplates[id]; !ok {
+			return fmt.Errorf("%s: unknown message id %q", path, id)
+		}
+		args := make([]any, MessageArgs(id))
+		for i := range args {
+			args[i] = "x"
+		}
+		if strings.Contains(fmt.Sprintf(tmpl, args...), "%!") {
+			return fmt.Errorf("%s: template for %q does not take %d arguments", path, id, len(args))
+		}
+	}
+	if *t == nil {
+		*t = make(Templates, len(overrides))
+	}
+	for id, tmpl := range overrides {
+		(*t)[id] = tmpl
+	}
+	return nil
+}
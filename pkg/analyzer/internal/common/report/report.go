@@ -9,9 +9,17 @@ import (
 )
 
 // Reporter receives diagnostics from checkers as (position, category,
-// message) triplets. ErrorCollector is the standard implementation.
+// message) triplets, optionally with related positions such as the Lock or
+// Add the diagnostic refers to. ErrorCollector is the standard implementation.
 type Reporter interface {
-	AddError(pos token.Pos, cat category.Category, message string)
+	AddError(pos token.Pos, cat category.Category, message string, related ...analysis.RelatedInformation)
+}
+
+// FixReporter is implemented by reporters that can carry suggested fixes
+// alongside a diagnostic. Checkers type-assert for it and fall back to
+// AddError, so test doubles only need to implement Reporter.
+type FixReporter interface {
+	AddErrorWithFixes(pos token.Pos, cat category.Category, message string, fixes []analysis.SuggestedFix, related ...analysis.RelatedInformation)
 }
 
 // ErrorReport represents a single diagnostic to be reported. Category must
@@ -19,9 +27,24 @@ type Reporter interface {
 // (golangci-lint, IDE integrations) and the inline ignore directive can
 // filter by it.
 type ErrorReport struct {
-	Pos      token.Pos
-	Category category.Category
-	Message  string
+	Pos            token.Pos
+	Category       category.Category
+	Message        string
+	Related        []analysis.RelatedInformation
+	SuggestedFixes []analysis.SuggestedFix
+}
+
+// reportKey identifies an ErrorReport for deduplication. Related positions
+// do not take part: the first report of a diagnostic wins.
+type reportKey struct {
+	pos     token.Pos
+	cat     category.Category
+	message string
+}
+
+// Related builds a related-information entry pointing at pos.
+func Related(pos token.Pos, message string) analysis.RelatedInformation {
+	return analysis.RelatedInformation{Pos: pos, Message: message}
 }
 
 // IgnoreFunc decides whether a diagnostic should be suppressed based on its
@@ -33,26 +56,49 @@ type IgnoreFunc func(filename string, line int, cat category.Category) bool
 // (Pos, Category, Message), and emits them sorted in deterministic order.
 type ErrorCollector struct {
 	errors []ErrorReport
-	seen   map[ErrorReport]struct{}
+	seen   map[reportKey]struct{}
 }
 
 // AddError records a diagnostic. cat should be a constant from the category
 // package; an empty category will not match any per-rule ignore directive.
-func (ec *ErrorCollector) AddError(pos token.Pos, cat category.Category, message string) {
-	report := ErrorReport{
-		Pos:      pos,
-		Category: cat,
-		Message:  message,
-	}
+// related entries are attached to the diagnostic in source order; invalid
+// positions are dropped.
+func (ec *ErrorCollector) AddError(pos token.Pos, cat category.Category, message string, related ...analysis.RelatedInformation) {
+	ec.AddErrorWithFixes(pos, cat, message, nil, related...)
+}
 
+// AddErrorWithFixes records a diagnostic like AddError and attaches fixes as
+// its suggested fixes. Like related entries, the fixes of the first report of
+// a diagnostic win.
+func (ec *ErrorCollector) AddErrorWithFixes(pos token.Pos, cat category.Category, message string, fixes []analysis.SuggestedFix, related ...analysis.RelatedInformation) {
+	key := reportKey{pos: pos, cat: cat, message: message}
 	if ec.seen == nil {
-		ec.seen = make(map[ErrorReport]struct{})
+		ec.seen = make(map[reportKey]struct{})
 	}
-	if _, exists := ec.seen[report]; exists {
+	if _, exists := ec.seen[key]; exists {
 		return
 	}
-	ec.seen[report] = struct{}{}
-	ec.errors = append(ec.errors, report)
+	ec.seen[key] = struct{}{}
+	ec.errors = append(ec.errors, ErrorReport{
+		Pos:            pos,
+		Category:       cat,
+		Message:        message,
+		Related:        sortedRelated(related),
+		SuggestedFixes: fixes,
+	})
+}
+
+// sortedRelated copies the valid entries of related ordered by position, so
+// callers may pass positions in any order and keep reusing their slices.
+func sortedRelated(related []analysis.RelatedInformation) []analysis.RelatedInformation {
+	var out []analysis.RelatedInformation
+	for _, r := range related {
+		if r.Pos.IsValid() {
+			out = append(out, r)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Pos < out[j].Pos })
+	return out
 }
 
 type preparedError struct {
@@ -61,7 +107,8 @@ type preparedError struct {
 }
 
 // ReportAll emits every collected diagnostic via pass.Report. When ignore is
-// non-nil, diagnostics for which it returns true are dropped.
+// non-nil, diagnostics for which it returns true are dropped, as are those
+// outside OnlyLines when it is set.
 func (ec *ErrorCollector) ReportAll(pass *analysis.Pass, ignore IgnoreFunc) {
 	for _, d := range ec.Diagnostics(pass, ignore) {
 		pass.Report(d)
@@ -104,16 +151,19 @@ func (ec *ErrorCollector) Diagnostics(pass *analysis.Pass, ignore IgnoreFunc) []
 	out := make([]analysis.Diagnostic, len(prepared))
 	for i, item := range prepared {
 		out[i] = analysis.Diagnostic{
-			Pos:      item.err.Pos,
-			Category: string(item.err.Category),
-			Message:  item.err.Message,
+			Pos:            item.err.Pos,
+			Category:       string(item.err.Category),
+			Message:        item.err.Message,
+			Related:        item.err.Related,
+			SuggestedFixes: item.err.SuggestedFixes,
 		}
 	}
 	return out
 }
 
 // filterAndPrepare resolves token positions once per diagnostic,
-// filters ignored entries, and prepares the remaining data for sorting.
+// filters ignored entries and those outside OnlyLines, and prepares the
+// remaining data for sorting.
 func (ec *ErrorCollector) filterAndPrepare(pass *analysis.Pass, ignore IgnoreFunc) []preparedError {
 	cache := make([]preparedError, 0, len(ec.errors))
 
@@ -122,6 +172,9 @@ func (ec *ErrorCollector) filterAndPrepare(pass *analysis.Pass, ignore IgnoreFun
 		if ignore != nil && ignore(pos.Filename, pos.Line, err.Category) {
 			continue
 		}
+		if len(OnlyLines) > 0 && !OnlyLines.Contains(pos.Filename, pos.Line) {
+			continue
+		}
 
 		cache = append(cache, preparedError{
 			err: err,
@@ -2,10 +2,13 @@ package report
 
 import (
 	"go/token"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/tools/go/analysis"
 )
 
@@ -85,6 +88,26 @@ func TestErrorCollector_ReportAll(t *testing.T) {
 		assert.Equal(t, []string{"err1", "err2"}, reported)
 	})
 
+	t.Run("same position sorted by message", func(t *testing.T) {
+		// Message is the last sort key, so the order does not depend on
+		// which check reported first.
+		ec := &ErrorCollector{}
+		ec.AddError(pos1, "cat", "waitgroup 'wg' has Add without corresponding Done")
+		ec.AddError(pos1, "cat", "waitgroup 'wg' Add has no matching Done in its goroutine")
+		var reported []string
+		pass := &analysis.Pass{
+			Fset: fset,
+			Report: func(d analysis.Diagnostic) {
+				reported = append(reported, d.Message)
+			},
+		}
+		ec.ReportAll(pass, nil)
+		assert.Equal(t, []string{
+			"waitgroup 'wg' Add has no matching Done in its goroutine",
+			"waitgroup 'wg' has Add without corresponding Done",
+		}, reported)
+	})
+
 	t.Run("different files", func(t *testing.T) {
 		fset2 := token.NewFileSet()
 		fileA := fset2.AddFile("a.go", -1, 100)
@@ -202,4 +225,141 @@ func TestErrorCollector_ReportAll(t *testing.T) {
 		ec.ReportAll(pass, nil)
 		assert.Equal(t, 3, n)
 	})
+
+	t.Run("related information is attached in source order", func(t *testing.T) {
+		ec := &ErrorCollector{}
+		ec.AddError(pos2, "cat", "double lock",
+			Related(pos3, "second"),
+			Related(token.NoPos, "dropped"),
+			Related(pos1, "first"))
+		ec.AddError(pos2, "cat", "double lock", Related(pos1, "dup ignored"))
+		var got []analysis.Diagnostic
+		pass := &analysis.Pass{
+			Fset:   fset,
+			Report: func(d analysis.Diagnostic) { got = append(got, d) },
+		}
+		ec.ReportAll(pass, nil)
+		assert.Len(t, got, 1)
+		assert.Equal(t, []analysis.RelatedInformation{
+			{Pos: pos1, Message: "first"},
+			{Pos: pos3, Message: "second"},
+		}, got[0].Related)
+	})
+
+	t.Run("no related information", func(t *testing.T) {
+		ec := &ErrorCollector{}
+		ec.AddError(pos1, "cat", "msg")
+		diags := ec.Diagnostics(&analysis.Pass{Fset: fset}, nil)
+		assert.Len(t, diags, 1)
+		assert.Nil(t, diags[0].Related)
+	})
+
+	t.Run("suggested fixes are carried to the diagnostic", func(t *testing.T) {
+		ec := &ErrorCollector{}
+		fix := analysis.SuggestedFix{
+			Message:   "insert",
+			TextEdits: []analysis.TextEdit{{Pos: pos1, End: pos1, NewText: []byte("x")}},
+		}
+		ec.AddErrorWithFixes(pos1, "cat", "msg", []analysis.SuggestedFix{fix})
+		ec.AddError(pos1, "cat", "msg") // dup keeps the first report's fix
+		ec.AddError(pos2, "cat", "plain")
+		diags := ec.Diagnostics(&analysis.Pass{Fset: fset}, nil)
+		assert.Len(t, diags, 2)
+		assert.Equal(t, []analysis.SuggestedFix{fix}, diags[0].SuggestedFixes)
+		assert.Nil(t, diags[1].SuggestedFixes)
+	})
+}
+
+func TestErrorCollector_OnlyLines(t *testing.T) {
+	fset := token.NewFileSet()
+	file := fset.AddFile("/repo/pkg/foo.go", -1, 100)
+	for offset := 10; offset < 100; offset += 10 {
+		file.AddLine(offset)
+	}
+	other := fset.AddFile("/repo/pkg/bar.go", -1, 100)
+	other.AddLine(10)
+
+	t.Cleanup(func() { OnlyLines = nil })
+	assert.NoError(t, OnlyLines.Set("pkg/foo.go:3-5"))
+
+	ec := &ErrorCollector{}
+	ec.AddError(file.LineStart(2), "cat", "before range")
+	ec.AddError(file.LineStart(3), "cat", "range start")
+	ec.AddError(file.LineStart(5), "cat", "range end")
+	ec.AddError(file.LineStart(6), "cat", "after range")
+	ec.AddError(other.LineStart(2), "cat", "other file")
+
+	var got []string
+	pass := &analysis.Pass{
+		Fset:   fset,
+		Report: func(d analysis.Diagnostic) { got = append(got, d.Message) },
+	}
+	ec.ReportAll(pass, nil)
+	assert.Equal(t, []string{"range start", "range end"}, got)
+
+	OnlyLines = nil
+	got = nil
+	ec.ReportAll(pass, nil)
+	assert.Len(t, got, 5, "no ranges means no filtering")
+}
+
+func TestLineRangesSet(t *testing.T) {
+	var r LineRanges
+	assert.NoError(t, r.Set("a.go:3-7"))
+	assert.NoError(t, r.Set("./dir/b.go:12"))
+	assert.Equal(t, LineRanges{
+		{File: "a.go", Start: 3, End: 7},
+		{File: "dir/b.go", Start: 12, End: 12},
+	}, r)
+	assert.Equal(t, "a.go:3-7,dir/b.go:12-12", r.String())
+
+	assert.True(t, r.Contains("/src/a.go", 3))
+	assert.True(t, r.Contains("/src/dir/b.go", 12))
+	assert.False(t, r.Contains("/src/xa.go", 3), "file must match a whole path element")
+	assert.False(t, r.Contains("/src/dir/b.go", 13))
+
+	for _, bad := range []string{"a.go", ":1-2", "a.go:x-2", "a.go:2-y", "a.go:0-2", "a.go:5-2"} {
+		assert.Error(t, r.Set(bad), bad)
+	}
+}
+
+func TestFormatMessage(t *testing.T) {
+	assert.Equal(t, "mutex 'mu' is locked but not unlocked in if",
+		FormatMessage("mutex.locked_not_unlocked_in", "mutex", "mu", "if"))
+
+	MessageTemplates = Templates{"mutex.locked_not_unlocked_in": "%[2]s (%[1]s) stays held after the %[3]s branch"}
+	t.Cleanup(func() { MessageTemplates = nil })
+	assert.Equal(t, "mu (mutex) stays held after the if branch",
+		FormatMessage("mutex.locked_not_unlocked_in", "mutex", "mu", "if"))
+	assert.Equal(t, "waitgroup 'wg' has Done without corresponding Add",
+		FormatMessage("waitgroup.done_without_add", "wg"), "ids without an override keep the default")
+}
+
+func TestTemplatesSet(t *testing.T) {
+	write := func(content string) string {
+		path := filepath.Join(t.TempDir(), "templates.json")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	var tmpl Templates
+	assert.NoError(t, tmpl.Set(write(`{"waitgroup.wait_without_add": "Wait sin Add en '%s'"}`)))
+	assert.Equal(t, Templates{"waitgroup.wait_without_add": "Wait sin Add en '%s'"}, tmpl)
+	assert.Equal(t, "waitgroup.wait_without_add", tmpl.String())
+
+	for _, bad := range []string{
+		`not json`,
+		`{"no.such.id": "x"}`,
+		`{"waitgroup.wait_without_add": "no argument"}`,
+		`{"waitgroup.wait_without_add": "%s and %s"}`,
+	} {
+		assert.Error(t, tmpl.Set(write(bad)), bad)
+	}
+	assert.Error(t, tmpl.Set(filepath.Join(t.TempDir(), "missing.json")))
+}
+
+func TestMessageArgs(t *testing.T) {
+	assert.Equal(t, 3, MessageArgs("mutex.locked_not_unlocked_in"))
+	assert.Equal(t, 1, MessageArgs("waitgroup.wait_without_add"))
+	assert.Panics(t, func() { MessageArgs("no.such.id") })
 }
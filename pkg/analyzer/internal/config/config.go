@@ -0,0 +1,248 @@
+// Package config loads the optional .goconcurrencylint.yaml file that lets
+// a team keep its linter settings at the module root instead of repeating
+// them as flags. The file is found by walking up from the directory of the
+// first file of the analyzed package, stopping at the directory holding
+// go.mod. Flags given on the command line take precedence over it.
+//
+// A configuration looks like:
+//
+//	enable: [GCL5002]          # opt-in checks to report
+//	disable: [mutex-in-loop]   # checks not to report, by code or slug
+//	severity:
+//	  GCL2016: warning         # error (the default) or warning
+//	exclude:                   # path globs of files to skip
+//	  - "*_gen.go"
+//	go-wrappers: [pool.Go]     # calls whose func literal runs as a goroutine
+//	ignore-names: ["*.mu"]     # name globs of primitives never tracked
+//	locker-types: ["*.Sema"]   # custom types checked like a sync.Mutex
+//	lock-methods: [Acquire]    # their lock methods (default Lock, RLock)
+//	unlock-methods: [Release]  # their unlock methods (default Unlock, RUnlock)
+//	extra-sync-packages: [example.com/fork/sync]  # drop-in replacements for sync
+package config
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+	"golang.org/x/tools/go/analysis"
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the name of the configuration file.
+const FileName = ".goconcurrencylint.yaml"
+
+// Severity levels accepted in the severity section.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Options is the parsed contents of a configuration file. Check ids are
+// canonicalized to their codes while loading.
+type Options struct {
+	// Enable lists opt-in checks to report, like the -enable flag.
+	Enable []string `yaml:"enable"`
+	// Disable lists checks whose findings are dropped.
+	Disable []string `yaml:"disable"`
+	// Severity maps a check to the level its findings are reported at.
+	Severity map[string]string `yaml:"severity"`
+	// Exclude lists path globs of files to skip, like the -exclude flag.
+	Exclude []string `yaml:"exclude"`
+	// GoWrappers lists functions that run their function-literal argument
+	// on a new goroutine, like the -go-wrappers flag.
+	GoWrappers []string `yaml:"go-wrappers"`
+	// IgnoreNames lists name globs of primitives that are never tracked,
+	// like the -ignore-names flag.
+	IgnoreNames []string `yaml:"ignore-names"`
+	// LockerTypes lists globs matched against the "package.Name" of custom
+	// types whose Lock/Unlock balance is checked like a sync.Mutex.
+	LockerTypes []string `yaml:"locker-types"`
+	// LockMethods and UnlockMethods name the methods of a LockerTypes type
+	// that lock and unlock it. They default to Lock/RLock and Unlock/RUnlock.
+	LockMethods   []string `yaml:"lock-methods"`
+	UnlockMethods []string `yaml:"unlock-methods"`
+	// ExtraSyncPackages lists import paths of drop-in replacements for
+	// sync whose Mutex, RWMutex, WaitGroup and other types are checked
+	// like the standard ones.
+	ExtraSyncPackages []string `yaml:"extra-sync-packages"`
+
+	// Path is the file the options were read from.
+	Path string `yaml:"-"`
+}
+
+// Disabled returns the set of checks listed under disable.
+func (o *Options) Disabled() map[category.Category]bool {
+	disabled := make(map[category.Category]bool)
+	if o == nil {
+		return disabled
+	}
+	for _, id := range o.Disable {
+		disabled[category.Category(id)] = true
+	}
+	return disabled
+}
+
+// SeverityOf returns the configured level of code, SeverityError by
+// default.
+func (o *Options) SeverityOf(code category.Category) string {
+	if o == nil {
+		return SeverityError
+	}
+	if level, ok := o.Severity[string(code)]; ok {
+		return level
+	}
+	return SeverityError
+}
+
+// loaded is a cached discovery result for one directory.
+type loaded struct {
+	opts *Options
+	err  error
+}
+
+// cache maps the directory a search started from to its result. Every
+// sub-analyzer of a package asks for the same directory, so the walk and
+// the parse happen once per package.
+var cache sync.Map
+
+// ForPass returns the options that apply to the package of pass, or nil
+// when no configuration file is found.
+func ForPass(pass *analysis.Pass) (*Options, error) {
+	for _, file := range pass.Files {
+		if tokFile := pass.Fset.File(file.Pos()); tokFile != nil && tokFile.Name() != "" {
+			return Find(filepath.Dir(tokFile.Name()))
+		}
+	}
+	return nil, nil
+}
+
+// Find returns the options from the nearest configuration file at or above
+// dir, or nil when there is none.
+func Find(dir string) (*Options, error) {
+	if cached, ok := cache.Load(dir); ok {
+		res := cached.(loaded)
+		return res.opts, res.err
+	}
+	opts, err := find(dir)
+	cache.Store(dir, loaded{opts: opts, err: err})
+	return opts, err
+}
+
+func find(dir string) (*Options, error) {
+	for {
+		name := filepath.Join(dir, FileName)
+		if _, err := os.Stat(name); err == nil {
+			return Load(name)
+		}
+		// The module root bounds the search: a file above it belongs to
+		// another project.
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return nil, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// Load reads and validates the configuration file at name.
+func Load(name string) (*Options, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	opts := &Options{Path: name}
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(opts); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	if err := opts.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return opts, nil
+}
+
+// validate canonicalizes every check id and rejects unknown ids, levels and
+// malformed globs, so a typo fails the run instead of being ignored.
+func (o *Options) validate() error {
+	for i, id := range o.Enable {
+		code, ok := category.Canonical(id)
+		if !ok {
+			return fmt.Errorf("enable: unknown check %q", id)
+		}
+		if !category.IsOptIn(code) {
+			return fmt.Errorf("enable: check %s is not opt-in, it is always enabled", code)
+		}
+		o.Enable[i] = string(code)
+	}
+	for i, id := range o.Disable {
+		code, ok := category.Canonical(id)
+		if !ok {
+			return fmt.Errorf("disable: unknown check %q", id)
+		}
+		o.Disable[i] = string(code)
+	}
+	severity := make(map[string]string, len(o.Severity))
+	for id, level := range o.Severity {
+		code, ok := category.Canonical(id)
+		if !ok {
+			return fmt.Errorf("severity: unknown check %q", id)
+		}
+		if level != SeverityError && level != SeverityWarning {
+			return fmt.Errorf("severity: %s: unknown level %q, want %q or %q", code, level, SeverityError, SeverityWarning)
+		}
+		severity[string(code)] = level
+	}
+	o.Severity = severity
+	for _, glob := range o.Exclude {
+		if _, err := path.Match(glob, ""); err != nil {
+			return fmt.Errorf("exclude: invalid glob %q: %w", glob, err)
+		}
+	}
+	for _, glob := range o.IgnoreNames {
+		if _, err := path.Match(glob, ""); err != nil {
+			return fmt.Errorf("ignore-names: invalid glob %q: %w", glob, err)
+		}
+	}
+	for _, glob := range o.LockerTypes {
+		if _, err := path.Match(glob, ""); err != nil {
+			return fmt.Errorf("locker-types: invalid glob %q: %w", glob, err)
+		}
+	}
+	for _, path := range o.ExtraSyncPackages {
+		if path == "" || strings.ContainsAny(path, " \t") {
+			return fmt.Errorf("extra-sync-packages: invalid import path %q", path)
+		}
+	}
+	if err := o.validateMethods("lock-methods", o.LockMethods); err != nil {
+		return err
+	}
+	return o.validateMethods("unlock-methods", o.UnlockMethods)
+}
+
+// validateMethods rejects a method list given without locker-types or
+// holding a name that is not an identifier.
+func (o *Options) validateMethods(key string, methods []string) error {
+	if len(methods) > 0 && len(o.LockerTypes) == 0 {
+		return fmt.Errorf("%s: set without locker-types", key)
+	}
+	for _, name := range methods {
+		if !token.IsIdentifier(name) {
+			return fmt.Errorf("%s: invalid method name %q", key, name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(name), 0o755))
+	require.NoError(t, os.WriteFile(name, []byte(content), 0o644))
+}
+
+func TestLoad(t *testing.T) {
+	name := filepath.Join(t.TempDir(), FileName)
+	writeFile(t, name, `
+enable: [pool-get-without-put]
+disable: [GCL1006]
+severity:
+  lock-without-unlock: warning
+exclude: ["*_gen.go"]
+go-wrappers: [pool.Go]
+ignore-names: ["*.mu"]
+locker-types: ["*.Semaphore"]
+lock-methods: [Acquire]
+unlock-methods: [Release]
+extra-sync-packages: [example.com/fork/sync]
+`)
+
+	opts, err := Load(name)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"GCL5002"}, opts.Enable, "slugs are canonicalized to codes")
+	assert.Equal(t, map[category.Category]bool{category.MutexInLoop: true}, opts.Disabled())
+	assert.Equal(t, SeverityWarning, opts.SeverityOf(category.LockWithoutUnlock))
+	assert.Equal(t, SeverityError, opts.SeverityOf(category.UnlockWithoutLock))
+	assert.Equal(t, []string{"*_gen.go"}, opts.Exclude)
+	assert.Equal(t, []string{"pool.Go"}, opts.GoWrappers)
+	assert.Equal(t, []string{"*.mu"}, opts.IgnoreNames)
+	assert.Equal(t, []string{"*.Semaphore"}, opts.LockerTypes)
+	assert.Equal(t, []string{"Acquire"}, opts.LockMethods)
+	assert.Equal(t, []string{"Release"}, opts.UnlockMethods)
+	assert.Equal(t, []string{"example.com/fork/sync"}, opts.ExtraSyncPackages)
+}
+
+func TestLoadEmptyFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), FileName)
+	writeFile(t, name, "")
+
+	opts, err := Load(name)
+	require.NoError(t, err)
+	assert.Empty(t, opts.Disabled())
+}
+
+func TestLoadRejectsInvalidOptions(t *testing.T) {
+	for _, content := range []string{
+		"enable: [GCL9999]",
+		"enable: [GCL1001]",
+		"disable: [no-such-check]",
+		"severity: {GCL1001: fatal}",
+		"exclude: ['[']",
+		"ignore-names: ['[']",
+		"locker-types: ['[']",
+		"lock-methods: [Acquire]",
+		"extra-sync-packages: ['']",
+		"{locker-types: ['*.Sema'], unlock-methods: ['Re lease']}",
+		"excludes: ['*_gen.go']",
+	} {
+		name := filepath.Join(t.TempDir(), FileName)
+		writeFile(t, name, content)
+		_, err := Load(name)
+		assert.Error(t, err, content)
+	}
+}
+
+func TestFindWalksUpToModuleRoot(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, FileName), "disable: [GCL1006]")
+	writeFile(t, filepath.Join(root, "mod", "go.mod"), "module example.com/mod\n")
+	writeFile(t, filepath.Join(root, "other", "go.mod"), "module example.com/other\n")
+	writeFile(t, filepath.Join(root, "other", FileName), "disable: [GCL1001]")
+
+	opts, err := Find(filepath.Join(root, "other", "pkg", "inner"))
+	require.NoError(t, err)
+	require.NotNil(t, opts)
+	assert.Equal(t, filepath.Join(root, "other", FileName), opts.Path)
+
+	opts, err = Find(filepath.Join(root, "mod", "pkg"))
+	require.NoError(t, err)
+	assert.Nil(t, opts, "a file above the module root belongs to another project")
+}
@@ -46,10 +46,15 @@ func run(pass *analysis.Pass) (any, error) {
 		(*ast.ValueSpec)(nil),
 		(*ast.AssignStmt)(nil),
 		(*ast.CallExpr)(nil),
+		(*ast.GoStmt)(nil),
 	}
 
+	// Preorder visits a GoStmt before its CallExpr, so the call is known to
+	// start a goroutine by the time its arguments are checked.
+	goCalls := make(map[*ast.CallExpr]bool)
+	decls := funcDecls(pass)
 	insp.Preorder(nodeFilter, func(n ast.Node) {
-		if files.IsGenerated(pass.Fset.File(n.Pos())) {
+		if files.Skips(pass.Fset, n.Pos()) {
 			return
 		}
 		switch node := n.(type) {
@@ -61,8 +66,10 @@ func run(pass *analysis.Pass) (any, error) {
 			reportValueSpec(node, pass, ec)
 		case *ast.AssignStmt:
 			reportAssignments(node, pass, ec)
+		case *ast.GoStmt:
+			goCalls[node.Call] = true
 		case *ast.CallExpr:
-			reportArgs(node, pass, ec)
+			reportArgs(node, pass, ec, goCalls[node], decls)
 		}
 	})
 
@@ -123,14 +130,27 @@ func reportAssignments(assign *ast.AssignStmt, pass *analysis.Pass, ec report.Re
 	}
 }
 
-func reportArgs(call *ast.CallExpr, pass *analysis.Pass, ec report.Reporter) {
+// reportArgs flags primitives passed by value. goroutine marks the call of a
+// go statement, whose arguments are copied into the new goroutine. A
+// WaitGroup whose copy the callee calls Done on gets a message naming that
+// consequence, since the balance checks treat the hand-off as a release.
+func reportArgs(call *ast.CallExpr, pass *analysis.Pass, ec report.Reporter, goroutine bool, decls map[*types.Func]*ast.FuncDecl) {
 	if call == nil {
 		return
 	}
-	for _, arg := range call.Args {
-		if kind, name, ok := copiedPrimitive(arg, pass); ok {
-			ec.AddError(arg.Pos(), category.SyncPrimitiveCopy, message(kind, name))
+	for i, arg := range call.Args {
+		kind, name, ok := copiedPrimitive(arg, pass)
+		if !ok {
+			continue
+		}
+		msg := message(kind, name)
+		if goroutine {
+			msg = goroutineMessage(kind, name)
 		}
+		if kind == "waitgroup" && calleeCallsDoneOnParam(call, i, pass, decls) {
+			msg = "waitgroup '" + name + "' passed by value; Done on copy has no effect"
+		}
+		ec.AddError(arg.Pos(), category.SyncPrimitiveCopy, msg)
 	}
 }
 
@@ -247,3 +267,12 @@ func message(kind, name string) string {
 	}
 	return kind + " '" + name + "' is copied by value"
 }
+
+// goroutineMessage is message for a value handed to `go f(x)`: the goroutine
+// works on its own copy, so the caller's primitive is never touched.
+func goroutineMessage(kind, name string) string {
+	if contained, ok := strings.CutPrefix(kind, "struct containing "); ok {
+		return "struct '" + name + "' containing " + contained + " copied into goroutine by value"
+	}
+	return kind + " '" + name + "' copied into goroutine by value"
+}
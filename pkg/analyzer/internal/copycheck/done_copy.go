@@ -0,0 +1,94 @@
+package copycheck
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// funcDecls maps the package's functions and methods to their declarations,
+// so a call's callee body can be inspected.
+func funcDecls(pass *analysis.Pass) map[*types.Func]*ast.FuncDecl {
+	decls := make(map[*types.Func]*ast.FuncDecl)
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+			if obj, ok := pass.TypesInfo.Defs[fn.Name].(*types.Func); ok {
+				decls[obj] = fn
+			}
+		}
+	}
+	return decls
+}
+
+// calleeCallsDoneOnParam reports whether the function called by call is a
+// literal or a package-level declaration whose i-th parameter has Done called
+// on it. A WaitGroup passed by value there is released on the copy only, so
+// the caller's Wait never returns.
+func calleeCallsDoneOnParam(call *ast.CallExpr, i int, pass *analysis.Pass, decls map[*types.Func]*ast.FuncDecl) bool {
+	var ftype *ast.FuncType
+	var body *ast.BlockStmt
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.FuncLit:
+		ftype, body = fun.Type, fun.Body
+	default:
+		decl := decls[typeutil.StaticCallee(pass.TypesInfo, call)]
+		if decl == nil {
+			return false
+		}
+		ftype, body = decl.Type, decl.Body
+	}
+
+	param := paramAt(ftype, i, pass.TypesInfo)
+	if param == nil {
+		return false
+	}
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		c, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := c.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Done" {
+			return true
+		}
+		if id, ok := ast.Unparen(sel.X).(*ast.Ident); ok && pass.TypesInfo.Uses[id] == param {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// paramAt returns the object of the i-th declared parameter, or nil when the
+// index falls past the named parameters.
+func paramAt(ftype *ast.FuncType, i int, info *types.Info) types.Object {
+	if ftype == nil || ftype.Params == nil {
+		return nil
+	}
+	for _, field := range ftype.Params.List {
+		if len(field.Names) == 0 {
+			if i == 0 {
+				return nil
+			}
+			i--
+			continue
+		}
+		for _, name := range field.Names {
+			if i == 0 {
+				return info.Defs[name]
+			}
+			i--
+		}
+	}
+	return nil
+}
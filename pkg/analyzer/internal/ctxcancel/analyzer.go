@@ -0,0 +1,151 @@
+// Package ctxcancel detects context cancel functions that are never called.
+package ctxcancel
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/report"
+)
+
+// Checker reports the cancel function of context.WithCancel, WithTimeout,
+// WithDeadline (and their Cause variants) when the function never calls it,
+// defers it or hands it off. The derived context, and the timer behind a
+// deadline, then live until the parent is cancelled. It mirrors the
+// Add/Done and Lock/Unlock balance checks for a context's lifetime.
+type Checker struct {
+	errorCollector report.Reporter
+	typesInfo      *types.Info
+}
+
+// NewChecker creates a context cancel checker. typesInfo is the pass-wide type
+// information used to confirm a call really is one of the context
+// constructors and to resolve every use of the cancel variable.
+func NewChecker(errorCollector report.Reporter, typesInfo *types.Info) *Checker {
+	return &Checker{
+		errorCollector: errorCollector,
+		typesInfo:      typesInfo,
+	}
+}
+
+// cancelUse tallies how a cancel variable is used in its function.
+type cancelUse struct {
+	def     *ast.Ident
+	called  bool
+	escaped bool
+}
+
+// CheckFunc flags cancel functions declared in fn (GCL7001) that are neither
+// called nor deferred on any path. A cancel that is returned, passed to a
+// function, stored or otherwise used as a value is someone else's to call, so
+// only calls, assignments to it and the `_ = cancel` silencer are accounted.
+func (c *Checker) CheckFunc(fn *ast.FuncDecl) {
+	if fn.Body == nil {
+		return
+	}
+	uses := c.cancelVars(fn.Body)
+	if len(uses) == 0 {
+		return
+	}
+
+	// Identifiers whose use does not count as an escape: call targets mark
+	// the cancel as called, assignment targets and blank-assigned values are
+	// neutral.
+	neutral := make(map[*ast.Ident]bool)
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.CallExpr:
+			if ident, ok := common.UnwrapParenExpr(node.Fun).(*ast.Ident); ok {
+				if use, ok := uses[c.typesInfo.ObjectOf(ident)]; ok {
+					use.called = true
+					neutral[ident] = true
+				}
+			}
+		case *ast.AssignStmt:
+			for i, lhs := range node.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok {
+					neutral[ident] = true
+				}
+				if len(node.Lhs) == len(node.Rhs) && isBlank(lhs) {
+					if ident, ok := common.UnwrapParenExpr(node.Rhs[i]).(*ast.Ident); ok {
+						neutral[ident] = true
+					}
+				}
+			}
+		}
+		return true
+	})
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		ident, ok := n.(*ast.Ident)
+		if !ok || neutral[ident] {
+			return true
+		}
+		if use, ok := uses[c.typesInfo.Uses[ident]]; ok {
+			use.escaped = true
+		}
+		return true
+	})
+
+	for _, use := range uses {
+		if use.called || use.escaped {
+			continue
+		}
+		c.errorCollector.AddError(use.def.Pos(), category.CancelNotCalled,
+			"context cancel function '"+use.def.Name+"' never called (leak)")
+	}
+}
+
+// cancelVars collects the local variables bound to the cancel result of a
+// context constructor, keyed by object and remembering the first binding.
+func (c *Checker) cancelVars(body *ast.BlockStmt) map[types.Object]*cancelUse {
+	uses := make(map[types.Object]*cancelUse)
+	record := func(lhs ast.Expr, rhs ast.Expr) {
+		ident, ok := lhs.(*ast.Ident)
+		if !ok || ident.Name == "_" || !c.isContextConstructor(rhs) {
+			return
+		}
+		obj := c.typesInfo.ObjectOf(ident)
+		if obj == nil || !common.IsCancelFunc(obj.Type()) {
+			return
+		}
+		if _, seen := uses[obj]; !seen {
+			uses[obj] = &cancelUse{def: ident}
+		}
+	}
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			if len(node.Lhs) == 2 && len(node.Rhs) == 1 {
+				record(node.Lhs[1], node.Rhs[0])
+			}
+		case *ast.ValueSpec:
+			if len(node.Names) == 2 && len(node.Values) == 1 {
+				record(node.Names[1], node.Values[0])
+			}
+		}
+		return true
+	})
+	return uses
+}
+
+// isContextConstructor reports whether expr calls a function of the context
+// package, such as context.WithCancel or context.WithTimeout.
+func (c *Checker) isContextConstructor(expr ast.Expr) bool {
+	call, ok := common.UnwrapParenExpr(expr).(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := common.UnwrapParenExpr(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	fn, ok := c.typesInfo.ObjectOf(sel.Sel).(*types.Func)
+	return ok && fn.Pkg() != nil && fn.Pkg().Path() == "context"
+}
+
+func isBlank(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == "_"
+}
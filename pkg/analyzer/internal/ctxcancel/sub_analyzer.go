@@ -0,0 +1,33 @@
+package ctxcancel
+
+import (
+	"go/ast"
+	"reflect"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/report"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/driver"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/filesetup"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+)
+
+// SubAnalyzer drives the context cancellation checks as an independent
+// analysis.Analyzer. Like the other sub-analyzers it returns its diagnostics as
+// Result so the umbrella Analyzer re-emits them.
+//
+// A cancel function is tracked within the function that declares it, and no
+// sync primitive marks the functions worth checking, so every function
+// declaration is checked.
+var SubAnalyzer = &analysis.Analyzer{
+	Name:       "goconcurrencylint_ctxcancel",
+	Doc:        "Detects cancel functions from context.WithCancel, WithTimeout and WithDeadline that are never called, leaking the derived context.",
+	Run:        run,
+	Requires:   []*analysis.Analyzer{inspect.Analyzer, filesetup.Analyzer},
+	ResultType: reflect.TypeFor[[]analysis.Diagnostic](),
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	return driver.RunFuncs(pass, func(reporter report.Reporter) func(*ast.FuncDecl) {
+		return NewChecker(reporter, pass.TypesInfo).CheckFunc
+	})
+}
@@ -1,16 +1,18 @@
 // Package driver provides the shared skeleton for sub-analyzer run functions.
 //
 // Both the mutex and waitgroup sub-analyzers follow the same per-function
-// visitation pattern: Preorder over *ast.FuncDecl, skip synthetic/generated
-// bodies, build a FunctionResult via primitives.ForFunction, apply a guard to
-// decide whether the function is relevant, construct a checker, call
-// AnalyzeFunction, and finally return the collected diagnostics. This package
-// captures that skeleton so each sub-analyzer can reduce its run function to a
-// single call.
+// visitation pattern: Preorder over *ast.FuncDecl, skip synthetic, generated
+// or excluded bodies, build a FunctionResult via primitives.ForFunction, apply
+// a guard to decide whether the function is relevant, construct a checker,
+// call AnalyzeFunction, and finally return the collected diagnostics. This
+// package captures that skeleton so each sub-analyzer can reduce its run
+// function to a single call. RunFuncs does the same for sub-analyzers with no
+// primitive to guard on, and RunPackage for the checks that span the package.
 package driver
 
 import (
 	"go/ast"
+	"go/types"
 
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/commentfilter"
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/report"
@@ -21,6 +23,11 @@ import (
 	"golang.org/x/tools/go/ast/inspector"
 )
 
+// ExportedOnly restricts Run to exported functions and to exported methods
+// of exported receiver types. It is bound to the umbrella analyzer's
+// -exported-only flag so a gradual rollout can lint the API surface first.
+var ExportedOnly bool
+
 // FunctionChecker is the minimal interface that every per-function checker
 // must satisfy. AnalyzeFunction is called once per relevant function
 // declaration after the checker has been constructed by Config.NewChecker.
@@ -64,8 +71,10 @@ func Run[C FunctionChecker](pass *analysis.Pass, cfg Config[C]) (any, error) {
 		if fn.Body == nil {
 			return
 		}
-		tokFile := pass.Fset.File(fn.Pos())
-		if files.IsGenerated(tokFile) {
+		if ExportedOnly && !isExportedFunc(fn) {
+			return
+		}
+		if files.Skips(pass.Fset, fn.Pos()) {
 			return
 		}
 
@@ -74,10 +83,105 @@ func Run[C FunctionChecker](pass *analysis.Pass, cfg Config[C]) (any, error) {
 			return
 		}
 
-		cf := files.FilterFor(tokFile)
+		cf := files.FilterFor(pass.Fset.File(fn.Pos()))
 		c := cfg.NewChecker(fr, ec, cf, pass)
 		c.AnalyzeFunction(fn)
 	})
 
 	return ec.Diagnostics(pass, files.IgnoreFunc()), nil
 }
+
+// RunFuncs hands every function declaration outside generated or excluded
+// files to the check newCheck builds on the pass's reporter, and returns the
+// collected diagnostics. It serves the sub-analyzers that track their own
+// values inside a function, with no primitive for Run's guard to look for.
+func RunFuncs(pass *analysis.Pass, newCheck func(reporter report.Reporter) func(fn *ast.FuncDecl)) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	files := pass.ResultOf[filesetup.Analyzer].(*filesetup.Result)
+	ec := &report.ErrorCollector{}
+	check := newCheck(ec)
+
+	insp.Preorder([]ast.Node{(*ast.FuncDecl)(nil)}, func(n ast.Node) {
+		if files.Skips(pass.Fset, n.Pos()) {
+			return
+		}
+		check(n.(*ast.FuncDecl))
+	})
+
+	return ec.Diagnostics(pass, files.IgnoreFunc()), nil
+}
+
+// Package is what a package-wide check sees of the pass: the type
+// information, the sync forks whose primitives count as well, and the
+// reporter its findings go to.
+type Package struct {
+	Info         *types.Info
+	SyncPackages []string
+	Reporter     report.Reporter
+
+	files []*ast.File
+	skip  func(*ast.File) bool
+}
+
+// Inspect walks every file of the package in order, as ast.Inspect does,
+// telling visit whether the file n sits in is generated or excluded. A check
+// may still learn from a skipped file, but reports nothing in it.
+func (p *Package) Inspect(visit func(n ast.Node, skipped bool) bool) {
+	for _, file := range p.files {
+		skipped := p.skip(file)
+		ast.Inspect(file, func(n ast.Node) bool { return visit(n, skipped) })
+	}
+}
+
+// RunPackage runs checks whose findings span functions, such as the ones on
+// struct fields locked or added to in one method and released in another,
+// over the whole package. It is meant to follow Run, once the per-function
+// checks are done, and returns the collected diagnostics.
+func RunPackage(pass *analysis.Pass, checks ...func(*Package)) []analysis.Diagnostic {
+	files := pass.ResultOf[filesetup.Analyzer].(*filesetup.Result)
+	ec := &report.ErrorCollector{}
+	pkg := &Package{
+		Info:         pass.TypesInfo,
+		SyncPackages: pass.ResultOf[primitives.Analyzer].(*primitives.Result).SyncPackages,
+		Reporter:     ec,
+		files:        pass.Files,
+		skip:         func(file *ast.File) bool { return files.IsSkipped(pass.Fset.File(file.Pos())) },
+	}
+	for _, check := range checks {
+		check(pkg)
+	}
+	return ec.Diagnostics(pass, files.IgnoreFunc())
+}
+
+// isExportedFunc reports whether fn is part of the package's API surface: an
+// exported function, or an exported method whose receiver type is exported.
+func isExportedFunc(fn *ast.FuncDecl) bool {
+	if !ast.IsExported(fn.Name.Name) {
+		return false
+	}
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return true
+	}
+	return ast.IsExported(receiverTypeName(fn.Recv.List[0].Type))
+}
+
+// receiverTypeName returns the base type name of a receiver expression,
+// peeling pointers and generic instantiations (*T, T[K], *T[K, V]).
+func receiverTypeName(expr ast.Expr) string {
+	for {
+		switch e := expr.(type) {
+		case *ast.ParenExpr:
+			expr = e.X
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.IndexListExpr:
+			expr = e.X
+		case *ast.Ident:
+			return e.Name
+		default:
+			return ""
+		}
+	}
+}
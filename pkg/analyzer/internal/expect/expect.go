@@ -0,0 +1,175 @@
+// Package expect is a test harness for checks written against inline
+// sources. Instead of analysistest's `// want` regexps, which repeat the
+// message text, the offending line carries a compact directive naming the
+// diagnostics it expects:
+//
+//	mu.Lock() // goconcurrencylint:expect mutex-leak
+//	wg.Add(1) // goconcurrencylint:expect GCL2001,wg-add-without-wait
+//
+// Each entry is a short code from Codes, which pins both the check and the
+// wording of its message, or a canonical code or legacy slug from the
+// category registry, which pins the check alone. Check matches the directives
+// against the findings analyzer.RunOnSource returns for the same source.
+package expect
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/report"
+)
+
+const directive = "goconcurrencylint:expect"
+
+// ShortCode ties a short expectation code to the check it reports under and
+// the id of the message template its diagnostic is rendered from.
+type ShortCode struct {
+	Category  category.Category
+	MessageID string
+}
+
+// Codes is the registry of short expectation codes.
+var Codes = map[string]ShortCode{
+	"mutex-leak":                    {category.LockWithoutUnlock, "mutex.locked_not_unlocked"},
+	"mutex-unlock-without-lock":     {category.UnlockWithoutLock, "mutex.unlocked_not_locked"},
+	"rwmutex-rlock-leak":            {category.LockWithoutUnlock, "rwmutex.rlocked_not_runlocked"},
+	"rwmutex-runlock-without-rlock": {category.UnlockWithoutLock, "rwmutex.runlocked_not_rlocked"},
+	"wg-add-without-done":           {category.AddWithoutDone, "waitgroup.add_without_done"},
+	"wg-done-without-add":           {category.DoneWithoutAdd, "waitgroup.done_without_add"},
+	"wg-wait-without-add":           {category.WaitWithoutAdd, "waitgroup.wait_without_add"},
+	"wg-add-without-wait":           {category.AddWithoutWait, "waitgroup.add_without_wait"},
+	"wg-wait-not-guaranteed":        {category.WaitNotGuaranteed, "waitgroup.wait_not_guaranteed"},
+}
+
+// Expectation is one entry of an expect directive.
+type Expectation struct {
+	Pos      token.Position
+	Code     string
+	Category category.Category
+	// Message matches the whole diagnostic message; nil when Code names a
+	// check rather than a short code.
+	Message *regexp.Regexp
+}
+
+// Diagnostic is a reported diagnostic as Match sees it: the line it is on,
+// its check code and its message without the "<code>: " prefix, as the
+// analyzer's findings carry them.
+type Diagnostic struct {
+	Line     int
+	Category string
+	Message  string
+}
+
+// Check parses the expect directives of src, the source given to
+// analyzer.RunOnSource, and fails t for every expectation without a
+// matching entry of got and every entry no expectation claims.
+func Check(t testing.TB, src string, got []Diagnostic) {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "source.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := Parse(fset, file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, problem := range Match(want, got) {
+		t.Error(problem)
+	}
+}
+
+// Parse collects the expect directives of file. An entry that is neither a
+// short code nor a known check is an error.
+func Parse(fset *token.FileSet, file *ast.File) ([]Expectation, error) {
+	var out []Expectation
+	for _, group := range file.Comments {
+		for _, c := range group.List {
+			text := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+			rest, ok := strings.CutPrefix(text, directive)
+			if !ok {
+				continue
+			}
+			pos := fset.Position(c.Pos())
+			codes := strings.FieldsFunc(rest, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+			if len(codes) == 0 {
+				return nil, fmt.Errorf("%s: %s names no diagnostic", pos, directive)
+			}
+			for _, code := range codes {
+				exp, err := expectation(pos, code)
+				if err != nil {
+					return nil, err
+				}
+				out = append(out, exp)
+			}
+		}
+	}
+	return out, nil
+}
+
+func expectation(pos token.Position, code string) (Expectation, error) {
+	if short, ok := Codes[code]; ok {
+		return Expectation{Pos: pos, Code: code, Category: short.Category, Message: messagePattern(short.MessageID)}, nil
+	}
+	if cat, ok := category.Canonical(code); ok {
+		return Expectation{Pos: pos, Code: code, Category: cat}, nil
+	}
+	return Expectation{}, fmt.Errorf("%s: unknown expectation %q", pos, code)
+}
+
+// placeholder stands in for each template argument so the rendered message
+// can be turned into a pattern; it cannot occur in a template.
+const placeholder = "\x00"
+
+// messagePattern renders the message template id, honoring any override in
+// report.MessageTemplates, with every argument matching any text.
+func messagePattern(id string) *regexp.Regexp {
+	args := make([]any, report.MessageArgs(id))
+	for i := range args {
+		args[i] = placeholder
+	}
+	parts := strings.Split(report.FormatMessage(id, args...), placeholder)
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.MustCompile("^" + strings.Join(parts, ".+") + "$")
+}
+
+// Match pairs each expectation with a diagnostic of its category on the same
+// line and returns a description of every expectation and diagnostic left
+// unpaired, sorted. want and diags describe a single file.
+func Match(want []Expectation, diags []Diagnostic) []string {
+	used := make([]bool, len(diags))
+	var problems []string
+	for _, exp := range want {
+		found := false
+		for i, d := range diags {
+			if used[i] || category.Category(d.Category) != exp.Category || d.Line != exp.Pos.Line {
+				continue
+			}
+			if exp.Message != nil && !exp.Message.MatchString(d.Message) {
+				continue
+			}
+			used[i], found = true, true
+			break
+		}
+		if !found {
+			problems = append(problems, fmt.Sprintf("%d: no diagnostic matching %s", exp.Pos.Line, exp.Code))
+		}
+	}
+	for i, d := range diags {
+		if !used[i] {
+			problems = append(problems, fmt.Sprintf("%d: unexpected diagnostic [%s] %s", d.Line, d.Category, d.Message))
+		}
+	}
+	sort.Strings(problems)
+	return problems
+}
@@ -0,0 +1,57 @@
+package expect
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodesUseKnownChecksAndMessages(t *testing.T) {
+	for code, short := range Codes {
+		_, ok := category.Lookup(short.Category)
+		assert.True(t, ok, "%s: unknown check %s", code, short.Category)
+		assert.NotPanics(t, func() { messagePattern(short.MessageID) }, "%s: unknown message id", code)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", `package p
+
+func f() {
+	a() // goconcurrencylint:expect mutex-leak
+	b() // goconcurrencylint:expect GCL2001
+	c()
+}
+`, parser.ParseComments)
+	require.NoError(t, err)
+	want, err := Parse(fset, file)
+	require.NoError(t, err)
+	require.Len(t, want, 2)
+
+	diags := []Diagnostic{
+		{Line: 4, Category: string(category.LockWithoutUnlock), Message: "mutex 'mu' is locked but not unlocked"},
+		{Line: 5, Category: string(category.AddWithoutDone), Message: "any wording"},
+	}
+	assert.Empty(t, Match(want, diags))
+
+	diags[0].Message = "mutex 'mu' is unlocked but not locked"
+	diags = append(diags, Diagnostic{Line: 6, Category: string(category.DoubleLock), Message: "extra"})
+	assert.Equal(t, []string{
+		"4: no diagnostic matching mutex-leak",
+		"4: unexpected diagnostic [GCL1001] mutex 'mu' is unlocked but not locked",
+		"6: unexpected diagnostic [GCL1011] extra",
+	}, Match(want, diags))
+}
+
+func TestParseRejectsUnknownCodes(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", "package p // goconcurrencylint:expect no-such-check\n", parser.ParseComments)
+	require.NoError(t, err)
+	_, err = Parse(fset, file)
+	assert.ErrorContains(t, err, `unknown expectation "no-such-check"`)
+}
@@ -1,21 +1,29 @@
 // Package filesetup runs the per-file bookkeeping that every sub-analyzer
-// would otherwise repeat: identifying generated files (so they can be
-// skipped) and building one CommentFilter per source file (so inline
+// would otherwise repeat: identifying generated, -exclude'd and (with
+// -skip-tests) _test.go files so they can be skipped, finding the functions
+// -max-stmts skips, and building one CommentFilter per source file (so inline
 // //nolint-style directives can be consulted at report time).
 //
 // It exists as its own analysis.Analyzer so the work happens once per
-// package. The mutex, waitgroup and copycheck sub-analyzers declare it in
-// their Requires and consume *Result via pass.ResultOf[filesetup.Analyzer].
+// package. Every sub-analyzer, and the callscan pass they share, declares it
+// in its Requires and consumes *Result via pass.ResultOf[filesetup.Analyzer].
 package filesetup
 
 import (
+	"fmt"
+	"go/ast"
 	"go/token"
+	"log"
+	"path"
+	"path/filepath"
 	"reflect"
+	"strings"
 
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/commentfilter"
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/report"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/config"
 	"golang.org/x/tools/go/analysis"
 )
 
@@ -23,27 +31,70 @@ import (
 // Generated is keyed by *token.File so callers can test membership with
 // the token.File they already have from pass.Fset.File(pos). Filters is
 // keyed by the file name (the same string CommentFilter exposes via
-// FileName) so it can be looked up from a diagnostic's filename.
+// FileName) so it can be looked up from a diagnostic's filename. Excluded
+// holds the files matched by Exclude or SkipTests, keyed like Generated.
 type Result struct {
 	Generated map[*token.File]struct{}
+	Excluded  map[*token.File]struct{}
 	Filters   map[string]*commentfilter.CommentFilter
+
+	// oversized holds the functions skipped under MaxStmts.
+	oversized []*ast.FuncDecl
 }
 
+// Exclude is a comma-separated list of path.Match globs naming files to skip
+// entirely. It is bound to the umbrella analyzer's -exclude flag; when it is
+// empty the exclude list of .goconcurrencylint.yaml applies instead.
+var Exclude string
+
+// SkipTests skips every _test.go file, as if it were listed in Exclude. It is
+// bound to the umbrella analyzer's -skip-tests flag.
+var SkipTests bool
+
+// MaxStmts skips functions whose body holds more than this many statements,
+// bounding analysis time on huge generated or table-driven functions. Zero
+// disables the limit. It is bound to the umbrella analyzer's -max-stmts flag.
+//
+// Every per-function and per-node check consults Skips and so ignores the
+// skipped functions. The package-wide field checks (mutex pointer fields,
+// WaitGroup field balance) still see them: they tally uses across every
+// function, and dropping one function's share would unbalance the tally.
+var MaxStmts int
+
 // Analyzer computes Result once per package.
 var Analyzer = &analysis.Analyzer{
 	Name:       "goconcurrencylint_filesetup",
-	Doc:        "Identifies generated files and builds per-file CommentFilters reused by the sync sub-analyzers.",
+	Doc:        "Identifies generated files and builds per-file CommentFilters reused by every sub-analyzer.",
 	Run:        run,
 	ResultType: reflect.TypeFor[*Result](),
 }
 
 func run(pass *analysis.Pass) (any, error) {
+	list := Exclude
+	if list == "" {
+		opts, err := config.ForPass(pass)
+		if err != nil {
+			return nil, err
+		}
+		if opts != nil {
+			list = strings.Join(opts.Exclude, ",")
+		}
+	}
+	globs, err := excludeGlobs(list)
+	if err != nil {
+		return nil, err
+	}
 	res := &Result{
 		Generated: make(map[*token.File]struct{}),
+		Excluded:  make(map[*token.File]struct{}),
 		Filters:   make(map[string]*commentfilter.CommentFilter, len(pass.Files)),
 	}
 	for _, file := range pass.Files {
 		tokFile := pass.Fset.File(file.Pos())
+		if tokFile != nil && (matchesAny(globs, tokFile.Name()) || SkipTests && isTestFile(tokFile.Name())) {
+			res.Excluded[tokFile] = struct{}{}
+			continue
+		}
 		if common.IsGeneratedFile(file) {
 			if tokFile != nil {
 				res.Generated[tokFile] = struct{}{}
@@ -54,10 +105,46 @@ func run(pass *analysis.Pass) (any, error) {
 		if name := cf.FileName(); name != "" {
 			res.Filters[name] = cf
 		}
+		if MaxStmts > 0 {
+			res.oversized = appendOversized(res.oversized, pass.Fset, file)
+		}
 	}
 	return res, nil
 }
 
+// appendOversized appends the functions of file whose body exceeds MaxStmts
+// to funcs, logging each one. The package is set up once, so every skip is
+// logged once however many sub-analyzers honor it.
+func appendOversized(funcs []*ast.FuncDecl, fset *token.FileSet, file *ast.File) []*ast.FuncDecl {
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		if n := countStmts(fn.Body); n > MaxStmts {
+			log.Printf("goconcurrencylint: skipping %s at %s: %d statements exceed -max-stmts=%d",
+				fn.Name.Name, fset.Position(fn.Pos()), n, MaxStmts)
+			funcs = append(funcs, fn)
+		}
+	}
+	return funcs
+}
+
+// countStmts returns the number of statements in body, including those in
+// nested blocks and function literals. Bare blocks are not counted themselves.
+func countStmts(body *ast.BlockStmt) int {
+	n := 0
+	ast.Inspect(body, func(node ast.Node) bool {
+		if _, ok := node.(ast.Stmt); ok {
+			if _, block := node.(*ast.BlockStmt); !block {
+				n++
+			}
+		}
+		return true
+	})
+	return n
+}
+
 // IsGenerated reports whether tokFile is a generated file scanned at setup
 // time. A nil tokFile is treated as not-generated.
 func (r *Result) IsGenerated(tokFile *token.File) bool {
@@ -68,6 +155,80 @@ func (r *Result) IsGenerated(tokFile *token.File) bool {
 	return ok
 }
 
+// IsSkipped reports whether tokFile is generated or excluded by -exclude or
+// -skip-tests, so no diagnostics should be produced for it.
+func (r *Result) IsSkipped(tokFile *token.File) bool {
+	if r.IsGenerated(tokFile) {
+		return true
+	}
+	if r == nil || tokFile == nil {
+		return false
+	}
+	_, ok := r.Excluded[tokFile]
+	return ok
+}
+
+// Skips reports whether pos lies in a file IsSkipped reports or inside a
+// function skipped for exceeding MaxStmts.
+func (r *Result) Skips(fset *token.FileSet, pos token.Pos) bool {
+	if r.IsSkipped(fset.File(pos)) {
+		return true
+	}
+	if r == nil {
+		return false
+	}
+	for _, fn := range r.oversized {
+		if fn.Pos() <= pos && pos < fn.End() {
+			return true
+		}
+	}
+	return false
+}
+
+// isTestFile reports whether filename is a Go test file.
+func isTestFile(filename string) bool {
+	return strings.HasSuffix(filename, "_test.go")
+}
+
+// excludeGlobs splits the -exclude list and validates each glob.
+func excludeGlobs(list string) ([]string, error) {
+	var globs []string
+	for _, glob := range strings.Split(list, ",") {
+		glob = strings.TrimSpace(glob)
+		if glob == "" {
+			continue
+		}
+		if _, err := path.Match(glob, ""); err != nil {
+			return nil, fmt.Errorf("-exclude: invalid glob %q: %w", glob, err)
+		}
+		globs = append(globs, glob)
+	}
+	return globs, nil
+}
+
+// matchesAny reports whether filename, or any trailing run of its path
+// elements, matches one of globs. A glob therefore matches a base name
+// ("*_gen.go") or a path suffix ("testdata/*/*.go") without spelling out
+// the absolute prefix.
+func matchesAny(globs []string, filename string) bool {
+	if len(globs) == 0 {
+		return false
+	}
+	name := filepath.ToSlash(filename)
+	for {
+		for _, glob := range globs {
+			if ok, _ := path.Match(glob, name); ok {
+				return true
+			}
+		}
+		i := strings.Index(name, "/")
+		if i < 0 {
+			return false
+		}
+		name = name[i+1:]
+	}
+}
+
 // FilterFor returns the CommentFilter for tokFile, or nil if the file has
 // no associated filter (generated files, files without a name, or a nil
 // tokFile).
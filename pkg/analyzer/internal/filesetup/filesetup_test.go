@@ -142,3 +142,70 @@ func N() {}
 	assert.NotNil(t, res.FilterFor(normalTokFile),
 		"hand-written file must have an associated CommentFilter")
 }
+
+func TestMatchesAny(t *testing.T) {
+	globs := []string{"*_gen.go", "vendor/*/*.go"}
+
+	assert.True(t, matchesAny(globs, "/src/app/model_gen.go"), "base name must match")
+	assert.True(t, matchesAny(globs, "/src/app/vendor/lib/x.go"), "path suffix must match")
+	assert.False(t, matchesAny(globs, "/src/app/model.go"))
+	assert.False(t, matchesAny(globs, "/src/app/vendor/lib/sub/x.go"), "* must not cross a path separator")
+	assert.False(t, matchesAny(nil, "/src/app/model_gen.go"))
+}
+
+func TestExcludeGlobs(t *testing.T) {
+	globs, err := excludeGlobs(" *_gen.go, ,vendor/*/*.go")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"*_gen.go", "vendor/*/*.go"}, globs)
+
+	_, err = excludeGlobs("[bad")
+	assert.Error(t, err, "malformed globs must be rejected")
+}
+
+// TestRunSkipsExcludedFiles verifies files matched by Exclude are recorded as
+// skipped and get no CommentFilter.
+func TestRunSkipsExcludedFiles(t *testing.T) {
+	Exclude = "skip_*.go"
+	t.Cleanup(func() { Exclude = "" })
+
+	fset := token.NewFileSet()
+	skipped, err := parser.ParseFile(fset, "/x/skip_me.go", "package p\n", parser.ParseComments)
+	require.NoError(t, err)
+	kept, err := parser.ParseFile(fset, "/x/keep.go", "package p\n", parser.ParseComments)
+	require.NoError(t, err)
+
+	raw, err := Analyzer.Run(&analysis.Pass{Fset: fset, Files: []*ast.File{skipped, kept}})
+	require.NoError(t, err)
+	res := raw.(*Result)
+
+	assert.True(t, res.IsSkipped(fset.File(skipped.Pos())))
+	assert.False(t, res.IsGenerated(fset.File(skipped.Pos())), "excluded files are not generated")
+	assert.False(t, res.IsSkipped(fset.File(kept.Pos())))
+	assert.Nil(t, res.FilterFor(fset.File(skipped.Pos())))
+	assert.False(t, (*Result)(nil).IsSkipped(fset.File(kept.Pos())), "nil receiver must be safe")
+}
+
+// TestRunSkipsTestFilesWithSkipTests verifies SkipTests marks _test.go files
+// as skipped and leaves them analyzed when unset.
+func TestRunSkipsTestFilesWithSkipTests(t *testing.T) {
+	fset := token.NewFileSet()
+	testFile, err := parser.ParseFile(fset, "/x/p_test.go", "package p\n", parser.ParseComments)
+	require.NoError(t, err)
+	source, err := parser.ParseFile(fset, "/x/p.go", "package p\n", parser.ParseComments)
+	require.NoError(t, err)
+	pass := &analysis.Pass{Fset: fset, Files: []*ast.File{testFile, source}}
+
+	raw, err := Analyzer.Run(pass)
+	require.NoError(t, err)
+	assert.False(t, raw.(*Result).IsSkipped(fset.File(testFile.Pos())), "test files are analyzed by default")
+
+	SkipTests = true
+	t.Cleanup(func() { SkipTests = false })
+
+	raw, err = Analyzer.Run(pass)
+	require.NoError(t, err)
+	res := raw.(*Result)
+	assert.True(t, res.IsSkipped(fset.File(testFile.Pos())))
+	assert.False(t, res.IsSkipped(fset.File(source.Pos())))
+	assert.Nil(t, res.FilterFor(fset.File(testFile.Pos())))
+}
@@ -0,0 +1,221 @@
+package mutex
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+)
+
+// reportAccessOutsideCriticalSection flags shared data touched after the mutex
+// that guards it was released (GCL1015). A variable counts as guarded by mu
+// when the function writes it while holding mu.Lock(); any later access in the
+// same block after mu.Unlock() is reported once. Only package variables and
+// struct fields are tracked, since locals copied under the lock are the
+// idiomatic way to use the data afterwards. Aliasing defeats the heuristic
+// both ways, so the check is opt-in.
+func (c *Checker) reportAccessOutsideCriticalSection(body *ast.BlockStmt) {
+	if c.rawBodyEffects || body == nil {
+		return
+	}
+	guarded := make(map[string]string)
+	forEachStmtList(body, func(list []ast.Stmt) {
+		c.collectGuardedWrites(list, guarded)
+	})
+	if len(guarded) == 0 {
+		return
+	}
+	reported := make(map[string]bool)
+	forEachStmtList(body, func(list []ast.Stmt) {
+		c.reportAccessesAfterUnlock(list, guarded, reported)
+	})
+}
+
+// forEachStmtList calls fn for every statement list in body, including the
+// bodies of case clauses and function literals.
+func forEachStmtList(body *ast.BlockStmt, fn func([]ast.Stmt)) {
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.BlockStmt:
+			fn(node.List)
+		case *ast.CaseClause:
+			fn(node.Body)
+		case *ast.CommClause:
+			fn(node.Body)
+		}
+		return true
+	})
+}
+
+// collectGuardedWrites records, for each shared variable written between a
+// Lock and its Unlock in list, the mutex held at the time.
+func (c *Checker) collectGuardedWrites(list []ast.Stmt, guarded map[string]string) {
+	held := make(map[string]bool)
+	for _, stmt := range list {
+		if _, varName, method, ok := c.mutexCallStatement(stmt); ok {
+			switch method {
+			case "Lock":
+				held[varName] = true
+			case "Unlock":
+				delete(held, varName)
+			}
+			continue
+		}
+		if len(held) == 0 {
+			continue
+		}
+		for _, key := range c.sharedWrites(stmt) {
+			if _, ok := guarded[key]; ok {
+				continue
+			}
+			for mu := range held {
+				guarded[key] = mu
+				break
+			}
+		}
+	}
+}
+
+// reportAccessesAfterUnlock reports the first access to each guarded variable
+// that follows an explicit Unlock of its mutex in list, until the mutex is
+// locked again. Statements that lock the mutex themselves are skipped.
+func (c *Checker) reportAccessesAfterUnlock(list []ast.Stmt, guarded map[string]string, reported map[string]bool) {
+	released := make(map[string]bool)
+	for _, stmt := range list {
+		if _, varName, method, ok := c.mutexCallStatement(stmt); ok {
+			switch method {
+			case "Lock":
+				delete(released, varName)
+			case "Unlock":
+				released[varName] = true
+			}
+			continue
+		}
+		if len(released) == 0 {
+			continue
+		}
+		c.forEachSharedAccess(stmt, func(key string, pos token.Pos) {
+			mu := guarded[key]
+			if !released[mu] || reported[key] || c.locksInside(stmt, mu) {
+				return
+			}
+			reported[key] = true
+			mutexType := "mutex"
+			if c.rwMutexNames[mu] {
+				mutexType = "rwmutex"
+			}
+			c.errorCollector.AddError(pos, category.AccessOutsideCriticalSection,
+				"variable '"+key+"' accessed outside "+mutexType+" '"+mu+"' critical section")
+		})
+	}
+}
+
+// sharedWrites returns the shared variables assigned or incremented anywhere
+// in stmt, outside function literals.
+func (c *Checker) sharedWrites(stmt ast.Stmt) []string {
+	var targets []ast.Expr
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		switch s := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.AssignStmt:
+			if s.Tok != token.DEFINE {
+				targets = append(targets, s.Lhs...)
+			}
+		case *ast.IncDecStmt:
+			targets = append(targets, s.X)
+		}
+		return true
+	})
+	var keys []string
+	for _, target := range targets {
+		if key, ok := c.sharedKey(writeRoot(target)); ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// writeRoot strips index and dereference expressions so `m[k] = v` and
+// `*p = v` count as writes to m and p.
+func writeRoot(expr ast.Expr) ast.Expr {
+	for {
+		switch e := common.UnwrapParenExpr(expr).(type) {
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.StarExpr:
+			expr = e.X
+		default:
+			return e
+		}
+	}
+}
+
+// forEachSharedAccess calls fn for every shared variable read or written in
+// stmt, outside function literals.
+func (c *Checker) forEachSharedAccess(stmt ast.Stmt, fn func(key string, pos token.Pos)) {
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.SelectorExpr, *ast.Ident:
+			if key, ok := c.sharedKey(node.(ast.Expr)); ok {
+				fn(key, node.Pos())
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// sharedKey names expr when it is a package-level variable or a field
+// selected from a variable, excluding the tracked mutexes themselves.
+func (c *Checker) sharedKey(expr ast.Expr) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		v, ok := c.typesInfo.ObjectOf(e).(*types.Var)
+		if !ok || v.Pkg() == nil || v.Parent() != v.Pkg().Scope() {
+			return "", false
+		}
+		if c.mutexNames[e.Name] || c.rwMutexNames[e.Name] {
+			return "", false
+		}
+		return e.Name, true
+	case *ast.SelectorExpr:
+		selection, ok := c.typesInfo.Selections[e]
+		if !ok || selection.Kind() != types.FieldVal {
+			return "", false
+		}
+		key := common.GetVarName(e)
+		if key == "?" || c.mutexNames[key] || c.rwMutexNames[key] {
+			return "", false
+		}
+		return key, true
+	}
+	return "", false
+}
+
+// locksInside reports whether stmt itself calls mu.Lock(), i.e. re-enters the
+// critical section somewhere inside a nested block.
+func (c *Checker) locksInside(stmt ast.Stmt, mu string) bool {
+	found := false
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		if _, ok := n.(*ast.FuncLit); ok {
+			return false
+		}
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Lock" && c.aliases.VarName(sel.X) == mu {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
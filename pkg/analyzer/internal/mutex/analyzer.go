@@ -10,6 +10,7 @@ import (
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/commentfilter"
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/report"
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/primitives"
+	"golang.org/x/tools/go/analysis"
 )
 
 // Checker handles the analysis of mutex and rwmutex usage.
@@ -29,6 +30,22 @@ type Checker struct {
 	termination     *terminationAnalyzer
 	loopCarry       *loopCarryAnalyzer
 
+	// aliases maps a local pointer to the mutex it was declared as the
+	// address of; see primitives.FunctionResult.MutexAliases.
+	aliases common.VarAliases
+
+	// goLaunches maps a go-wrapper or time.AfterFunc call statement to the
+	// go statement it stands for; see primitives.FunctionResult.GoLaunches.
+	goLaunches map[ast.Stmt]*ast.GoStmt
+
+	// syncPackages lists the sync forks configured for the package; see
+	// primitives.Result.SyncPackages.
+	syncPackages []string
+
+	// lockers maps the lock and unlock methods of configured custom locker
+	// types onto Lock and Unlock; nil when none are configured.
+	lockers *primitives.TypeMatcher
+
 	// explicitTransferCache is keyed by *ast.BlockStmt so it remains correct
 	// across functions; the cached map is shared by reference, callers must
 	// treat it as read-only.
@@ -38,6 +55,11 @@ type Checker struct {
 	// composite literals), shared by reference across functions; read-only.
 	lifecycleScanCache *lifecycleScanCache
 
+	// packageLockOrder collects the lock order edges of every analyzed
+	// function, shared by reference so cross-function cycles can be reported
+	// once the whole package has been visited.
+	packageLockOrder *packageLockOrder
+
 	// safeDeferBeforeLock holds the positions of defer statements whose deferred
 	// unlock is immediately balanced by a matching lock in the same block (see
 	// detectSafeDeferBeforeLock). Computed once per analyzed function and shared
@@ -53,6 +75,17 @@ type Checker struct {
 	// analyzed function (see detectCrossGoroutineDeferHandoff).
 	crossGoroutineDeferHandoff map[token.Pos]bool
 
+	// conditionalLockDefers maps the position of a `defer mu.Unlock()` to the
+	// Lock an earlier if takes on only some paths (see
+	// detectConditionalLockDefers). conditionalLocks holds those Lock
+	// positions, which are reported with their defer rather than on their own.
+	conditionalLockDefers map[token.Pos]token.Pos
+	conditionalLocks      map[token.Pos]bool
+
+	// loopRLocks maps each RLock in a loop body that never calls RUnlock on
+	// the same rwmutex to its name (see detectLoopRLocks).
+	loopRLocks map[token.Pos]string
+
 	*funcAnalysis
 }
 
@@ -98,6 +131,7 @@ type packageScope struct {
 	functions             []*ast.FuncDecl
 	explicitTransferCache map[*ast.BlockStmt]map[token.Pos]struct{}
 	scanCache             *lifecycleScanCache
+	lockOrder             *packageLockOrder
 }
 
 func newPackageScope(files []*ast.File) *packageScope {
@@ -106,6 +140,7 @@ func newPackageScope(files []*ast.File) *packageScope {
 		functions:             collectFunctionDecls(files),
 		explicitTransferCache: make(map[*ast.BlockStmt]map[token.Pos]struct{}),
 		scanCache:             newLifecycleScanCache(),
+		lockOrder:             newPackageLockOrder(),
 	}
 }
 
@@ -117,6 +152,10 @@ func NewChecker(fr *primitives.FunctionResult, errorCollector report.Reporter, c
 	c := &Checker{
 		mutexNames:            fr.Mutexes,
 		rwMutexNames:          fr.RWMutexes,
+		lockers:               fr.Lockers,
+		aliases:               fr.MutexAliases,
+		goLaunches:            fr.GoLaunches,
+		syncPackages:          fr.SyncPackages,
 		errorCollector:        errorCollector,
 		commentFilter:         cf,
 		typesInfo:             typesInfo,
@@ -125,26 +164,42 @@ func NewChecker(fr *primitives.FunctionResult, errorCollector report.Reporter, c
 		termination:           term,
 		explicitTransferCache: scope.explicitTransferCache,
 		lifecycleScanCache:    scope.scanCache,
+		packageLockOrder:      scope.lockOrder,
 	}
 	c.loopCarry = newLoopCarryAnalyzer(c.mutexNames, c.rwMutexNames, cf, errorCollector, term)
+	c.loopCarry.aliases = c.aliases
 	return c
 }
 
 func (c *Checker) AnalyzeFunction(fn *ast.FuncDecl) {
 	c.funcAnalysis = newFuncAnalysis(fn)
 	c.tryLock = newTryLockTracker(c.mutexNames, c.rwMutexNames, c.commentFilter, c.errorCollector)
+	c.tryLock.aliases = c.aliases
 	c.wrapper = newWrapperResolver(c.receiverMethods, c.function, c.rawBodyEffects, c.typesInfo)
 	c.lifecycle = newLifecycleResolver(c.receiverMethods, c.functions, c.typesInfo, c.explicitTransferCache, c.lifecycleScanCache, c.function)
 	c.panicDetector = newLockedPanicDetector(c.mutexNames, c.rwMutexNames, c.typesInfo, c.errorCollector, c.rawBodyEffects)
 	c.flagGuardedFlags = c.detectFlagGuardedReleaseFlags(fn)
+	c.heldOnReturn = commentfilter.HeldOnReturn(fn)
 	c.safeDeferBeforeLock = c.detectSafeDeferBeforeLock(fn)
 	c.crossGoroutineDeferHandoff = c.detectCrossGoroutineDeferHandoff(fn)
+	c.conditionalLockDefers = c.detectConditionalLockDefers(fn)
+	c.conditionalLocks = make(map[token.Pos]bool, len(c.conditionalLockDefers))
+	for _, lockPos := range c.conditionalLockDefers {
+		c.conditionalLocks[lockPos] = true
+	}
+	c.loopRLocks = c.detectLoopRLocks(fn)
 	c.stats = initialStats(c.mutexNames, c.rwMutexNames)
 	lockOrder := newLockOrderDetector(c.mutexNames, c.rwMutexNames, c.commentFilter, c.typesInfo, c.errorCollector)
+	lockOrder.aliases = c.aliases
+	lockOrder.observe = c.packageLockOrder.recorder(fn, c.typesInfo)
 	lockOrder.check(fn.Body)
 	finalStats := c.analyzeBlock(fn.Body, c.stats)
 	c.tryLock.reportUnchecked()
+	c.reportLoopRLocks()
 	c.reportUnmatchedLocks(finalStats)
+	c.reportAccessOutsideCriticalSection(fn.Body)
+	c.reportLockOnValueReceiver(fn)
+	c.reportNilPointerLocals(fn)
 }
 
 func relativeMutexPath(varName, prefix string) (string, bool) {
@@ -205,7 +260,7 @@ func (c *Checker) functionIsParameterUnlockHelper(varName string, acquireMethods
 		if !ok {
 			return true
 		}
-		if common.GetVarName(sel.X) != varName {
+		if c.aliases.VarName(sel.X) != varName {
 			return true
 		}
 		if _, ok := acquireSet[sel.Sel.Name]; ok {
@@ -278,7 +333,7 @@ func (c *Checker) simulateMethodEffect(fn *ast.FuncDecl, varName string, isRWMut
 	}
 
 	sub := newSimulationFuncAnalysis(fn, stack, c.localFuncStack)
-	simulated := c.forkForSimulation(sub, mutexNames, rwMutexNames)
+	simulated := c.forkForSimulation(sub, mutexNames, rwMutexNames, nil)
 
 	start := map[string]*Stats{varName: cloneStats(initial)}
 	final := simulated.analyzeBlock(fn.Body, start)
@@ -310,7 +365,7 @@ func (c *Checker) applyLocalFunctionLiteralLifecycleEffects(call *ast.CallExpr,
 	defer delete(stack, fnlit)
 
 	sub := newSimulationFuncAnalysis(c.function, c.simulationStack, stack)
-	simulated := c.forkForSimulation(sub, c.mutexNames, c.rwMutexNames)
+	simulated := c.forkForSimulation(sub, c.mutexNames, c.rwMutexNames, c.aliases)
 
 	baseline := cloneStatsMap(stats)
 	final := simulated.analyzeBlock(fnlit.Body, baseline)
@@ -596,3 +651,13 @@ func (s *Stats) removeFirstBorrowedRUnlockPos() {
 		s.borrowedRUnlockPos = s.borrowedRUnlockPos[1:]
 	}
 }
+
+// heldAt returns related information pointing at each acquisition still
+// recorded in positions, labelled "<kind> '<name>' <verb> here".
+func heldAt(positions []token.Pos, kind, name, verb string) []analysis.RelatedInformation {
+	related := make([]analysis.RelatedInformation, 0, len(positions))
+	for _, pos := range positions {
+		related = append(related, report.Related(pos, kind+" '"+name+"' "+verb+" here"))
+	}
+	return related
+}
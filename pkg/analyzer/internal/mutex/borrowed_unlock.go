@@ -44,7 +44,7 @@ func (c *Checker) lockAcquiredInCallbackArgument(mutexName string, lockMethods [
 			if !ok {
 				continue
 			}
-			if closureLocksMutex(fnlit.Body, mutexName, lockMethods) {
+			if bodyLocksMutex(c.aliases, fnlit.Body, mutexName, lockMethods) {
 				found = true
 				return false
 			}
@@ -63,13 +63,13 @@ func (c *Checker) isReadLockUpgrade(mutexName string) bool {
 	if c.function == nil || c.function.Body == nil {
 		return false
 	}
-	return functionBodyContainsFieldCall(c.function.Body, mutexName, WriteLockPattern.LockMethods) &&
-		functionBodyContainsFieldCall(c.function.Body, mutexName, ReadLockPattern.LockMethods)
+	return bodyLocksMutex(c.aliases, c.function.Body, mutexName, WriteLockPattern.LockMethods) &&
+		bodyLocksMutex(c.aliases, c.function.Body, mutexName, ReadLockPattern.LockMethods)
 }
 
-// closureLocksMutex reports whether body contains a `mutexName.<lockMethod>()`
+// bodyLocksMutex reports whether body contains a `mutexName.<lockMethod>()`
 // call for one of the supplied lock methods.
-func closureLocksMutex(body *ast.BlockStmt, mutexName string, lockMethods []string) bool {
+func bodyLocksMutex(aliases common.VarAliases, body *ast.BlockStmt, mutexName string, lockMethods []string) bool {
 	found := false
 	ast.Inspect(body, func(n ast.Node) bool {
 		if found {
@@ -83,7 +83,7 @@ func closureLocksMutex(body *ast.BlockStmt, mutexName string, lockMethods []stri
 		if !ok {
 			return true
 		}
-		if common.GetVarName(sel.X) == mutexName && slices.Contains(lockMethods, sel.Sel.Name) {
+		if aliases.VarName(sel.X) == mutexName && slices.Contains(lockMethods, sel.Sel.Name) {
 			found = true
 			return false
 		}
@@ -10,7 +10,7 @@ import (
 
 // analyzeRangeStatement handles range statements
 func (c *Checker) analyzeRangeStatement(stmt *ast.RangeStmt, stats map[string]*Stats) {
-	newLoopMutexDetector(c.errorCollector, c.typesInfo).check(stmt.Body)
+	c.loopMutexes().check(stmt.Body)
 	c.loopCarry.reportDeferredUnlocksInLoop(stmt.Body)
 	rangeStats := c.analyzeBlock(stmt.Body, stats)
 	copyStatsMap(stats, rangeStats)
@@ -75,8 +75,11 @@ func (c *Checker) analyzeTypeSwitchStatement(stmt *ast.TypeSwitchStmt, stats map
 
 // analyzeSelectStatement handles select statements. A select always executes
 // exactly one of its clauses, so the clause set is exhaustive even without a
-// default arm.
+// default arm. A default arm is just another clause: an unlock is guaranteed
+// only when every arm that falls through agrees on it, so a default that
+// unlocks does not cover a comm clause that keeps the lock.
 func (c *Checker) analyzeSelectStatement(stmt *ast.SelectStmt, stats map[string]*Stats) {
+	c.reportSelectWhileLocked(stmt, stats)
 	c.analyzeBranchClauses(selectClauses(stmt.Body), true, stats, "select")
 }
 
@@ -122,7 +125,7 @@ func (c *Checker) analyzeBranchClauses(clauses []branchClause, exhaustive bool,
 	}
 
 	for i := range clauses {
-		c.reportUnmatchedLocksInBranch(stats, branchStats[i], branchType)
+		c.reportUnmatchedLocksInClause(stats, branchStats[i], siblingLocksFor(stats, branchStats, i), branchType)
 	}
 }
 
@@ -235,17 +238,17 @@ func (c *Checker) branchInitialStatsForCondition(cond ast.Expr, stats map[string
 		return thenStats, elseStats
 	}
 
-	varName := common.GetVarName(sel.X)
+	varName := c.aliases.VarName(sel.X)
 	switch sel.Sel.Name {
 	case "TryLock":
 		if c.mutexNames[varName] || c.rwMutexNames[varName] {
 			thenStats[varName].lock++
-			thenStats[varName].lockPos = append(thenStats[varName].lockPos, call.Pos())
+			thenStats[varName].lockPos = append(thenStats[varName].lockPos, common.MethodPos(call))
 		}
 	case "TryRLock":
 		if c.rwMutexNames[varName] {
 			thenStats[varName].rlock++
-			thenStats[varName].rlockPos = append(thenStats[varName].rlockPos, call.Pos())
+			thenStats[varName].rlockPos = append(thenStats[varName].rlockPos, common.MethodPos(call))
 		}
 	}
 
@@ -305,6 +308,7 @@ func (c *Checker) analyzeGoStatement(stmt *ast.GoStmt, stats map[string]*Stats)
 
 	if fnLit, ok := stmt.Call.Fun.(*ast.FuncLit); ok {
 		cg := newCrossGoroutineDetector(c.mutexNames, c.rwMutexNames, c.commentFilter, c.typesInfo)
+		cg.aliases = c.aliases
 		// Record goroutines launched while a mutex is held that also try to
 		// acquire the same mutex. The conflict is reported at function exit if
 		// the parent still holds the lock.
@@ -348,6 +352,7 @@ func (c *Checker) analyzeGoStatement(stmt *ast.GoStmt, stats map[string]*Stats)
 		goStats := c.analyzeBlock(fnLit.Body, goInitial)
 		cg.suppressBorrowedReleases(goStats, crossReleases)
 		c.reportUnmatchedLocksInBranch(goInitial, goStats, "goroutine")
+		c.reportLocksSplitAcrossGoroutines(stats, crossReleases)
 		cg.applyReleases(stats, crossReleases)
 		return
 	}
@@ -362,7 +367,7 @@ func (c *Checker) analyzeForStatement(stmt *ast.ForStmt, stats map[string]*Stats
 		return
 	}
 
-	newLoopMutexDetector(c.errorCollector, c.typesInfo).check(stmt.Body)
+	c.loopMutexes().check(stmt.Body)
 	c.loopCarry.reportDeferredUnlocksInLoop(stmt.Body)
 	forStats := c.analyzeBlock(stmt.Body, stats)
 	c.loopCarry.applyLoopExitLocks(stmt, stats, forStats)
@@ -66,3 +66,48 @@ func TestSameBranchState(t *testing.T) {
 		})
 	}
 }
+
+// TestSiblingLocksFor pins which arms count as leaving a lock held: only the
+// net outstanding count above the entry state matters, and the arm under
+// report is excluded so its own locks never excuse its own unlocks.
+func TestSiblingLocksFor(t *testing.T) {
+	entry := map[string]*Stats{"mu": {}, "rw": {}}
+	arms := []map[string]*Stats{
+		{"mu": {lock: 1}, "rw": {}},
+		{"mu": {borrowedLock: 1}, "rw": {rlock: 1}},
+		{"mu": {lock: 1, deferUnlock: 1}, "rw": {}},
+	}
+
+	tests := []struct {
+		name string
+		self int
+		want map[string]siblingLocks
+	}{
+		{
+			name: "deferred release in arm 2 is not held; rlock left by arm 1 is",
+			self: 0,
+			want: map[string]siblingLocks{"mu": {}, "rw": {rlock: true}},
+		},
+		{
+			name: "arm 1 sees the lock left by arm 0",
+			self: 1,
+			want: map[string]siblingLocks{"mu": {lock: true}, "rw": {}},
+		},
+		{
+			name: "arm 2 sees the locks left by arms 0 and 1",
+			self: 2,
+			want: map[string]siblingLocks{"mu": {lock: true}, "rw": {rlock: true}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := siblingLocksFor(entry, arms, tc.self)
+			for name, want := range tc.want {
+				if got[name] != want {
+					t.Errorf("siblingLocksFor(self=%d)[%q] = %+v, want %+v", tc.self, name, got[name], want)
+				}
+			}
+		})
+	}
+}
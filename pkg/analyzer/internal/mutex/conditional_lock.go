@@ -0,0 +1,102 @@
+package mutex
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
+)
+
+// detectConditionalLockDefers finds an unconditional `defer mu.Unlock()`
+// whose Lock is taken inside a preceding if that not every path enters:
+//
+//	if cond {
+//	    mu.Lock()
+//	}
+//	defer mu.Unlock()
+//
+// On the path that skips the if the deferred Unlock releases a mutex that
+// is not locked, which panics. The result maps the defer position to the
+// conditional Lock so handleDeferUnlock / handleDeferRUnlock can report the
+// pair as one finding, and the Lock is not reported again as left locked in
+// the if.
+func (c *Checker) detectConditionalLockDefers(fn *ast.FuncDecl) map[token.Pos]token.Pos {
+	defers := make(map[token.Pos]token.Pos)
+	if fn == nil || fn.Body == nil {
+		return defers
+	}
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		if block, ok := n.(*ast.BlockStmt); ok {
+			c.markConditionalLockDefersInBlock(block.List, defers)
+		}
+		return true
+	})
+	return defers
+}
+
+func (c *Checker) markConditionalLockDefersInBlock(stmts []ast.Stmt, defers map[token.Pos]token.Pos) {
+	for i, stmt := range stmts {
+		deferStmt, ok := stmt.(*ast.DeferStmt)
+		if !ok {
+			continue
+		}
+		sel, ok := deferStmt.Call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			continue
+		}
+		varName, lockMethod, ok := c.unlockSelectorTarget(sel)
+		if !ok {
+			continue
+		}
+		if lockPos := conditionalLockBefore(c.aliases, stmts[:i], varName, lockMethod, sel.Sel.Name); lockPos.IsValid() {
+			defers[deferStmt.Pos()] = lockPos
+		}
+	}
+}
+
+// conditionalLockBefore walks back from the end of stmts to the nearest
+// statement that takes varName's lockMethod. It returns the Lock's position
+// when that statement is an if whose body takes the lock, without releasing
+// it again, and whose else (if any) does not.
+func conditionalLockBefore(aliases common.VarAliases, stmts []ast.Stmt, varName, lockMethod, unlockMethod string) token.Pos {
+	for i := len(stmts) - 1; i >= 0; i-- {
+		if !mentionsMethodCall(aliases, stmts[i], varName, lockMethod) {
+			continue
+		}
+		ifStmt, ok := stmts[i].(*ast.IfStmt)
+		if !ok || mentionsMethodCall(aliases, ifStmt.Body, varName, unlockMethod) {
+			return token.NoPos
+		}
+		if ifStmt.Else != nil && mentionsMethodCall(aliases, ifStmt.Else, varName, lockMethod) {
+			return token.NoPos
+		}
+		for _, bodyStmt := range ifStmt.Body.List {
+			if statementIsMethodCall(aliases, bodyStmt, varName, lockMethod) {
+				return bodyStmt.(*ast.ExprStmt).X.Pos()
+			}
+		}
+		return token.NoPos
+	}
+	return token.NoPos
+}
+
+// mentionsMethodCall reports whether node calls varName.methodName outside
+// function literals.
+func mentionsMethodCall(aliases common.VarAliases, node ast.Node, varName, methodName string) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		switch x := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			if sel, ok := x.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == methodName && aliases.VarName(sel.X) == varName {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
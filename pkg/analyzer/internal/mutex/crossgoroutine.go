@@ -28,6 +28,7 @@ import (
 type crossGoroutineDetector struct {
 	mutexNames    map[string]bool
 	rwMutexNames  map[string]bool
+	aliases       common.VarAliases
 	commentFilter *commentfilter.CommentFilter
 	typesInfo     *types.Info
 }
@@ -136,7 +137,7 @@ func (d *crossGoroutineDetector) scanCall(call *ast.CallExpr, parentStats map[st
 		return
 	}
 
-	varName := common.GetVarName(sel.X)
+	varName := d.aliases.VarName(sel.X)
 	switch sel.Sel.Name {
 	case "Lock", "TryLock":
 		if d.mutexNames[varName] || d.rwMutexNames[varName] {
@@ -149,7 +150,7 @@ func (d *crossGoroutineDetector) scanCall(call *ast.CallExpr, parentStats map[st
 				return
 			}
 			if d.parentHoldsExclusiveLock(parentStats, varName) {
-				releases.unlocks[varName] = append(releases.unlocks[varName], call.Pos())
+				releases.unlocks[varName] = append(releases.unlocks[varName], common.MethodPos(call))
 			}
 		}
 	case "RLock", "TryRLock":
@@ -163,7 +164,7 @@ func (d *crossGoroutineDetector) scanCall(call *ast.CallExpr, parentStats map[st
 				return
 			}
 			if d.parentHoldsReadLock(parentStats, varName) {
-				releases.runlocks[varName] = append(releases.runlocks[varName], call.Pos())
+				releases.runlocks[varName] = append(releases.runlocks[varName], common.MethodPos(call))
 			}
 		}
 	}
@@ -275,7 +276,7 @@ func (d *crossGoroutineDetector) goroutineBodyLockCallMethod(body *ast.BlockStmt
 		if !ok {
 			return true
 		}
-		if containsMethod(methodNames, sel.Sel.Name) && common.GetVarName(sel.X) == varName {
+		if containsMethod(methodNames, sel.Sel.Name) && d.aliases.VarName(sel.X) == varName {
 			foundMethod = sel.Sel.Name
 			return false
 		}
@@ -418,7 +419,7 @@ func (d *crossGoroutineDetector) statementUnlocks(stmt ast.Stmt, varName string,
 		return false
 	}
 	sel, ok := call.Fun.(*ast.SelectorExpr)
-	return ok && common.GetVarName(sel.X) == varName && containsMethod(unlockMethods, sel.Sel.Name)
+	return ok && d.aliases.VarName(sel.X) == varName && containsMethod(unlockMethods, sel.Sel.Name)
 }
 
 func (d *crossGoroutineDetector) statementMayBlock(stmt ast.Stmt) bool {
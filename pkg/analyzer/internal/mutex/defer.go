@@ -6,6 +6,7 @@ import (
 
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/report"
 )
 
 func (c *Checker) analyzeReturnStatement(stmt *ast.ReturnStmt, stats map[string]*Stats) {
@@ -36,6 +37,17 @@ func (c *Checker) analyzeDeferStatement(stmt *ast.DeferStmt, stats map[string]*S
 		return
 	}
 
+	// Handle `defer unlock()` where unlock is bound to a mutex method value,
+	// and `defer (*sync.Mutex).Unlock(&mu)`
+	bound, ok := c.boundMethodValue(stmt.Call.Fun)
+	if !ok {
+		bound, ok = c.methodExpressionCall(stmt.Call)
+	}
+	if ok {
+		c.handleDeferCall(bound, stmt.Pos(), stats)
+		return
+	}
+
 	// Handle direct defer calls
 	if call, ok := stmt.Call.Fun.(*ast.SelectorExpr); ok {
 		c.handleDeferCall(call, stmt.Pos(), stats)
@@ -44,35 +56,40 @@ func (c *Checker) analyzeDeferStatement(stmt *ast.DeferStmt, stats map[string]*S
 
 	// Handle defer with function literals
 	if fnlit, ok := stmt.Call.Fun.(*ast.FuncLit); ok {
+		c.analyzeDeferredClosureScope(fnlit, stats)
 		c.handleDeferFunctionLiteral(fnlit, stmt.Pos(), stats)
 	}
 }
 
 // handleDeferCall processes direct defer calls
 func (c *Checker) handleDeferCall(call *ast.SelectorExpr, pos token.Pos, stats map[string]*Stats) {
-	varName := common.GetVarName(call.X)
+	varName := c.aliases.VarName(call.X)
+	method := call.Sel.Name
+	if c.mutexNames[varName] {
+		method = c.lockers.Canonical(method)
+	}
 
-	if call.Sel.Name == "Lock" && c.consumeBorrowedDeferredLock(varName, stats) {
+	if method == "Lock" && c.consumeBorrowedDeferredLock(varName, stats) {
 		return
 	}
-	if call.Sel.Name == "RLock" && c.consumeBorrowedDeferredRLock(varName, stats) {
+	if method == "RLock" && c.consumeBorrowedDeferredRLock(varName, stats) {
 		return
 	}
-	if call.Sel.Name == "Lock" && c.deferredRelockBalancesEarlierDeferredUnlock(varName, stats) {
+	if method == "Lock" && c.deferredRelockBalancesEarlierDeferredUnlock(varName, stats) {
 		return
 	}
-	if call.Sel.Name == "RLock" && c.deferredRRelockBalancesEarlierDeferredRUnlock(varName, stats) {
+	if method == "RLock" && c.deferredRRelockBalancesEarlierDeferredRUnlock(varName, stats) {
 		return
 	}
 
 	// defer Lock / defer RLock re-acquires the lock on
 	// function return instead of releasing it, guaranteed deadlock.
-	if c.mutexNames[varName] && call.Sel.Name == "Lock" {
+	if c.mutexNames[varName] && method == "Lock" {
 		c.errorCollector.AddError(pos, category.DeferLock, "mutex '"+varName+"' defer calls Lock instead of Unlock, will deadlock on return")
 		return
 	}
 	if c.rwMutexNames[varName] {
-		switch call.Sel.Name {
+		switch method {
 		case "Lock":
 			c.errorCollector.AddError(pos, category.DeferLock, "rwmutex '"+varName+"' defer calls Lock instead of Unlock, will deadlock on return")
 			return
@@ -82,12 +99,12 @@ func (c *Checker) handleDeferCall(call *ast.SelectorExpr, pos token.Pos, stats m
 		}
 	}
 
-	if c.mutexNames[varName] && call.Sel.Name == "Unlock" {
+	if c.mutexNames[varName] && method == "Unlock" {
 		c.handleDeferUnlock(varName, pos, stats, false)
 	}
 
 	if c.rwMutexNames[varName] {
-		switch call.Sel.Name {
+		switch method {
 		case "Unlock":
 			c.handleDeferUnlock(varName, pos, stats, true)
 		case "RUnlock":
@@ -126,9 +143,20 @@ func (c *Checker) deferredRRelockBalancesEarlierDeferredRUnlock(varName string,
 	return st != nil && st.rlock == 0 && st.deferRUnlock > 0
 }
 
-// handleDeferFunctionLiteral processes defer with function literals
+// handleDeferFunctionLiteral processes defer with function literals. A held
+// lock is only credited with the closure's unlock when that unlock runs on
+// every path through the closure: `defer func() { if ok { mu.Unlock() } }()`
+// leaves the lock held whenever ok is false, so it must not suppress the
+// unmatched-lock report. Recover-guarded and flag-guarded unlocks keep their
+// dedicated handling.
 func (c *Checker) handleDeferFunctionLiteral(fnlit *ast.FuncLit, pos token.Pos, stats map[string]*Stats) {
 	guard := newRecoverGuardInspector(c.commentFilter)
+	guard.aliases = c.aliases
+
+	// A closure that only re-acquires a lock is a deferred Lock in disguise.
+	for _, lock := range c.deferredClosureLocks(fnlit, guard) {
+		c.handleDeferCall(lock, pos, stats)
+	}
 
 	// Check for mutex unlocks in function literal
 	for mutexName := range c.mutexNames {
@@ -136,7 +164,11 @@ func (c *Checker) handleDeferFunctionLiteral(fnlit *ast.FuncLit, pos token.Pos,
 			if c.isFlagGuarded(mutexName) {
 				continue
 			}
-			if stats[mutexName].lock == 0 && guard.unlocksOnlyInRecoverGuard(fnlit.Body, mutexName, "Unlock") {
+			recoverGuarded := guard.unlocksOnlyInRecoverGuard(fnlit.Body, mutexName, "Unlock")
+			if stats[mutexName].lock == 0 && recoverGuarded {
+				continue
+			}
+			if stats[mutexName].lock > 0 && !recoverGuarded && !c.closureUnlocksOnEveryPath(fnlit.Body.List, mutexName, "Unlock") {
 				continue
 			}
 			c.handleDeferUnlock(mutexName, pos, stats, false)
@@ -149,13 +181,21 @@ func (c *Checker) handleDeferFunctionLiteral(fnlit *ast.FuncLit, pos token.Pos,
 			if c.isFlagGuarded(rwMutexName) {
 				continue
 			}
-			if stats[rwMutexName].lock == 0 && guard.unlocksOnlyInRecoverGuard(fnlit.Body, rwMutexName, "Unlock") {
+			recoverGuarded := guard.unlocksOnlyInRecoverGuard(fnlit.Body, rwMutexName, "Unlock")
+			if stats[rwMutexName].lock == 0 && recoverGuarded {
+				continue
+			}
+			if stats[rwMutexName].lock > 0 && !recoverGuarded && !c.closureUnlocksOnEveryPath(fnlit.Body.List, rwMutexName, "Unlock") {
 				continue
 			}
 			c.handleDeferUnlock(rwMutexName, pos, stats, true)
 		}
 		if guard.containsRUnlock(fnlit.Body, rwMutexName) && !guard.containsRLock(fnlit.Body, rwMutexName) {
-			if stats[rwMutexName].rlock == 0 && guard.unlocksOnlyInRecoverGuard(fnlit.Body, rwMutexName, "RUnlock") {
+			recoverGuarded := guard.unlocksOnlyInRecoverGuard(fnlit.Body, rwMutexName, "RUnlock")
+			if stats[rwMutexName].rlock == 0 && recoverGuarded {
+				continue
+			}
+			if stats[rwMutexName].rlock > 0 && !recoverGuarded && !c.closureUnlocksOnEveryPath(fnlit.Body.List, rwMutexName, "RUnlock") {
 				continue
 			}
 			c.handleDeferRUnlock(rwMutexName, pos, stats)
@@ -163,6 +203,133 @@ func (c *Checker) handleDeferFunctionLiteral(fnlit *ast.FuncLit, pos token.Pos,
 	}
 }
 
+// deferredClosureLocks returns the Lock/RLock calls made directly in the
+// deferred closure's body on a mutex the closure never releases, so
+// `defer func() { mu.Lock() }()` is handled like `defer mu.Lock()`.
+func (c *Checker) deferredClosureLocks(fnlit *ast.FuncLit, guard *recoverGuardInspector) []*ast.SelectorExpr {
+	var locks []*ast.SelectorExpr
+	for _, stmt := range fnlit.Body.List {
+		if c.commentFilter.ShouldSkipStatement(stmt) {
+			continue
+		}
+		call, name, method, ok := c.mutexCallStatement(stmt)
+		if !ok {
+			continue
+		}
+		switch {
+		case method == "Lock" && !guard.containsUnlock(fnlit.Body, name):
+		case method == "RLock" && !guard.containsRUnlock(fnlit.Body, name):
+		default:
+			continue
+		}
+		locks = append(locks, call.Fun.(*ast.SelectorExpr))
+	}
+	return locks
+}
+
+// closureUnlocksOnEveryPath reports whether the deferred closure statements
+// call method on mutexName whatever path they take: directly, in a nested
+// block, or in both arms of an if/else, with no earlier statement able to
+// return or terminate first. Unlocks inside loops, switches or an if without
+// else are conditional.
+func (c *Checker) closureUnlocksOnEveryPath(stmts []ast.Stmt, mutexName, method string) bool {
+	for _, stmt := range stmts {
+		if c.commentFilter.ShouldSkipStatement(stmt) {
+			continue
+		}
+		switch s := stmt.(type) {
+		case *ast.ExprStmt:
+			if _, name, m, ok := c.mutexCallStatement(s); ok && name == mutexName && m == method {
+				return true
+			}
+			if fnlit := c.calledFuncLit(s.X); fnlit != nil && c.closureUnlocksOnEveryPath(fnlit.Body.List, mutexName, method) {
+				return true
+			}
+		case *ast.DeferStmt:
+			if _, name, m, ok := c.mutexCallStatement(&ast.ExprStmt{X: s.Call}); ok && name == mutexName && m == method {
+				return true
+			}
+		case *ast.BlockStmt:
+			if c.closureUnlocksOnEveryPath(s.List, mutexName, method) {
+				return true
+			}
+		case *ast.IfStmt:
+			if s.Else != nil && c.closureUnlocksOnEveryPath(s.Body.List, mutexName, method) &&
+				c.closureUnlocksOnEveryPath([]ast.Stmt{s.Else}, mutexName, method) {
+				return true
+			}
+		}
+		if c.closureStatementMayLeave(stmt) {
+			return false
+		}
+	}
+	return false
+}
+
+// calledFuncLit returns the function literal expr calls, either in place,
+// `func() { ... }()`, or through a local declared with it,
+// `release := func() { ... }; release()`.
+func (c *Checker) calledFuncLit(expr ast.Expr) *ast.FuncLit {
+	call, ok := common.UnwrapParenExpr(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return nil
+	}
+	switch fun := common.UnwrapParenExpr(call.Fun).(type) {
+	case *ast.FuncLit:
+		return fun
+	case *ast.Ident:
+		obj := c.typesInfo.Uses[fun]
+		if obj == nil || c.function == nil || c.function.Body == nil {
+			return nil
+		}
+		var bound *ast.FuncLit
+		ast.Inspect(c.function.Body, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.AssignStmt:
+				if node.Tok != token.DEFINE || len(node.Lhs) != len(node.Rhs) {
+					break
+				}
+				for i, lhs := range node.Lhs {
+					if ident, ok := lhs.(*ast.Ident); ok && c.typesInfo.Defs[ident] == obj {
+						bound, _ = common.UnwrapParenExpr(node.Rhs[i]).(*ast.FuncLit)
+					}
+				}
+			case *ast.ValueSpec:
+				for i, name := range node.Names {
+					if c.typesInfo.Defs[name] == obj && i < len(node.Values) {
+						bound, _ = common.UnwrapParenExpr(node.Values[i]).(*ast.FuncLit)
+					}
+				}
+			}
+			return bound == nil
+		})
+		return bound
+	}
+	return nil
+}
+
+// closureStatementMayLeave reports whether stmt can leave the enclosing
+// closure early, through a return or a terminating call. Unlike
+// statementMayExit, break and continue stay inside the closure.
+func (c *Checker) closureStatementMayLeave(stmt ast.Stmt) bool {
+	found := false
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		switch node := n.(type) {
+		case *ast.DeferStmt, *ast.GoStmt, *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			found = true
+		case *ast.CallExpr:
+			found = c.termination.callTerminatesExecution(node)
+		}
+		return !found
+	})
+	return found
+}
+
 // handleDeferUnlock processes defer unlock calls
 func (c *Checker) handleDeferUnlock(varName string, pos token.Pos, stats map[string]*Stats, isRWMutex bool) {
 	if stats[varName].lock == 0 {
@@ -179,11 +346,25 @@ func (c *Checker) handleDeferUnlock(varName string, pos token.Pos, stats map[str
 			stats[varName].deferUnlock++
 			return
 		}
+		if isRWMutex && remainingLockCount(stats[varName].rlock, stats[varName].deferRUnlock) > 0 {
+			// Deferred Unlock while only a read lock is held: report the wrong
+			// method and credit it as the RUnlock it was meant to be, like the
+			// direct Unlock case, so the read lock is not reported again.
+			c.errorCollector.AddError(pos, category.RWMutexAPIMismatch, "rwmutex '"+varName+"' deferred Unlock but only read lock is held, did you mean RUnlock?",
+				heldAt(stats[varName].rlockPos, "rwmutex", varName, "rlocked")...)
+			stats[varName].deferRUnlock++
+			return
+		}
 		mutexType := "mutex"
 		if isRWMutex {
 			mutexType = "rwmutex"
 		}
-		c.errorCollector.AddError(pos, category.DeferUnlockWithoutLock, mutexType+" '"+varName+"' has defer unlock but no corresponding lock")
+		if lockPos, ok := c.conditionalLockDefers[pos]; ok {
+			c.errorCollector.AddError(pos, category.DeferUnlockWithoutLock, mutexType+" '"+varName+"' defer Unlock but Lock is conditional",
+				report.Related(lockPos, mutexType+" '"+varName+"' locked here"))
+		} else {
+			c.errorCollector.AddError(pos, category.DeferUnlockWithoutLock, mutexType+" '"+varName+"' has defer unlock but no corresponding lock")
+		}
 		c.deferErrors.badDeferUnlock[varName] = true
 	} else {
 		stats[varName].deferUnlock++
@@ -203,7 +384,19 @@ func (c *Checker) handleDeferRUnlock(varName string, pos token.Pos, stats map[st
 			stats[varName].deferRUnlock++
 			return
 		}
-		c.errorCollector.AddError(pos, category.DeferUnlockWithoutLock, "rwmutex '"+varName+"' has defer runlock but no corresponding rlock")
+		if remainingLockCount(stats[varName].lock, stats[varName].deferUnlock) > 0 {
+			// Deferred RUnlock while only the write lock is held.
+			c.errorCollector.AddError(pos, category.RWMutexAPIMismatch, "rwmutex '"+varName+"' deferred RUnlock but only write lock is held, did you mean Unlock?",
+				heldAt(stats[varName].lockPos, "rwmutex", varName, "locked")...)
+			stats[varName].deferUnlock++
+			return
+		}
+		if rlockPos, ok := c.conditionalLockDefers[pos]; ok {
+			c.errorCollector.AddError(pos, category.DeferUnlockWithoutLock, "rwmutex '"+varName+"' defer RUnlock but RLock is conditional",
+				report.Related(rlockPos, "rwmutex '"+varName+"' rlocked here"))
+		} else {
+			c.errorCollector.AddError(pos, category.DeferUnlockWithoutLock, "rwmutex '"+varName+"' has defer runlock but no corresponding rlock")
+		}
 		c.deferErrors.badDeferRUnlock[varName] = true
 	} else {
 		stats[varName].deferRUnlock++
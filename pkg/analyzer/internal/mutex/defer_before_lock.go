@@ -56,7 +56,7 @@ func (c *Checker) markSafeDeferBeforeLockInBlock(stmts []ast.Stmt, safe map[toke
 // before any statement that can exit the function.
 func (c *Checker) matchingLockFollowsSafely(rest []ast.Stmt, varName, lockMethod string) bool {
 	for _, stmt := range rest {
-		if statementIsMethodCall(stmt, varName, lockMethod) {
+		if statementIsMethodCall(c.aliases, stmt, varName, lockMethod) {
 			return true
 		}
 		if c.statementMayExit(stmt) {
@@ -105,7 +105,7 @@ func (c *Checker) unlockSelectorTarget(sel *ast.SelectorExpr) (string, string, b
 	if !isUnlockMethod(sel.Sel.Name) {
 		return "", "", false
 	}
-	varName := common.GetVarName(sel.X)
+	varName := c.aliases.VarName(sel.X)
 	if !c.mutexNames[varName] && !c.rwMutexNames[varName] {
 		return "", "", false
 	}
@@ -117,7 +117,7 @@ func (c *Checker) unlockSelectorTarget(sel *ast.SelectorExpr) (string, string, b
 }
 
 // statementIsMethodCall reports whether stmt is exactly `varName.methodName()`.
-func statementIsMethodCall(stmt ast.Stmt, varName, methodName string) bool {
+func statementIsMethodCall(aliases common.VarAliases, stmt ast.Stmt, varName, methodName string) bool {
 	exprStmt, ok := stmt.(*ast.ExprStmt)
 	if !ok {
 		return false
@@ -130,5 +130,5 @@ func statementIsMethodCall(stmt ast.Stmt, varName, methodName string) bool {
 	if !ok {
 		return false
 	}
-	return sel.Sel.Name == methodName && common.GetVarName(sel.X) == varName
+	return sel.Sel.Name == methodName && aliases.VarName(sel.X) == varName
 }
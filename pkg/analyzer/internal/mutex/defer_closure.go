@@ -0,0 +1,74 @@
+package mutex
+
+import "go/ast"
+
+// analyzeDeferredClosureScope analyzes a deferred closure that takes and
+// releases its own locks as a scope of its own, the way a goroutine body is,
+// so `defer func() { mu.Lock(); ...; mu.Unlock() }()` leaving a lock held on
+// some path is reported with a "deferred closure" context. Closures that
+// release a lock taken by the enclosing function, or only re-acquire one, are
+// left to handleDeferFunctionLiteral.
+func (c *Checker) analyzeDeferredClosureScope(fnlit *ast.FuncLit, stats map[string]*Stats) {
+	if c.rawBodyEffects || fnlit.Body == nil || !c.closureOwnsItsLocks(fnlit.Body) {
+		return
+	}
+	initial := emptyStatsLike(stats)
+	final := c.analyzeBlock(fnlit.Body, initial)
+	c.reportUnmatchedLocksInBranch(initial, final, "deferred closure")
+}
+
+// closureOwnsItsLocks reports whether body acquires every lock it touches
+// before releasing it and releases each one at least once. Goroutines and
+// other closures nested in body are not part of its scope and are skipped.
+func (c *Checker) closureOwnsItsLocks(body *ast.BlockStmt) bool {
+	type lockKey struct {
+		name string
+		read bool
+	}
+	acquired := make(map[lockKey]bool)
+	released := make(map[lockKey]bool)
+	owns := true
+	ast.Inspect(body, func(n ast.Node) bool {
+		if !owns {
+			return false
+		}
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			if c.commentFilter.ShouldSkipCall(node) {
+				return true
+			}
+			sel, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			name := c.aliases.VarName(sel.X)
+			if !c.mutexNames[name] && !c.rwMutexNames[name] {
+				return true
+			}
+			method := sel.Sel.Name
+			key := lockKey{name: name, read: method == "RLock" || method == "RUnlock"}
+			switch {
+			case isLockMethod(method):
+				acquired[key] = true
+			case isUnlockMethod(method) && acquired[key]:
+				released[key] = true
+			default:
+				// A release before any acquire borrows the caller's lock, and
+				// TryLock needs its result tracked: neither is a closed scope.
+				owns = false
+			}
+		}
+		return true
+	})
+	if !owns || len(acquired) == 0 {
+		return false
+	}
+	for key := range acquired {
+		if !released[key] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,55 @@
+package mutex
+
+import (
+	"go/ast"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+)
+
+// reportEmptyCriticalSection flags a Lock/RLock statement whose very next
+// statement in the same block is the matching Unlock/RUnlock on the same
+// mutex, leaving nothing protected. Lock-then-Unlock is occasionally used as a
+// barrier to wait for the current holder, so the check is opt-in.
+func (c *Checker) reportEmptyCriticalSection(stmt, next ast.Stmt) {
+	if c.rawBodyEffects || next == nil {
+		return
+	}
+	lockCall, varName, lockMethod, ok := c.mutexCallStatement(stmt)
+	if !ok || !isLockMethod(lockMethod) {
+		return
+	}
+	_, nextVar, nextMethod, ok := c.mutexCallStatement(next)
+	if !ok || nextVar != varName || nextMethod != matchingUnlockMethod(lockMethod) {
+		return
+	}
+
+	mutexType := "mutex"
+	if c.rwMutexNames[varName] {
+		mutexType = "rwmutex"
+	}
+	c.errorCollector.AddError(lockCall.Pos(), category.EmptyCriticalSection,
+		mutexType+" '"+varName+"' has empty critical section")
+}
+
+// mutexCallStatement matches a bare `mu.Method()` expression statement on a
+// tracked mutex and returns the call, the mutex name and the method.
+func (c *Checker) mutexCallStatement(stmt ast.Stmt) (*ast.CallExpr, string, string, bool) {
+	exprStmt, ok := stmt.(*ast.ExprStmt)
+	if !ok {
+		return nil, "", "", false
+	}
+	call, ok := common.UnwrapParenExpr(exprStmt.X).(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return nil, "", "", false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil, "", "", false
+	}
+	varName := c.aliases.VarName(sel.X)
+	if !c.mutexNames[varName] && !c.rwMutexNames[varName] {
+		return nil, "", "", false
+	}
+	return call, varName, sel.Sel.Name, true
+}
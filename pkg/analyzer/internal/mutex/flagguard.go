@@ -31,8 +31,8 @@ func (c *Checker) detectFlagGuardedReleaseFlags(fn *ast.FuncDecl) map[string]str
 	var result map[string]string
 	consider := func(names map[string]bool) {
 		for name := range names {
-			flag, ok := deferredFlagGuardedUnlock(fn.Body, name, "Unlock")
-			if !ok || !everyLockPairsWithSetFlag(fn.Body, name, "Lock", flag) {
+			flag, ok := deferredFlagGuardedUnlock(c.aliases, fn.Body, name, "Unlock")
+			if !ok || !everyLockPairsWithSetFlag(c.aliases, fn.Body, name, "Lock", flag) {
 				continue
 			}
 			if result == nil {
@@ -104,50 +104,50 @@ func exprMentionsIdent(expr ast.Expr, name string) bool {
 // deferredFlagGuardedUnlock finds a deferred closure in body whose unlocks of
 // mutexName (via unlockMethod) all sit inside a single `if <flag> { ... }`, and
 // returns the guard flag name.
-func deferredFlagGuardedUnlock(body *ast.BlockStmt, mutexName, unlockMethod string) (string, bool) {
-	return deferredFlagGuardedUnlockInStatements(body.List, mutexName, unlockMethod)
+func deferredFlagGuardedUnlock(aliases common.VarAliases, body *ast.BlockStmt, mutexName, unlockMethod string) (string, bool) {
+	return deferredFlagGuardedUnlockInStatements(aliases, body.List, mutexName, unlockMethod)
 }
 
-func deferredFlagGuardedUnlockInStatements(stmts []ast.Stmt, mutexName, unlockMethod string) (string, bool) {
+func deferredFlagGuardedUnlockInStatements(aliases common.VarAliases, stmts []ast.Stmt, mutexName, unlockMethod string) (string, bool) {
 	for _, stmt := range stmts {
 		switch s := stmt.(type) {
 		case *ast.DeferStmt:
-			if flag, ok := flagFromGuardedDefer(s, mutexName, unlockMethod); ok {
+			if flag, ok := flagFromGuardedDefer(aliases, s, mutexName, unlockMethod); ok {
 				return flag, true
 			}
 		case *ast.BlockStmt:
-			if flag, ok := deferredFlagGuardedUnlockInStatements(s.List, mutexName, unlockMethod); ok {
+			if flag, ok := deferredFlagGuardedUnlockInStatements(aliases, s.List, mutexName, unlockMethod); ok {
 				return flag, true
 			}
 		case *ast.IfStmt:
-			if flag, ok := deferredFlagGuardedUnlockInStatements(s.Body.List, mutexName, unlockMethod); ok {
+			if flag, ok := deferredFlagGuardedUnlockInStatements(aliases, s.Body.List, mutexName, unlockMethod); ok {
 				return flag, true
 			}
-			if flag, ok := deferredFlagGuardedUnlockInElse(s.Else, mutexName, unlockMethod); ok {
+			if flag, ok := deferredFlagGuardedUnlockInElse(aliases, s.Else, mutexName, unlockMethod); ok {
 				return flag, true
 			}
 		case *ast.ForStmt:
-			if flag, ok := deferredFlagGuardedUnlockInStatements(s.Body.List, mutexName, unlockMethod); ok {
+			if flag, ok := deferredFlagGuardedUnlockInStatements(aliases, s.Body.List, mutexName, unlockMethod); ok {
 				return flag, true
 			}
 		case *ast.RangeStmt:
-			if flag, ok := deferredFlagGuardedUnlockInStatements(s.Body.List, mutexName, unlockMethod); ok {
+			if flag, ok := deferredFlagGuardedUnlockInStatements(aliases, s.Body.List, mutexName, unlockMethod); ok {
 				return flag, true
 			}
 		case *ast.SwitchStmt:
-			if flag, ok := deferredFlagGuardedUnlockInCaseClauses(s.Body.List, mutexName, unlockMethod); ok {
+			if flag, ok := deferredFlagGuardedUnlockInCaseClauses(aliases, s.Body.List, mutexName, unlockMethod); ok {
 				return flag, true
 			}
 		case *ast.TypeSwitchStmt:
-			if flag, ok := deferredFlagGuardedUnlockInCaseClauses(s.Body.List, mutexName, unlockMethod); ok {
+			if flag, ok := deferredFlagGuardedUnlockInCaseClauses(aliases, s.Body.List, mutexName, unlockMethod); ok {
 				return flag, true
 			}
 		case *ast.SelectStmt:
-			if flag, ok := deferredFlagGuardedUnlockInCommClauses(s.Body.List, mutexName, unlockMethod); ok {
+			if flag, ok := deferredFlagGuardedUnlockInCommClauses(aliases, s.Body.List, mutexName, unlockMethod); ok {
 				return flag, true
 			}
 		case *ast.LabeledStmt:
-			if flag, ok := deferredFlagGuardedUnlockInStatements([]ast.Stmt{s.Stmt}, mutexName, unlockMethod); ok {
+			if flag, ok := deferredFlagGuardedUnlockInStatements(aliases, []ast.Stmt{s.Stmt}, mutexName, unlockMethod); ok {
 				return flag, true
 			}
 		}
@@ -155,50 +155,50 @@ func deferredFlagGuardedUnlockInStatements(stmts []ast.Stmt, mutexName, unlockMe
 	return "", false
 }
 
-func deferredFlagGuardedUnlockInElse(stmt ast.Stmt, mutexName, unlockMethod string) (string, bool) {
+func deferredFlagGuardedUnlockInElse(aliases common.VarAliases, stmt ast.Stmt, mutexName, unlockMethod string) (string, bool) {
 	switch s := stmt.(type) {
 	case *ast.BlockStmt:
-		return deferredFlagGuardedUnlockInStatements(s.List, mutexName, unlockMethod)
+		return deferredFlagGuardedUnlockInStatements(aliases, s.List, mutexName, unlockMethod)
 	case *ast.IfStmt:
-		return deferredFlagGuardedUnlockInStatements([]ast.Stmt{s}, mutexName, unlockMethod)
+		return deferredFlagGuardedUnlockInStatements(aliases, []ast.Stmt{s}, mutexName, unlockMethod)
 	default:
 		return "", false
 	}
 }
 
-func deferredFlagGuardedUnlockInCaseClauses(stmts []ast.Stmt, mutexName, unlockMethod string) (string, bool) {
+func deferredFlagGuardedUnlockInCaseClauses(aliases common.VarAliases, stmts []ast.Stmt, mutexName, unlockMethod string) (string, bool) {
 	for _, stmt := range stmts {
 		cc, ok := stmt.(*ast.CaseClause)
 		if !ok {
 			continue
 		}
-		if flag, ok := deferredFlagGuardedUnlockInStatements(cc.Body, mutexName, unlockMethod); ok {
+		if flag, ok := deferredFlagGuardedUnlockInStatements(aliases, cc.Body, mutexName, unlockMethod); ok {
 			return flag, true
 		}
 	}
 	return "", false
 }
 
-func deferredFlagGuardedUnlockInCommClauses(stmts []ast.Stmt, mutexName, unlockMethod string) (string, bool) {
+func deferredFlagGuardedUnlockInCommClauses(aliases common.VarAliases, stmts []ast.Stmt, mutexName, unlockMethod string) (string, bool) {
 	for _, stmt := range stmts {
 		cc, ok := stmt.(*ast.CommClause)
 		if !ok {
 			continue
 		}
-		if flag, ok := deferredFlagGuardedUnlockInStatements(cc.Body, mutexName, unlockMethod); ok {
+		if flag, ok := deferredFlagGuardedUnlockInStatements(aliases, cc.Body, mutexName, unlockMethod); ok {
 			return flag, true
 		}
 	}
 	return "", false
 }
 
-func flagFromGuardedDefer(deferStmt *ast.DeferStmt, mutexName, unlockMethod string) (string, bool) {
+func flagFromGuardedDefer(aliases common.VarAliases, deferStmt *ast.DeferStmt, mutexName, unlockMethod string) (string, bool) {
 	fnlit, ok := deferStmt.Call.Fun.(*ast.FuncLit)
 	if !ok || fnlit.Body == nil {
 		return "", false
 	}
 
-	total := countMutexMethodCalls(fnlit.Body, mutexName, unlockMethod)
+	total := countMutexMethodCalls(aliases, fnlit.Body, mutexName, unlockMethod)
 	if total == 0 {
 		return "", false
 	}
@@ -213,7 +213,7 @@ func flagFromGuardedDefer(deferStmt *ast.DeferStmt, mutexName, unlockMethod stri
 			continue
 		}
 		// Every unlock in the closure must be under this single flag guard.
-		if countMutexMethodCalls(ifStmt.Body, mutexName, unlockMethod) == total {
+		if countMutexMethodCalls(aliases, ifStmt.Body, mutexName, unlockMethod) == total {
 			return flagIdent.Name, true
 		}
 	}
@@ -224,7 +224,7 @@ func flagFromGuardedDefer(deferStmt *ast.DeferStmt, mutexName, unlockMethod stri
 // `mutexName.lockMethod()` and every such call is immediately followed by
 // `flag = true` in the same statement list. Function literals, deferred calls and
 // goroutines run in a different frame and are not traversed.
-func everyLockPairsWithSetFlag(body *ast.BlockStmt, mutexName, lockMethod, flag string) bool {
+func everyLockPairsWithSetFlag(aliases common.VarAliases, body *ast.BlockStmt, mutexName, lockMethod, flag string) bool {
 	foundLock := false
 	paired := true
 
@@ -232,7 +232,7 @@ func everyLockPairsWithSetFlag(body *ast.BlockStmt, mutexName, lockMethod, flag
 	var visitCallbackExprs func(ast.Expr)
 	visit = func(stmts []ast.Stmt) {
 		for i, stmt := range stmts {
-			if isMutexMethodCallStmt(stmt, mutexName, lockMethod) {
+			if isMutexMethodCallStmt(aliases, stmt, mutexName, lockMethod) {
 				foundLock = true
 				if i+1 >= len(stmts) || !isAssignTrue(stmts[i+1], flag) {
 					paired = false
@@ -311,7 +311,7 @@ func everyLockPairsWithSetFlag(body *ast.BlockStmt, mutexName, lockMethod, flag
 	return foundLock && paired
 }
 
-func countMutexMethodCalls(block *ast.BlockStmt, mutexName, method string) int {
+func countMutexMethodCalls(aliases common.VarAliases, block *ast.BlockStmt, mutexName, method string) int {
 	count := 0
 	ast.Inspect(block, func(n ast.Node) bool {
 		call, ok := n.(*ast.CallExpr)
@@ -319,7 +319,7 @@ func countMutexMethodCalls(block *ast.BlockStmt, mutexName, method string) int {
 			return true
 		}
 		if sel, ok := call.Fun.(*ast.SelectorExpr); ok &&
-			sel.Sel.Name == method && common.GetVarName(sel.X) == mutexName {
+			sel.Sel.Name == method && aliases.VarName(sel.X) == mutexName {
 			count++
 		}
 		return true
@@ -327,7 +327,7 @@ func countMutexMethodCalls(block *ast.BlockStmt, mutexName, method string) int {
 	return count
 }
 
-func isMutexMethodCallStmt(stmt ast.Stmt, mutexName, method string) bool {
+func isMutexMethodCallStmt(aliases common.VarAliases, stmt ast.Stmt, mutexName, method string) bool {
 	exprStmt, ok := stmt.(*ast.ExprStmt)
 	if !ok {
 		return false
@@ -337,7 +337,7 @@ func isMutexMethodCallStmt(stmt ast.Stmt, mutexName, method string) bool {
 		return false
 	}
 	sel, ok := call.Fun.(*ast.SelectorExpr)
-	return ok && sel.Sel.Name == method && common.GetVarName(sel.X) == mutexName
+	return ok && sel.Sel.Name == method && aliases.VarName(sel.X) == mutexName
 }
 
 // isAssignTrue reports whether stmt assigns the boolean literal true to flag.
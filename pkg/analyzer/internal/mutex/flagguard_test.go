@@ -245,7 +245,7 @@ func f() {
 			file, _ := parseTypedLifecycleFile(t, tc.src)
 			body := funcBody(t, file, "f")
 
-			flag, ok := deferredFlagGuardedUnlock(body, "mu", "Unlock")
+			flag, ok := deferredFlagGuardedUnlock(nil, body, "mu", "Unlock")
 			if flag != tc.wantFlag || ok != tc.wantOK {
 				t.Errorf("deferredFlagGuardedUnlock() = (%q, %v), want (%q, %v)", flag, ok, tc.wantFlag, tc.wantOK)
 			}
@@ -317,7 +317,7 @@ func f(held bool) {
 			file, _ := parseTypedLifecycleFile(t, tc.src)
 			body := funcBody(t, file, "f")
 
-			if got := everyLockPairsWithSetFlag(body, "mu", "Lock", "held"); got != tc.want {
+			if got := everyLockPairsWithSetFlag(nil, body, "mu", "Lock", "held"); got != tc.want {
 				t.Errorf("everyLockPairsWithSetFlag() = %v, want %v", got, tc.want)
 			}
 		})
@@ -406,7 +406,7 @@ func TestIsMutexMethodCallStmt(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			if got := isMutexMethodCallStmt(firstStmt(t, tc.src), tc.mutex, tc.method); got != tc.want {
+			if got := isMutexMethodCallStmt(nil, firstStmt(t, tc.src), tc.mutex, tc.method); got != tc.want {
 				t.Errorf("isMutexMethodCallStmt() = %v, want %v", got, tc.want)
 			}
 		})
@@ -443,7 +443,7 @@ func f(c bool) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			if got := countMutexMethodCalls(body, tc.mutex, tc.method); got != tc.want {
+			if got := countMutexMethodCalls(nil, body, tc.mutex, tc.method); got != tc.want {
 				t.Errorf("countMutexMethodCalls() = %d, want %d", got, tc.want)
 			}
 		})
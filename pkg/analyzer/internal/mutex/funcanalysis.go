@@ -2,7 +2,9 @@ package mutex
 
 import (
 	"go/ast"
+	"go/types"
 
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/report"
 )
 
@@ -22,10 +24,20 @@ type funcAnalysis struct {
 	simulationStack        map[methodSimulationKey]bool
 	localFuncStack         map[*ast.FuncLit]bool
 
+	// methodValues maps local variables bound to a mutex method value
+	// (`unlock := mu.Unlock`) to the bound selector, so calling or deferring
+	// the variable is analyzed like the method call itself.
+	methodValues map[types.Object]*ast.SelectorExpr
+
 	// flagGuardedFlags maps mutexes released by a deferred, flag-guarded unlock
 	// (see detectFlagGuardedReleaseFlags) to their guard flag name. Populated once
 	// per real function.
 	flagGuardedFlags map[string]string
+
+	// heldOnReturn holds the mutexes the function's doc comment declares it
+	// returns holding (//goconcurrencylint:locks), which are not reported as
+	// leaked.
+	heldOnReturn map[string]bool
 }
 
 func newFuncAnalysis(fn *ast.FuncDecl) *funcAnalysis {
@@ -59,20 +71,26 @@ func newSimulationFuncAnalysis(fn *ast.FuncDecl, simStack map[methodSimulationKe
 }
 
 // forkForSimulation builds a sibling Checker that shares the package-wide
-// configuration with the receiver but uses the supplied per-function state
-// and primitive name maps. The fork gets its own ErrorCollector so
-// simulation diagnostics do not leak into the parent run.
-func (c *Checker) forkForSimulation(fa *funcAnalysis, mutexNames, rwMutexNames map[string]bool) *Checker {
+// configuration with the receiver but uses the supplied per-function state,
+// primitive name maps and aliases; a fork simulating another function passes
+// nil aliases, since the receiver's belong to its own body. The fork gets its
+// own ErrorCollector so simulation diagnostics do not leak into the parent
+// run.
+func (c *Checker) forkForSimulation(fa *funcAnalysis, mutexNames, rwMutexNames map[string]bool, aliases common.VarAliases) *Checker {
 	sim := &Checker{
 		mutexNames:            mutexNames,
 		rwMutexNames:          rwMutexNames,
+		aliases:               aliases,
+		goLaunches:            c.goLaunches,
+		syncPackages:          c.syncPackages,
+		lockers:               c.lockers,
 		errorCollector:        &report.ErrorCollector{},
 		commentFilter:         c.commentFilter,
 		typesInfo:             c.typesInfo,
 		receiverMethods:       c.receiverMethods,
 		functions:             c.functions,
 		termination:           c.termination,
-		loopCarry:             c.loopCarry,
+		loopCarry:             c.loopCarry.withAliases(aliases),
 		explicitTransferCache: c.explicitTransferCache,
 		lifecycleScanCache:    c.lifecycleScanCache,
 		safeDeferBeforeLock:   c.safeDeferBeforeLock,
@@ -82,6 +100,7 @@ func (c *Checker) forkForSimulation(fa *funcAnalysis, mutexNames, rwMutexNames m
 	// (isolated) collector so simulation diagnostics never leak into the parent
 	// run. fa.rawBodyEffects is true here, so the wrapper resolver stays inert.
 	sim.tryLock = newTryLockTracker(sim.mutexNames, sim.rwMutexNames, sim.commentFilter, sim.errorCollector)
+	sim.tryLock.aliases = aliases
 	sim.wrapper = newWrapperResolver(sim.receiverMethods, fa.function, fa.rawBodyEffects, sim.typesInfo)
 	sim.lifecycle = newLifecycleResolver(sim.receiverMethods, sim.functions, sim.typesInfo, sim.explicitTransferCache, sim.lifecycleScanCache, fa.function)
 	sim.panicDetector = newLockedPanicDetector(sim.mutexNames, sim.rwMutexNames, sim.typesInfo, sim.errorCollector, fa.rawBodyEffects)
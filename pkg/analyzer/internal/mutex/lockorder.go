@@ -25,9 +25,14 @@ import (
 type lockOrderDetector struct {
 	mutexNames    map[string]bool
 	rwMutexNames  map[string]bool
+	aliases       common.VarAliases
 	commentFilter *commentfilter.CommentFilter
 	typesInfo     *types.Info
 	reporter      report.Reporter
+
+	// observe, when set, is told of every held-then-acquired pair, so the
+	// edges of each function can feed the package-wide lock order graph.
+	observe func(held, acquired string, pos token.Pos)
 }
 
 func newLockOrderDetector(mutexNames, rwMutexNames map[string]bool, cf *commentfilter.CommentFilter, typesInfo *types.Info, reporter report.Reporter) *lockOrderDetector {
@@ -140,7 +145,7 @@ func (d *lockOrderDetector) scanCall(call *ast.CallExpr, held map[string]bool, e
 		return
 	}
 
-	varName := common.GetVarName(sel.X)
+	varName := d.aliases.VarName(sel.X)
 	switch {
 	case d.isAcquire(varName, sel.Sel.Name):
 		d.recordHeldEdges(varName, call.Pos(), held, edges, reported)
@@ -307,7 +312,7 @@ func (d *lockOrderDetector) releaseCallName(call *ast.CallExpr) (string, bool) {
 	if !ok {
 		return "", false
 	}
-	varName := common.GetVarName(sel.X)
+	varName := d.aliases.VarName(sel.X)
 	if !d.isRelease(varName, sel.Sel.Name) {
 		return "", false
 	}
@@ -332,7 +337,7 @@ func (d *lockOrderDetector) tryLockCallVar(expr ast.Expr) (string, bool) {
 	if !ok {
 		return "", false
 	}
-	varName := common.GetVarName(sel.X)
+	varName := d.aliases.VarName(sel.X)
 	switch sel.Sel.Name {
 	case "TryLock":
 		if d.mutexNames[varName] || d.rwMutexNames[varName] {
@@ -352,6 +357,9 @@ func (d *lockOrderDetector) recordHeldEdges(varName string, pos token.Pos, held
 			continue
 		}
 
+		if d.observe != nil {
+			d.observe(heldName, varName, pos)
+		}
 		edge := lockOrderEdge{from: heldName, to: varName}
 		reverse := lockOrderEdge{from: varName, to: heldName}
 		if _, exists := edges[edge]; !exists {
@@ -0,0 +1,135 @@
+package mutex
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/report"
+)
+
+// packageLockOrder is the package-wide lock order graph. The per-function
+// lockOrderDetector only sees both halves of an AB-BA pair when they sit in
+// the same body; here each function's edges are keyed by the identity of the
+// locks (a package-level variable or a struct field) rather than their local
+// names, so f taking s.a then s.b and g taking t.b then t.a meet.
+type packageLockOrder struct {
+	edges map[lockIdentityEdge]lockOrderSite
+}
+
+// lockIdentityEdge is a held-then-acquired relation between two locks.
+type lockIdentityEdge struct {
+	from, to types.Object
+}
+
+// lockOrderSite is the first place an edge was seen, with the lock names as
+// written in that function.
+type lockOrderSite struct {
+	pos      token.Pos
+	fn       *ast.FuncDecl
+	from, to string
+}
+
+func newPackageLockOrder() *packageLockOrder {
+	return &packageLockOrder{edges: make(map[lockIdentityEdge]lockOrderSite)}
+}
+
+// recorder returns the observe hook for the lockOrderDetector of fn. Locks
+// whose identity is local to fn (variables, parameters, embedded mutexes
+// reached through a local) cannot be shared with another function and are
+// ignored.
+func (o *packageLockOrder) recorder(fn *ast.FuncDecl, info *types.Info) func(held, acquired string, pos token.Pos) {
+	if o == nil || fn == nil || fn.Body == nil || info == nil {
+		return nil
+	}
+	identities := lockIdentities(fn.Body, info)
+	return func(held, acquired string, pos token.Pos) {
+		from, to := identities[held], identities[acquired]
+		if from == nil || to == nil || from == to {
+			return
+		}
+		edge := lockIdentityEdge{from: from, to: to}
+		if _, seen := o.edges[edge]; !seen {
+			o.edges[edge] = lockOrderSite{pos: pos, fn: fn, from: held, to: acquired}
+		}
+	}
+}
+
+// report flags each pair of locks acquired in opposite orders by two
+// different functions. Pairs within one function are left to the
+// per-function detector. The diagnostic sits on the later acquisition and
+// points at the earlier one.
+func (o *packageLockOrder) report(reporter report.Reporter) {
+	if o == nil {
+		return
+	}
+	sites := make([]lockOrderSite, 0, len(o.edges))
+	reverses := make(map[token.Pos]lockOrderSite)
+	for edge, site := range o.edges {
+		reverse, ok := o.edges[lockIdentityEdge{from: edge.to, to: edge.from}]
+		if !ok || reverse.fn == site.fn || site.pos < reverse.pos {
+			continue
+		}
+		sites = append(sites, site)
+		reverses[site.pos] = reverse
+	}
+	sort.Slice(sites, func(i, j int) bool { return sites[i].pos < sites[j].pos })
+
+	for _, site := range sites {
+		reverse := reverses[site.pos]
+		first, second := orderedLockNames(site.from, site.to)
+		reporter.AddError(site.pos, category.LockOrderCycle,
+			"inconsistent lock ordering between '"+first+"' and '"+second+"' may deadlock",
+			report.Related(reverse.pos, "'"+reverse.to+"' acquired while holding '"+reverse.from+"' in "+reverse.fn.Name.Name))
+	}
+}
+
+// lockIdentities maps the name of every lock acquired or released in body to
+// its package-wide identity, when it has one.
+func lockIdentities(body *ast.BlockStmt, info *types.Info) map[string]types.Object {
+	identities := make(map[string]types.Object)
+	ast.Inspect(body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		switch sel.Sel.Name {
+		case "Lock", "RLock", "TryLock", "TryRLock", "Unlock", "RUnlock":
+			if obj := lockIdentity(sel.X, info); obj != nil {
+				identities[common.GetVarName(sel.X)] = obj
+			}
+		}
+		return true
+	})
+	return identities
+}
+
+// lockIdentity returns the object that names the lock expr refers to across
+// functions: a package-level variable, or the struct field selected.
+func lockIdentity(expr ast.Expr, info *types.Info) types.Object {
+	switch e := common.UnwrapParenExpr(expr).(type) {
+	case *ast.Ident:
+		if v, ok := info.Uses[e].(*types.Var); ok && v.Pkg() != nil && v.Parent() == v.Pkg().Scope() {
+			return v
+		}
+	case *ast.SelectorExpr:
+		if selection, ok := info.Selections[e]; ok {
+			if selection.Kind() == types.FieldVal {
+				return selection.Obj()
+			}
+			return nil
+		}
+		// A qualified identifier: a mutex declared in another package.
+		if v, ok := info.Uses[e.Sel].(*types.Var); ok {
+			return v
+		}
+	}
+	return nil
+}
@@ -21,6 +21,21 @@ func (c *Checker) analyzeExpressionStatement(stmt *ast.ExprStmt, stats map[strin
 		return
 	}
 
+	bound, ok := c.boundMethodValue(call.Fun)
+	if !ok {
+		bound, ok = c.methodExpressionCall(call)
+	}
+	if ok {
+		varName := c.aliases.VarName(bound.X)
+		if c.mutexNames[varName] {
+			c.handleMutexCall(varName, bound.Sel.Name, common.MethodPos(call), stats)
+		}
+		if c.rwMutexNames[varName] {
+			c.handleRWMutexCall(varName, bound.Sel.Name, common.MethodPos(call), stats)
+		}
+		return
+	}
+
 	if c.applyLocalFunctionLiteralLifecycleEffects(call, stats) {
 		return
 	}
@@ -41,7 +56,7 @@ func (c *Checker) analyzeExpressionStatement(stmt *ast.ExprStmt, stats map[strin
 		return
 	}
 
-	varName := common.GetVarName(sel.X)
+	varName := c.aliases.VarName(sel.X)
 
 	// When a TryLock/TryRLock return value is ignored, the caller has no way to
 	// know whether the lock was actually acquired, so any subsequent operation
@@ -49,26 +64,26 @@ func (c *Checker) analyzeExpressionStatement(stmt *ast.ExprStmt, stats map[strin
 	switch sel.Sel.Name {
 	case "TryLock":
 		if c.mutexNames[varName] {
-			c.errorCollector.AddError(call.Pos(), category.UncheckedTryLock, "mutex '"+varName+"' TryLock return value not checked, lock may not be held")
+			c.errorCollector.AddError(common.MethodPos(call), category.UncheckedTryLock, "mutex '"+varName+"' TryLock return value not checked, lock may not be held")
 			return
 		}
 		if c.rwMutexNames[varName] {
-			c.errorCollector.AddError(call.Pos(), category.UncheckedTryLock, "rwmutex '"+varName+"' TryLock return value not checked, lock may not be held")
+			c.errorCollector.AddError(common.MethodPos(call), category.UncheckedTryLock, "rwmutex '"+varName+"' TryLock return value not checked, lock may not be held")
 			return
 		}
 	case "TryRLock":
 		if c.rwMutexNames[varName] {
-			c.errorCollector.AddError(call.Pos(), category.UncheckedTryLock, "rwmutex '"+varName+"' TryRLock return value not checked, lock may not be held")
+			c.errorCollector.AddError(common.MethodPos(call), category.UncheckedTryLock, "rwmutex '"+varName+"' TryRLock return value not checked, lock may not be held")
 			return
 		}
 	}
 
 	if c.mutexNames[varName] {
-		c.handleMutexCall(varName, sel.Sel.Name, call.Pos(), stats)
+		c.handleMutexCall(varName, sel.Sel.Name, common.MethodPos(call), stats)
 	}
 
 	if c.rwMutexNames[varName] {
-		c.handleRWMutexCall(varName, sel.Sel.Name, call.Pos(), stats)
+		c.handleRWMutexCall(varName, sel.Sel.Name, common.MethodPos(call), stats)
 	}
 
 	c.applyLocalMethodLifecycleEffects(call, stats)
@@ -76,6 +91,7 @@ func (c *Checker) analyzeExpressionStatement(stmt *ast.ExprStmt, stats map[strin
 
 // handleMutexCall processes mutex method calls
 func (c *Checker) handleMutexCall(varName, methodName string, pos token.Pos, stats map[string]*Stats) {
+	methodName = c.lockers.Canonical(methodName)
 	if c.wrapper.resolve(varName, methodName) {
 		return
 	}
@@ -83,7 +99,8 @@ func (c *Checker) handleMutexCall(varName, methodName string, pos token.Pos, sta
 	switch methodName {
 	case "Lock":
 		if stats[varName].lock > 0 {
-			c.errorCollector.AddError(pos, category.DoubleLock, "mutex '"+varName+"' is re-locked before unlock")
+			c.errorCollector.AddError(pos, category.DoubleLock, "mutex '"+varName+"' is re-locked before unlock",
+				heldAt(stats[varName].lockPos, "mutex", varName, "locked")...)
 		}
 		if stats[varName].borrowedLock > 0 {
 			stats[varName].borrowedLock--
@@ -140,7 +157,8 @@ func (c *Checker) handleRWMutexCall(varName, methodName string, pos token.Pos, s
 			// Read-to-write upgrade on the same goroutine: Lock blocks waiting
 			// for this goroutine's own read lock to be released. Guaranteed
 			// self-deadlock (RWMutex is not upgradable).
-			c.errorCollector.AddError(pos, category.RWMutexRecursiveLock, "rwmutex '"+varName+"' attempts write Lock while read lock is held")
+			c.errorCollector.AddError(pos, category.RWMutexRecursiveLock, "rwmutex '"+varName+"' attempts write Lock while read lock is held",
+				heldAt(stats[varName].rlockPos, "rwmutex", varName, "rlocked")...)
 		}
 		if stats[varName].borrowedLock > 0 {
 			stats[varName].borrowedLock--
@@ -162,7 +180,8 @@ func (c *Checker) handleRWMutexCall(varName, methodName string, pos token.Pos, s
 		// Unlock called when only a read lock is held.
 		// Correct the state as if RUnlock was called to avoid cascading errors.
 		if stats[varName].rlock > 0 && stats[varName].lock == 0 {
-			c.errorCollector.AddError(pos, category.RWMutexAPIMismatch, "rwmutex '"+varName+"' Unlock called but only read lock is held, did you mean RUnlock?")
+			c.errorCollector.AddError(pos, category.RWMutexAPIMismatch, "rwmutex '"+varName+"' Unlock called but only read lock is held, did you mean RUnlock?",
+				heldAt(stats[varName].rlockPos, "rwmutex", varName, "rlocked")...)
 			stats[varName].rlock--
 			stats[varName].removeFirstRLockPos()
 			return
@@ -186,7 +205,8 @@ func (c *Checker) handleRWMutexCall(varName, methodName string, pos token.Pos, s
 		// TryRLock is excluded: it returns false instead of blocking, so it does
 		// not deadlock.
 		if methodName == "RLock" && stats[varName].lock > 0 {
-			c.errorCollector.AddError(pos, category.RWMutexRecursiveLock, "rwmutex '"+varName+"' attempts read RLock while write lock is held")
+			c.errorCollector.AddError(pos, category.RWMutexRecursiveLock, "rwmutex '"+varName+"' attempts read RLock while write lock is held",
+				heldAt(stats[varName].lockPos, "rwmutex", varName, "locked")...)
 		}
 		if stats[varName].borrowedRLock > 0 {
 			stats[varName].borrowedRLock--
@@ -199,7 +219,8 @@ func (c *Checker) handleRWMutexCall(varName, methodName string, pos token.Pos, s
 		// RUnlock called when only a write lock is held.
 		// Correct the state as if Unlock was called to avoid cascading errors.
 		if stats[varName].lock > 0 && stats[varName].rlock == 0 {
-			c.errorCollector.AddError(pos, category.RWMutexAPIMismatch, "rwmutex '"+varName+"' RUnlock called but only write lock is held, did you mean Unlock?")
+			c.errorCollector.AddError(pos, category.RWMutexAPIMismatch, "rwmutex '"+varName+"' RUnlock called but only write lock is held, did you mean Unlock?",
+				heldAt(stats[varName].lockPos, "rwmutex", varName, "locked")...)
 			stats[varName].lock--
 			stats[varName].removeFirstLockPos()
 			return
@@ -218,15 +239,20 @@ func (c *Checker) handleRWMutexCall(varName, methodName string, pos token.Pos, s
 }
 
 // analyzeAssignStatement handles assignments: collection-length bookkeeping,
-// potential-panic-while-locked reporting, and TryLock result tracking (the
-// latter delegated to the per-function tryLockTracker).
+// potential-panic-while-locked reporting, TryLock result tracking (delegated to
+// the per-function tryLockTracker), mutex method-value bindings and mutexes
+// reassigned while locked.
 func (c *Checker) analyzeAssignStatement(stmt *ast.AssignStmt, stats map[string]*Stats) {
 	c.panicDetector.recordCollectionLengthsFromAssign(stmt)
 	c.panicDetector.reportPotentialPanicWhileLocked(stmt, stats)
 	c.tryLock.recordAssignment(stmt)
+	c.recordMethodValueAssign(stmt)
+	c.reportWriteUnderRLock(stmt, stats)
+	c.reportReassignWhileLocked(stmt, stats)
 }
 
 func (c *Checker) analyzeDeclStatement(stmt *ast.DeclStmt, stats map[string]*Stats) {
 	c.panicDetector.recordCollectionLengthsFromDecl(stmt)
+	c.recordMethodValueDecl(stmt)
 	c.panicDetector.reportPotentialPanicWhileLocked(stmt, stats)
 }
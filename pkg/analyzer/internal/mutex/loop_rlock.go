@@ -0,0 +1,191 @@
+package mutex
+
+import (
+	"go/ast"
+	"go/token"
+	"maps"
+	"slices"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/report"
+)
+
+// detectLoopRLocks finds the RLock calls in a loop body that never calls
+// RUnlock on the same rwmutex:
+//
+//	for {
+//	    mu.RLock()
+//	    if done {
+//	        break
+//	    }
+//	}
+//
+// Every iteration takes another read lock and none is released, so the
+// reader count only grows and the next writer blocks forever. The result
+// maps each such RLock to its rwmutex; reportLoopRLocks reports them with a
+// message naming the loop, so the unmatched-lock reports skip them.
+func (c *Checker) detectLoopRLocks(fn *ast.FuncDecl) map[token.Pos]string {
+	locks := make(map[token.Pos]string)
+	if fn == nil || fn.Body == nil || len(c.rwMutexNames) == 0 {
+		return locks
+	}
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch loop := n.(type) {
+		case *ast.ForStmt:
+			c.markLoopRLocks(loop, loop.Body, locks)
+		case *ast.RangeStmt:
+			c.markLoopRLocks(loop, loop.Body, locks)
+		}
+		return true
+	})
+	return locks
+}
+
+// markLoopRLocks records the unreleased RLocks of one loop. An rwmutex
+// reached through a variable the loop declares, as in
+// `for _, blk := range blks { blk.mu.RLock() }`, is a different lock on each
+// iteration, so it is left to the wrapper and unmatched-lock checks. So is
+// an RLock every path from which leaves the loop through a break or return:
+// it is taken at most once.
+func (c *Checker) markLoopRLocks(loop ast.Node, body *ast.BlockStmt, locks map[token.Pos]string) {
+	var path []ast.Node
+	ast.Inspect(body, func(n ast.Node) bool {
+		if n == nil {
+			path = path[:len(path)-1]
+			return true
+		}
+		path = append(path, n)
+		switch x := n.(type) {
+		case *ast.FuncLit:
+			path = path[:len(path)-1]
+			return false
+		case *ast.CallExpr:
+			sel, ok := x.Fun.(*ast.SelectorExpr)
+			if !ok || sel.Sel.Name != "RLock" || c.commentFilter.ShouldSkipCall(x) {
+				return true
+			}
+			if c.declaredInLoop(loop, sel.X) {
+				return true
+			}
+			if varName := c.aliases.VarName(sel.X); c.rwMutexNames[varName] && !mentionsMethodCall(c.aliases, body, varName, "RUnlock") &&
+				c.reachesNextIteration(path) {
+				locks[x.Pos()] = varName
+			}
+		}
+		return true
+	})
+}
+
+// reachesNextIteration reports whether some path from the last node of
+// path, which runs from a loop body down to an RLock call, reaches a
+// continue or the end of the body. The statements after each enclosing
+// statement are followed outwards until one of them always leaves the flow.
+func (c *Checker) reachesNextIteration(path []ast.Node) bool {
+	for i := len(path) - 2; i >= 0; i-- {
+		var list []ast.Stmt
+		inSwitch := false
+		switch holder := path[i].(type) {
+		case *ast.BlockStmt:
+			// The body of a switch or select lists clauses, not statements.
+			switch path[i+1].(type) {
+			case *ast.CaseClause, *ast.CommClause:
+				continue
+			}
+			list = holder.List
+		case *ast.CaseClause:
+			list, inSwitch = holder.Body, true
+		case *ast.CommClause:
+			list, inSwitch = holder.Body, true
+		default:
+			continue
+		}
+		idx := slices.IndexFunc(list, func(stmt ast.Stmt) bool { return stmt == path[i+1] })
+		if idx < 0 {
+			continue
+		}
+		next, fallsOff := c.tailFlow(list[idx+1:], inSwitch)
+		if next {
+			return true
+		}
+		if !fallsOff {
+			return false
+		}
+	}
+	return true
+}
+
+// tailFlow reports whether stmts may run a continue (next) and whether they
+// may complete normally (fallsOff). In a switch or select clause an
+// unlabeled break completes the clause.
+func (c *Checker) tailFlow(stmts []ast.Stmt, inSwitch bool) (next, fallsOff bool) {
+	for _, stmt := range stmts {
+		if containsContinue(stmt) {
+			return true, true
+		}
+		if branch, ok := stmt.(*ast.BranchStmt); ok && inSwitch && branch.Tok == token.BREAK && branch.Label == nil {
+			return false, true
+		}
+		if c.termination.statementAlwaysTerminates(stmt) {
+			return false, false
+		}
+	}
+	return false, true
+}
+
+// containsContinue reports whether stmt holds a continue that can target the
+// enclosing loop: a labeled one, or an unlabeled one outside nested loops and
+// function literals.
+func containsContinue(stmt ast.Stmt) bool {
+	found := false
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ForStmt, *ast.RangeStmt:
+			found = found || containsLabeledContinue(x)
+			return false
+		case *ast.BranchStmt:
+			found = found || x.Tok == token.CONTINUE
+		}
+		return !found
+	})
+	return found
+}
+
+// containsLabeledContinue reports whether n holds a labeled continue outside
+// function literals.
+func containsLabeledContinue(n ast.Node) bool {
+	found := false
+	ast.Inspect(n, func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.BranchStmt:
+			found = found || (x.Tok == token.CONTINUE && x.Label != nil)
+		}
+		return !found
+	})
+	return found
+}
+
+// declaredInLoop reports whether the variable at the root of expr is
+// declared by loop: in its header or in its body.
+func (c *Checker) declaredInLoop(loop ast.Node, expr ast.Expr) bool {
+	root := rootIdent(expr)
+	if root == nil || c.typesInfo == nil {
+		return false
+	}
+	obj := c.typesInfo.Uses[root]
+	return obj != nil && obj.Pos() >= loop.Pos() && obj.Pos() < loop.End()
+}
+
+// reportLoopRLocks reports the RLock calls found by detectLoopRLocks.
+func (c *Checker) reportLoopRLocks() {
+	if c.rawBodyEffects {
+		return
+	}
+	for _, pos := range slices.Sorted(maps.Keys(c.loopRLocks)) {
+		c.errorCollector.AddError(pos, category.LockWithoutUnlock,
+			report.FormatMessage("rwmutex.rlock_in_loop", c.loopRLocks[pos]))
+	}
+}
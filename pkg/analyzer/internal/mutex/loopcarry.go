@@ -20,6 +20,7 @@ import (
 type loopCarryAnalyzer struct {
 	mutexNames     map[string]bool
 	rwMutexNames   map[string]bool
+	aliases        common.VarAliases
 	commentFilter  *commentfilter.CommentFilter
 	errorCollector report.Reporter
 	termination    *terminationAnalyzer
@@ -41,6 +42,17 @@ func newLoopCarryAnalyzer(
 	}
 }
 
+// withAliases returns lc resolving names through aliases, copied when
+// either has any.
+func (lc *loopCarryAnalyzer) withAliases(aliases common.VarAliases) *loopCarryAnalyzer {
+	if len(aliases) == 0 && len(lc.aliases) == 0 {
+		return lc
+	}
+	fork := *lc
+	fork.aliases = aliases
+	return &fork
+}
+
 func (lc *loopCarryAnalyzer) reportDeferredUnlocksInLoop(body *ast.BlockStmt) {
 	if body == nil {
 		return
@@ -67,7 +79,7 @@ func (lc *loopCarryAnalyzer) reportDeferredUnlocksInLoopStatements(stmts []ast.S
 			if !ok {
 				continue
 			}
-			varName := common.GetVarName(sel.X)
+			varName := lc.aliases.VarName(sel.X)
 			switch sel.Sel.Name {
 			case "Lock":
 				if lc.mutexNames[varName] || lc.rwMutexNames[varName] {
@@ -94,7 +106,7 @@ func (lc *loopCarryAnalyzer) reportDeferredUnlocksInLoopStatements(stmts []ast.S
 			if !ok {
 				continue
 			}
-			varName := common.GetVarName(sel.X)
+			varName := lc.aliases.VarName(sel.X)
 			switch sel.Sel.Name {
 			case "Unlock":
 				if locked[varName] {
@@ -197,7 +209,7 @@ func (lc *loopCarryAnalyzer) loopMayBreakHoldingMutex(stmts []ast.Stmt, varName
 			}
 
 			sel, ok := call.Fun.(*ast.SelectorExpr)
-			if !ok || common.GetVarName(sel.X) != varName {
+			if !ok || lc.aliases.VarName(sel.X) != varName {
 				continue
 			}
 
@@ -300,7 +312,7 @@ func (lc *loopCarryAnalyzer) loopMayCarryMutexPastIteration(stmts []ast.Stmt, va
 			}
 
 			sel, ok := call.Fun.(*ast.SelectorExpr)
-			if !ok || common.GetVarName(sel.X) != varName {
+			if !ok || lc.aliases.VarName(sel.X) != varName {
 				continue
 			}
 
@@ -16,14 +16,23 @@ import (
 // state. It depends only on a reporter and type information, so it can be
 // constructed and exercised without a full Checker.
 type loopMutexDetector struct {
-	reporter  report.Reporter
-	typesInfo *types.Info
+	reporter     report.Reporter
+	typesInfo    *types.Info
+	syncPackages []string
 }
 
 func newLoopMutexDetector(reporter report.Reporter, typesInfo *types.Info) *loopMutexDetector {
 	return &loopMutexDetector{reporter: reporter, typesInfo: typesInfo}
 }
 
+// loopMutexes returns the loop mutex detector for the current function,
+// matching the sync forks configured for the package.
+func (c *Checker) loopMutexes() *loopMutexDetector {
+	d := newLoopMutexDetector(c.errorCollector, c.typesInfo)
+	d.syncPackages = c.syncPackages
+	return d
+}
+
 // check examines the top-level statements of a loop body. Nested loops are
 // handled when they themselves are analysed as for/range statements. Function
 // literals inside the loop are skipped to avoid false positives for patterns
@@ -82,8 +91,8 @@ func (d *loopMutexDetector) reportAssign(s *ast.AssignStmt, loopBody *ast.BlockS
 // is shared with per-iteration goroutines that are joined before the iteration
 // ends, in which case a fresh mutex per iteration is intentional.
 func (d *loopMutexDetector) reportMutexDecl(typ types.Type, name string, pos token.Pos, loopBody *ast.BlockStmt) {
-	isMutex := common.IsMutex(typ)
-	isRWMutex := common.IsRWMutex(typ)
+	isMutex := common.IsMutex(typ, d.syncPackages...)
+	isRWMutex := common.IsRWMutex(typ, d.syncPackages...)
 	if !isMutex && !isRWMutex {
 		return
 	}
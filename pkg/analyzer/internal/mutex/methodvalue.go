@@ -0,0 +1,122 @@
+package mutex
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
+)
+
+// recordMethodValueAssign tracks `f := mu.Unlock` style bindings of a mutex
+// method value to a local variable, so a later `f()` or `defer f()` is treated
+// as the bound call. Rebinding the variable to anything else forgets it.
+func (c *Checker) recordMethodValueAssign(stmt *ast.AssignStmt) {
+	if stmt == nil || (stmt.Tok != token.ASSIGN && stmt.Tok != token.DEFINE) {
+		return
+	}
+	for i, lhs := range stmt.Lhs {
+		ident, ok := lhs.(*ast.Ident)
+		if !ok || ident.Name == "_" {
+			continue
+		}
+		var rhs ast.Expr
+		if len(stmt.Lhs) == len(stmt.Rhs) {
+			rhs = stmt.Rhs[i]
+		}
+		c.recordMethodValue(ident, rhs)
+	}
+}
+
+// recordMethodValueDecl is recordMethodValueAssign for `var f = mu.Unlock`.
+func (c *Checker) recordMethodValueDecl(stmt *ast.DeclStmt) {
+	gen, ok := stmt.Decl.(*ast.GenDecl)
+	if !ok || gen.Tok != token.VAR {
+		return
+	}
+	for _, spec := range gen.Specs {
+		vs, ok := spec.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+		for i, name := range vs.Names {
+			var rhs ast.Expr
+			if i < len(vs.Values) {
+				rhs = vs.Values[i]
+			}
+			c.recordMethodValue(name, rhs)
+		}
+	}
+}
+
+func (c *Checker) recordMethodValue(ident *ast.Ident, rhs ast.Expr) {
+	obj := c.typesInfo.ObjectOf(ident)
+	if obj == nil {
+		return
+	}
+	sel, ok := c.mutexMethodValue(rhs)
+	if !ok {
+		delete(c.methodValues, obj)
+		return
+	}
+	if c.methodValues == nil {
+		c.methodValues = make(map[types.Object]*ast.SelectorExpr)
+	}
+	c.methodValues[obj] = sel
+}
+
+// mutexMethodValue reports whether expr is an uncalled lock or unlock method
+// of a tracked mutex, e.g. the `mu.Unlock` in `f := mu.Unlock`.
+func (c *Checker) mutexMethodValue(expr ast.Expr) (*ast.SelectorExpr, bool) {
+	sel, ok := common.UnwrapParenExpr(expr).(*ast.SelectorExpr)
+	if !ok {
+		return nil, false
+	}
+	if !isLockMethod(sel.Sel.Name) && !isUnlockMethod(sel.Sel.Name) {
+		return nil, false
+	}
+	varName := c.aliases.VarName(sel.X)
+	if !c.mutexNames[varName] && !c.rwMutexNames[varName] {
+		return nil, false
+	}
+	if selection, ok := c.typesInfo.Selections[sel]; !ok || selection.Kind() != types.MethodVal {
+		return nil, false
+	}
+	return sel, true
+}
+
+// boundMethodValue returns the mutex method selector bound to the variable
+// called by fun, if any.
+func (c *Checker) boundMethodValue(fun ast.Expr) (*ast.SelectorExpr, bool) {
+	ident, ok := common.UnwrapParenExpr(fun).(*ast.Ident)
+	if !ok || len(c.methodValues) == 0 {
+		return nil, false
+	}
+	sel, ok := c.methodValues[c.typesInfo.ObjectOf(ident)]
+	return sel, ok
+}
+
+// methodExpressionCall matches a lock or unlock method expression called on
+// a tracked mutex, e.g. `(*sync.Mutex).Lock(&mu)`, and returns the selector
+// of the equivalent `mu.Lock()` call.
+func (c *Checker) methodExpressionCall(call *ast.CallExpr) (*ast.SelectorExpr, bool) {
+	if len(call.Args) != 1 {
+		return nil, false
+	}
+	sel, ok := common.UnwrapParenExpr(call.Fun).(*ast.SelectorExpr)
+	if !ok || (!isLockMethod(sel.Sel.Name) && !isUnlockMethod(sel.Sel.Name)) {
+		return nil, false
+	}
+	if selection, ok := c.typesInfo.Selections[sel]; !ok || selection.Kind() != types.MethodExpr {
+		return nil, false
+	}
+	recv := common.UnwrapParenExpr(call.Args[0])
+	if unary, ok := recv.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		recv = common.UnwrapParenExpr(unary.X)
+	}
+	varName := c.aliases.VarName(recv)
+	if !c.mutexNames[varName] && !c.rwMutexNames[varName] {
+		return nil, false
+	}
+	return &ast.SelectorExpr{X: recv, Sel: sel.Sel}, true
+}
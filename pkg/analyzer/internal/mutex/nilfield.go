@@ -0,0 +1,177 @@
+package mutex
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/driver"
+)
+
+// pointerFieldUsage records, for one *sync.Mutex / *sync.RWMutex struct field,
+// where it is locked and whether anything in the package may set it.
+type pointerFieldUsage struct {
+	owner    string
+	rw       bool
+	acquires []token.Pos
+	assigned bool
+}
+
+// CheckNilPointerFields reports Lock/RLock calls on an unexported
+// *sync.Mutex or *sync.RWMutex struct field that nothing in the package ever
+// assigns (GCL1016). Such a field stays nil, so the first Lock dereferences a
+// nil pointer and panics.
+//
+// The field counts as assigned when it is keyed in a composite literal, the
+// struct is built with an unkeyed literal, or the field is used any other way
+// than as the receiver of a method call (assigned, address taken, compared
+// against nil, passed on). Assignments in skipped files still count, but
+// only calls in analyzed files are reported.
+func CheckNilPointerFields(pkg *driver.Package) {
+	usage := make(map[*types.Var]*pointerFieldUsage)
+	var order []*types.Var
+	lookup := func(field *types.Var, owner string, rw bool) *pointerFieldUsage {
+		u := usage[field]
+		if u == nil {
+			u = &pointerFieldUsage{owner: owner, rw: rw}
+			usage[field] = u
+			order = append(order, field)
+		}
+		return u
+	}
+
+	receivers := make(map[*ast.SelectorExpr]bool)
+	pkg.Inspect(func(n ast.Node, skipped bool) bool {
+		switch node := n.(type) {
+		case *ast.CallExpr:
+			method, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			recv, ok := common.UnwrapParenExpr(method.X).(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			field, owner, rw, ok := pointerMutexField(recv, pkg.Info, pkg.SyncPackages)
+			if !ok {
+				return true
+			}
+			receivers[recv] = true
+			u := lookup(field, owner, rw)
+			if !skipped && isAcquireMethod(method.Sel.Name) {
+				u.acquires = append(u.acquires, node.Pos())
+			}
+		case *ast.SelectorExpr:
+			if receivers[node] {
+				return true
+			}
+			if field, owner, rw, ok := pointerMutexField(node, pkg.Info, pkg.SyncPackages); ok {
+				lookup(field, owner, rw).assigned = true
+			}
+		case *ast.CompositeLit:
+			markLiteralFields(node, pkg.Info, func(field *types.Var) {
+				if isPointerMutexField(field, pkg.SyncPackages) {
+					lookup(field, "?", false).assigned = true
+				}
+			})
+		}
+		return true
+	})
+
+	for _, field := range order {
+		u := usage[field]
+		if u.assigned || field.Exported() {
+			continue
+		}
+		kind := "mutex"
+		if u.rw {
+			kind = "rwmutex"
+		}
+		for _, pos := range u.acquires {
+			pkg.Reporter.AddError(pos, category.NilMutexField,
+				kind+" field '"+u.owner+"."+field.Name()+"' is a pointer never assigned in the package, so locking it dereferences nil")
+		}
+	}
+}
+
+// isAcquireMethod reports whether method takes the lock.
+func isAcquireMethod(method string) bool {
+	switch method {
+	case "Lock", "RLock", "TryLock", "TryRLock":
+		return true
+	}
+	return false
+}
+
+// markLiteralFields calls mark for every struct field a composite literal
+// sets. An unkeyed literal sets every field.
+func markLiteralFields(lit *ast.CompositeLit, info *types.Info, mark func(*types.Var)) {
+	if len(lit.Elts) == 0 {
+		return
+	}
+	tv, ok := info.Types[lit]
+	if !ok {
+		return
+	}
+	typ := tv.Type
+	if ptr, ok := types.Unalias(typ).(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	st, ok := typ.Underlying().(*types.Struct)
+	if !ok {
+		return
+	}
+	if _, keyed := lit.Elts[0].(*ast.KeyValueExpr); !keyed {
+		for i := range st.NumFields() {
+			mark(st.Field(i).Origin())
+		}
+		return
+	}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		if field, ok := info.Uses[key].(*types.Var); ok && field.IsField() {
+			mark(field.Origin())
+		}
+	}
+}
+
+// pointerMutexField reports whether sel selects a struct field of type
+// *sync.Mutex or *sync.RWMutex and returns the field and the name of the
+// struct type it is selected from.
+func pointerMutexField(sel *ast.SelectorExpr, info *types.Info, syncPackages []string) (*types.Var, string, bool, bool) {
+	selection, ok := info.Selections[sel]
+	if !ok || selection.Kind() != types.FieldVal {
+		return nil, "", false, false
+	}
+	field, ok := selection.Obj().(*types.Var)
+	if !ok || !isPointerMutexField(field, syncPackages) {
+		return nil, "", false, false
+	}
+	owner := "?"
+	recv := selection.Recv()
+	if ptr, ok := types.Unalias(recv).(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+	if named, ok := types.Unalias(recv).(*types.Named); ok {
+		owner = named.Obj().Name()
+	}
+	return field.Origin(), owner, common.IsRWMutex(field.Type(), syncPackages...), true
+}
+
+// isPointerMutexField reports whether field is a *sync.Mutex or
+// *sync.RWMutex, the sync forks of syncPackages included.
+func isPointerMutexField(field *types.Var, syncPackages []string) bool {
+	if _, isPtr := types.Unalias(field.Type()).(*types.Pointer); !isPtr {
+		return false
+	}
+	return common.IsMutex(field.Type(), syncPackages...) || common.IsRWMutex(field.Type(), syncPackages...)
+}
@@ -0,0 +1,119 @@
+package mutex
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+)
+
+// reportNilPointerLocals flags Lock/RLock calls on a *sync.Mutex or
+// *sync.RWMutex local declared with `var mu *sync.Mutex` (or `= nil`) that
+// the function never sets. The pointer stays nil, so the call dereferences
+// it and panics. Like CheckNilPointerFields, any use other than as a method
+// receiver — an assignment, `&mu`, a comparison, passing it on — counts as
+// possibly setting it.
+func (c *Checker) reportNilPointerLocals(fn *ast.FuncDecl) {
+	if c.rawBodyEffects || fn.Body == nil {
+		return
+	}
+	nilLocals := make(map[types.Object]bool)
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		decl, ok := n.(*ast.DeclStmt)
+		if !ok {
+			return true
+		}
+		gen, ok := decl.Decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			return true
+		}
+		for _, spec := range gen.Specs {
+			vs, ok := spec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+			for i, name := range vs.Names {
+				if i < len(vs.Values) && !isNilIdent(vs.Values[i], c.typesInfo) {
+					continue
+				}
+				if obj := c.typesInfo.Defs[name]; obj != nil && isPointerMutexVar(obj, c.syncPackages) {
+					nilLocals[obj] = true
+				}
+			}
+		}
+		return true
+	})
+	if len(nilLocals) == 0 {
+		return
+	}
+
+	type acquire struct {
+		call   *ast.CallExpr
+		recv   *ast.Ident
+		method string
+	}
+	var acquires []acquire
+	receivers := make(map[*ast.Ident]bool)
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		ident, ok := common.UnwrapParenExpr(sel.X).(*ast.Ident)
+		if !ok || !nilLocals[c.typesInfo.Uses[ident]] {
+			return true
+		}
+		receivers[ident] = true
+		if isAcquireMethod(sel.Sel.Name) && !c.commentFilter.ShouldSkipCall(call) {
+			acquires = append(acquires, acquire{call, ident, sel.Sel.Name})
+		}
+		return true
+	})
+	for ident, obj := range c.typesInfo.Uses {
+		if nilLocals[obj] && !receivers[ident] {
+			delete(nilLocals, obj)
+		}
+	}
+
+	for _, a := range acquires {
+		obj := c.typesInfo.Uses[a.recv]
+		if !nilLocals[obj] {
+			continue
+		}
+		kind := "mutex"
+		if common.IsRWMutex(obj.Type(), c.syncPackages...) {
+			kind = "rwmutex"
+		}
+		c.errorCollector.AddError(a.call.Pos(), category.NilMutexLocal,
+			kind+" '"+a.recv.Name+"' is a nil pointer when "+a.method+" is called")
+	}
+}
+
+// isPointerMutexVar reports whether obj is a *sync.Mutex or *sync.RWMutex
+// variable, the sync forks of syncPackages included.
+func isPointerMutexVar(obj types.Object, syncPackages []string) bool {
+	v, ok := obj.(*types.Var)
+	if !ok {
+		return false
+	}
+	if _, isPtr := types.Unalias(v.Type()).(*types.Pointer); !isPtr {
+		return false
+	}
+	return common.IsMutex(v.Type(), syncPackages...) || common.IsRWMutex(v.Type(), syncPackages...)
+}
+
+// isNilIdent reports whether expr is the predeclared nil.
+func isNilIdent(expr ast.Expr, info *types.Info) bool {
+	ident, ok := common.UnwrapParenExpr(expr).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	_, isNil := info.Uses[ident].(*types.Nil)
+	return isNil
+}
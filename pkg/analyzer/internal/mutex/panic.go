@@ -68,14 +68,17 @@ func (d *lockedPanicDetector) reportPotentialPanicWhileLocked(stmt ast.Stmt, sta
 			continue
 		}
 		if d.mutexNames[name] && remainingLockCount(st.lock, st.deferUnlock) > 0 {
-			d.reporter.AddError(panicPos, category.PanicBeforeUnlock, "mutex '"+name+"' may remain locked if index expression panics before unlock")
+			d.reporter.AddError(panicPos, category.PanicBeforeUnlock, "mutex '"+name+"' may remain locked if index expression panics before unlock",
+				heldAt(st.lockPos, "mutex", name, "locked")...)
 		}
 		if d.rwMutexNames[name] {
 			if remainingLockCount(st.lock, st.deferUnlock) > 0 {
-				d.reporter.AddError(panicPos, category.PanicBeforeUnlock, "rwmutex '"+name+"' may remain locked if index expression panics before unlock")
+				d.reporter.AddError(panicPos, category.PanicBeforeUnlock, "rwmutex '"+name+"' may remain locked if index expression panics before unlock",
+					heldAt(st.lockPos, "rwmutex", name, "locked")...)
 			}
 			if remainingLockCount(st.rlock, st.deferRUnlock) > 0 {
-				d.reporter.AddError(panicPos, category.PanicBeforeUnlock, "rwmutex '"+name+"' may remain rlocked if index expression panics before runlock")
+				d.reporter.AddError(panicPos, category.PanicBeforeUnlock, "rwmutex '"+name+"' may remain rlocked if index expression panics before runlock",
+					heldAt(st.rlockPos, "rwmutex", name, "rlocked")...)
 			}
 		}
 	}
@@ -218,3 +221,89 @@ func (d *lockedPanicDetector) recordCollectionLength(name string, expr ast.Expr)
 	}
 	delete(d.collectionLengths, name)
 }
+
+// reportUnlockSkippedByPanic flags locks still held at an explicit panic or
+// runtime.Goexit whose release follows it in the same statement list. That
+// release can never run, so a caller that recovers from the panic, or any
+// other goroutine once this one exits, finds the mutex locked for good.
+func (c *Checker) reportUnlockSkippedByPanic(stmt ast.Stmt, rest []ast.Stmt, stats map[string]*Stats) {
+	if c.rawBodyEffects {
+		return
+	}
+	exprStmt, ok := stmt.(*ast.ExprStmt)
+	if !ok {
+		return
+	}
+	call, ok := common.UnwrapParenExpr(exprStmt.X).(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	what := c.unwindingCallName(call)
+	if what == "" {
+		return
+	}
+
+	released := unreachableReleases(c.aliases, rest)
+	for name, st := range stats {
+		if st == nil || released[name] == nil {
+			continue
+		}
+		kind := "mutex"
+		if c.rwMutexNames[name] {
+			kind = "rwmutex"
+		} else if !c.mutexNames[name] {
+			continue
+		}
+		if released[name]["Unlock"] && remainingLockCount(st.lock, st.deferUnlock) > 0 {
+			c.errorCollector.AddError(call.Pos(), category.PanicBeforeUnlock,
+				kind+" '"+name+"' may remain locked: "+what+" makes the later Unlock unreachable",
+				heldAt(st.lockPos, kind, name, "locked")...)
+		}
+		if kind == "rwmutex" && released[name]["RUnlock"] && remainingLockCount(st.rlock, st.deferRUnlock) > 0 {
+			c.errorCollector.AddError(call.Pos(), category.PanicBeforeUnlock,
+				"rwmutex '"+name+"' may remain rlocked: "+what+" makes the later RUnlock unreachable",
+				heldAt(st.rlockPos, kind, name, "rlocked")...)
+		}
+	}
+}
+
+// unwindingCallName returns "panic" or "runtime.Goexit" when call unwinds
+// the goroutine while running its deferred calls, and "" otherwise. os.Exit
+// and log.Fatal end the process, so a lock they skip cannot be observed.
+func (c *Checker) unwindingCallName(call *ast.CallExpr) string {
+	if ident, ok := call.Fun.(*ast.Ident); ok && c.termination.isBuiltinPanic(ident) {
+		return "panic"
+	}
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Goexit" && c.termination.callTerminatesExecution(call) {
+		return "runtime.Goexit"
+	}
+	return ""
+}
+
+// unreachableReleases collects the Unlock and RUnlock calls in stmts, by
+// mutex name, ignoring function literals that run elsewhere.
+func unreachableReleases(aliases common.VarAliases, stmts []ast.Stmt) map[string]map[string]bool {
+	released := make(map[string]map[string]bool)
+	for _, stmt := range stmts {
+		ast.Inspect(stmt, func(n ast.Node) bool {
+			if _, ok := n.(*ast.FuncLit); ok {
+				return false
+			}
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || (sel.Sel.Name != "Unlock" && sel.Sel.Name != "RUnlock") {
+				return true
+			}
+			name := aliases.VarName(sel.X)
+			if released[name] == nil {
+				released[name] = make(map[string]bool)
+			}
+			released[name][sel.Sel.Name] = true
+			return true
+		})
+	}
+	return released
+}
@@ -0,0 +1,146 @@
+package mutex
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+)
+
+// reportReadOnlyWriteLock flags a write Lock on an rwmutex whose critical
+// section only reads: the statements up to the matching Unlock in the same
+// block, or the rest of the function body when the Unlock is deferred right
+// after the Lock. RLock would let readers run together. Any call the section
+// makes may write through it, so only builtins and conversions are allowed;
+// the check is a heuristic and opt-in.
+func (c *Checker) reportReadOnlyWriteLock(stmt ast.Stmt, rest []ast.Stmt) {
+	if c.rawBodyEffects || c.function == nil || c.function.Body == nil {
+		return
+	}
+	lockCall, varName, lockMethod, ok := c.mutexCallStatement(stmt)
+	if !ok || lockMethod != "Lock" || !c.rwMutexNames[varName] {
+		return
+	}
+	section, ok := c.writeLockSection(stmt, varName, rest)
+	if !ok || len(section) == 0 {
+		return
+	}
+	for _, s := range section {
+		if !c.isReadOnly(s) {
+			return
+		}
+	}
+	c.errorCollector.AddError(lockCall.Pos(), category.LockWhereRLockSuffices,
+		"rwmutex '"+varName+"' Lock with read-only critical section; consider RLock")
+}
+
+// writeLockSection returns the statements of rest guarded by the Lock in
+// stmt: those before the first Unlock of varName, or, when rest opens with
+// `defer varName.Unlock()` and stmt sits directly in the function body, the
+// remaining statements of the body.
+func (c *Checker) writeLockSection(stmt ast.Stmt, varName string, rest []ast.Stmt) ([]ast.Stmt, bool) {
+	if len(rest) > 0 && c.isDeferredUnlockOf(rest[0], varName) {
+		for _, top := range c.function.Body.List {
+			if top == stmt {
+				return rest[1:], true
+			}
+		}
+		return nil, false
+	}
+	for i, next := range rest {
+		if _, nextVar, nextMethod, ok := c.mutexCallStatement(next); ok && nextVar == varName && nextMethod == "Unlock" {
+			return rest[:i], true
+		}
+	}
+	return nil, false
+}
+
+// isDeferredUnlockOf matches `defer varName.Unlock()`.
+func (c *Checker) isDeferredUnlockOf(stmt ast.Stmt, varName string) bool {
+	deferStmt, ok := stmt.(*ast.DeferStmt)
+	if !ok {
+		return false
+	}
+	_, deferVar, deferMethod, ok := c.mutexCallStatement(&ast.ExprStmt{X: deferStmt.Call})
+	return ok && deferVar == varName && deferMethod == "Unlock"
+}
+
+// isReadOnly reports whether stmt neither writes state that outlives the
+// function nor makes a call, send or address-of that could. Assigning a
+// variable declared in the function body is a read of the guarded state.
+func (c *Checker) isReadOnly(stmt ast.Stmt) bool {
+	readOnly := true
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		if !readOnly {
+			return false
+		}
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			if node.Tok != token.DEFINE {
+				readOnly = c.allLocalTargets(node.Lhs...)
+			}
+		case *ast.RangeStmt:
+			if node.Tok == token.ASSIGN {
+				readOnly = c.allLocalTargets(node.Key, node.Value)
+			}
+		case *ast.IncDecStmt:
+			readOnly = c.allLocalTargets(node.X)
+		case *ast.SendStmt, *ast.GoStmt, *ast.DeferStmt:
+			readOnly = false
+		case *ast.UnaryExpr:
+			readOnly = node.Op != token.AND
+		case *ast.CallExpr:
+			readOnly = c.isReadOnlyCall(node)
+		}
+		return readOnly
+	})
+	return readOnly
+}
+
+// allLocalTargets reports whether every non-nil target is the blank
+// identifier or a variable declared inside the function body.
+func (c *Checker) allLocalTargets(targets ...ast.Expr) bool {
+	body := c.function.Body
+	for _, target := range targets {
+		if target == nil {
+			continue
+		}
+		ident, ok := common.UnwrapParenExpr(target).(*ast.Ident)
+		if !ok {
+			return false
+		}
+		if ident.Name == "_" {
+			continue
+		}
+		obj := c.typesInfo.ObjectOf(ident)
+		if obj == nil || obj.Pos() < body.Pos() || obj.Pos() >= body.End() {
+			return false
+		}
+	}
+	return true
+}
+
+// readOnlyBuiltins are the builtins that cannot modify their arguments.
+var readOnlyBuiltins = map[string]bool{
+	"len": true, "cap": true, "min": true, "max": true,
+	"real": true, "imag": true, "complex": true,
+}
+
+// isReadOnlyCall reports whether call is a conversion, a read-only builtin
+// or an Unlock of a tracked mutex, such as one before an early return.
+func (c *Checker) isReadOnlyCall(call *ast.CallExpr) bool {
+	if tv, ok := c.typesInfo.Types[call.Fun]; ok && tv.IsType() {
+		return true
+	}
+	switch fun := common.UnwrapParenExpr(call.Fun).(type) {
+	case *ast.Ident:
+		_, isBuiltin := c.typesInfo.ObjectOf(fun).(*types.Builtin)
+		return isBuiltin && readOnlyBuiltins[fun.Name]
+	case *ast.SelectorExpr:
+		_, _, method, ok := c.mutexCallStatement(&ast.ExprStmt{X: call})
+		return ok && isUnlockMethod(method)
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package mutex
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+)
+
+// reportReassignWhileLocked flags an assignment to a tracked mutex variable
+// while it is locked or rlocked. The lock held belongs to the old value, so
+// it can no longer be released through the variable, and later Unlock calls
+// act on the new, unlocked mutex. The state is reset to match: the leak is
+// reported here rather than again at function exit.
+func (c *Checker) reportReassignWhileLocked(stmt *ast.AssignStmt, stats map[string]*Stats) {
+	for _, lhs := range stmt.Lhs {
+		if ident, ok := lhs.(*ast.Ident); ok && stmt.Tok == token.DEFINE &&
+			(c.typesInfo == nil || c.typesInfo.Defs[ident] != nil) {
+			continue // a new variable, not a reassignment
+		}
+		varName := common.GetVarName(lhs)
+		st := stats[varName]
+		if st == nil || st.lock == 0 && st.rlock == 0 {
+			continue
+		}
+		if !c.rawBodyEffects {
+			mutexType := "mutex"
+			if c.rwMutexNames[varName] {
+				mutexType = "rwmutex"
+			}
+			related := heldAt(st.lockPos, mutexType, varName, "locked")
+			related = append(related, heldAt(st.rlockPos, mutexType, varName, "rlocked")...)
+			c.errorCollector.AddError(lhs.Pos(), category.ReassignWhileLocked,
+				mutexType+" '"+varName+"' reassigned while locked; original lock leaked", related...)
+		}
+		st.lock, st.rlock = 0, 0
+		st.lockPos, st.rlockPos = nil, nil
+	}
+}
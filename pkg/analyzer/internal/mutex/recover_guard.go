@@ -14,6 +14,7 @@ import (
 // without a full Checker.
 type recoverGuardInspector struct {
 	commentFilter *commentfilter.CommentFilter
+	aliases       common.VarAliases
 }
 
 func newRecoverGuardInspector(commentFilter *commentfilter.CommentFilter) *recoverGuardInspector {
@@ -48,7 +49,7 @@ func (g *recoverGuardInspector) unlocksOnlyInRecoverGuard(block *ast.BlockStmt,
 				return true
 			}
 			sel, ok := call.Fun.(*ast.SelectorExpr)
-			if !ok || sel.Sel.Name != methodName || common.GetVarName(sel.X) != mutexName {
+			if !ok || sel.Sel.Name != methodName || g.aliases.VarName(sel.X) != mutexName {
 				return true
 			}
 			foundUnlock = true
@@ -197,10 +198,16 @@ func (g *recoverGuardInspector) containsRLock(block *ast.BlockStmt, mutexName st
 }
 
 // containsMutexMethodCall checks if a block contains a call to a specific
-// method on the given mutex variable.
+// method on the given mutex variable. A nested function literal only counts
+// when it runs: called in place, or assigned to a name the block calls. A
+// literal that is stored or passed on may never be invoked.
 func (g *recoverGuardInspector) containsMutexMethodCall(block *ast.BlockStmt, mutexName, method string) bool {
+	invoked := invokedFuncLits(block)
 	var found bool
 	ast.Inspect(block, func(n ast.Node) bool {
+		if fnlit, ok := n.(*ast.FuncLit); ok {
+			return invoked[fnlit]
+		}
 		call, ok := n.(*ast.CallExpr)
 		if !ok || g.commentFilter.ShouldSkipCall(call) {
 			return true
@@ -212,7 +219,7 @@ func (g *recoverGuardInspector) containsMutexMethodCall(block *ast.BlockStmt, mu
 		}
 
 		if sel.Sel.Name == method &&
-			common.GetVarName(sel.X) == mutexName {
+			g.aliases.VarName(sel.X) == mutexName {
 			found = true
 		}
 
@@ -220,3 +227,47 @@ func (g *recoverGuardInspector) containsMutexMethodCall(block *ast.BlockStmt, mu
 	})
 	return found
 }
+
+// invokedFuncLits returns the function literals in block that are called:
+// `func() { ... }()`, or `f := func() { ... }` followed by a call of f.
+func invokedFuncLits(block *ast.BlockStmt) map[*ast.FuncLit]bool {
+	invoked := make(map[*ast.FuncLit]bool)
+	assigned := make(map[string][]*ast.FuncLit)
+	called := make(map[string]bool)
+	ast.Inspect(block, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.CallExpr:
+			switch fun := common.UnwrapParenExpr(node.Fun).(type) {
+			case *ast.FuncLit:
+				invoked[fun] = true
+			case *ast.Ident:
+				called[fun.Name] = true
+			}
+		case *ast.AssignStmt:
+			if len(node.Lhs) != len(node.Rhs) {
+				break
+			}
+			for i, rhs := range node.Rhs {
+				ident, isIdent := node.Lhs[i].(*ast.Ident)
+				if fnlit, ok := common.UnwrapParenExpr(rhs).(*ast.FuncLit); ok && isIdent {
+					assigned[ident.Name] = append(assigned[ident.Name], fnlit)
+				}
+			}
+		case *ast.ValueSpec:
+			for i, value := range node.Values {
+				if fnlit, ok := common.UnwrapParenExpr(value).(*ast.FuncLit); ok && i < len(node.Names) {
+					assigned[node.Names[i].Name] = append(assigned[node.Names[i].Name], fnlit)
+				}
+			}
+		}
+		return true
+	})
+	for name, fnlits := range assigned {
+		if called[name] {
+			for _, fnlit := range fnlits {
+				invoked[fnlit] = true
+			}
+		}
+	}
+	return invoked
+}
@@ -79,3 +79,32 @@ func f() {
 		t.Error("expected containsRLock(body, \"rw\") == false")
 	}
 }
+
+// TestRecoverGuardInspector_NestedClosures verifies that an Unlock inside a
+// nested function literal only counts when the literal is called.
+func TestRecoverGuardInspector_NestedClosures(t *testing.T) {
+	src := `package p
+func invoked() {
+	func() { mu.Unlock() }()
+}
+func called() {
+	release := func() { mu.Unlock() }
+	release()
+}
+func stored() {
+	hooks = append(hooks, func() { mu.Unlock() })
+}
+`
+	file, cf := parseFile(t, src)
+	g := newRecoverGuardInspector(cf)
+
+	if !g.containsUnlock(funcBody(t, file, "invoked"), "mu") {
+		t.Error("expected containsUnlock == true for an immediately invoked closure")
+	}
+	if !g.containsUnlock(funcBody(t, file, "called"), "mu") {
+		t.Error("expected containsUnlock == true for a closure called through a local")
+	}
+	if g.containsUnlock(funcBody(t, file, "stored"), "mu") {
+		t.Error("expected containsUnlock == false for a closure that is only stored")
+	}
+}
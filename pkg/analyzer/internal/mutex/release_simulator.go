@@ -1,10 +1,6 @@
 package mutex
 
-import (
-	"go/ast"
-
-	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
-)
+import "go/ast"
 
 // pathReleaseSimulator checks simple paths for one matching unlock before exit.
 type pathReleaseSimulator struct {
@@ -142,7 +138,7 @@ func (s *pathReleaseSimulator) isMatchingRelease(expr ast.Expr) bool {
 	if !ok {
 		return false
 	}
-	if common.GetVarName(sel.X) != s.varName {
+	if s.analyzer.aliases.VarName(sel.X) != s.varName {
 		return false
 	}
 	return sel.Sel.Name == s.method
@@ -4,6 +4,7 @@ import (
 	"go/token"
 
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/report"
 )
 
 // trailingPositions returns the last count positions from the slice.
@@ -17,24 +18,68 @@ func trailingPositions(positions []token.Pos, count int) []token.Pos {
 	return positions[len(positions)-count:]
 }
 
+// siblingLocks records, per mutex, whether another arm of the same switch or
+// select leaves it write-locked or read-locked relative to the entry state.
+type siblingLocks struct {
+	lock, rlock bool
+}
+
 // reportUnmatchedLocksInBranch reports unmatched locks in conditional branches
 func (c *Checker) reportUnmatchedLocksInBranch(initial, final map[string]*Stats, branchType string) {
+	c.reportUnmatchedLocksInClause(initial, final, nil, branchType)
+}
+
+// reportUnmatchedLocksInClause reports unmatched locks in one arm of a switch
+// or select. Only one arm runs, so an unlock of a mutex that a sibling arm
+// leaves locked is the other half of a pair split across arms: the Lock is
+// already flagged in its own arm, and the Unlock stays a branch-local error
+// that is not reported again as orphaned.
+func (c *Checker) reportUnmatchedLocksInClause(initial, final map[string]*Stats, siblings map[string]siblingLocks, branchType string) {
 	if c.rawBodyEffects {
 		return
 	}
 
 	for mutexName := range c.mutexNames {
-		c.reportBranchDelta(mutexName, initial[mutexName], final[mutexName], false, branchType)
+		c.reportBranchDelta(mutexName, initial[mutexName], final[mutexName], false, branchType, siblings[mutexName])
 	}
 
 	for rwMutexName := range c.rwMutexNames {
-		c.reportBranchDelta(rwMutexName, initial[rwMutexName], final[rwMutexName], true, branchType)
+		c.reportBranchDelta(rwMutexName, initial[rwMutexName], final[rwMutexName], true, branchType, siblings[rwMutexName])
 	}
 }
 
+// siblingLocksFor collects the locks that arms other than self leave held
+// compared to the entry state.
+func siblingLocksFor(entry map[string]*Stats, arms []map[string]*Stats, self int) map[string]siblingLocks {
+	siblings := make(map[string]siblingLocks)
+	for i, arm := range arms {
+		if i == self {
+			continue
+		}
+		for name, final := range arm {
+			if final == nil {
+				continue
+			}
+			initial := entry[name]
+			if initial == nil {
+				initial = &Stats{}
+			}
+			held := siblings[name]
+			if remainingLockCount(final.lock, final.deferUnlock) > remainingLockCount(initial.lock, initial.deferUnlock) {
+				held.lock = true
+			}
+			if remainingLockCount(final.rlock, final.deferRUnlock) > remainingLockCount(initial.rlock, initial.deferRUnlock) {
+				held.rlock = true
+			}
+			siblings[name] = held
+		}
+	}
+	return siblings
+}
+
 // reportBranchDelta reports only the extra locks that remain held compared to
 // the branch entry state.
-func (c *Checker) reportBranchDelta(mutexName string, initial, final *Stats, isRWMutex bool, branchType string) {
+func (c *Checker) reportBranchDelta(mutexName string, initial, final *Stats, isRWMutex bool, branchType string, siblings siblingLocks) {
 	if final == nil {
 		return
 	}
@@ -47,17 +92,20 @@ func (c *Checker) reportBranchDelta(mutexName string, initial, final *Stats, isR
 		mutexType = "rwmutex"
 	}
 
-	lockMessage := mutexType + " '" + mutexName + "' is locked but not unlocked in " + branchType
+	lockMessage := report.FormatMessage("mutex.locked_not_unlocked_in", mutexType, mutexName, branchType)
 	if delta := remainingLockCount(final.lock, final.deferUnlock) - remainingLockCount(initial.lock, initial.deferUnlock); delta > 0 {
 		for _, pos := range trailingPositions(final.lockPos, delta) {
-			c.errorCollector.AddError(pos, category.LockWithoutUnlock, lockMessage)
+			if !c.conditionalLocks[pos] {
+				c.errorCollector.AddError(pos, category.LockWithoutUnlock, lockMessage)
+			}
 		}
 	}
 
-	suppressBorrowedUnlock := c.unlockDiagnosticSuppressed(mutexName, WriteLockPattern.LockMethods) ||
+	suppressBorrowedUnlock := siblings.lock ||
+		c.unlockDiagnosticSuppressed(mutexName, WriteLockPattern.LockMethods) ||
 		c.terminatingTailUnlockSuppressed(mutexName) ||
 		c.lockAcquiredInCallbackArgument(mutexName, WriteLockPattern.LockMethods)
-	unlockMessage := mutexType + " '" + mutexName + "' is unlocked but not locked"
+	unlockMessage := report.FormatMessage("mutex.unlocked_not_locked", mutexType, mutexName)
 	if delta := final.borrowedLock - initial.borrowedLock; delta > 0 && !suppressBorrowedUnlock {
 		for _, pos := range trailingPositions(final.borrowedUnlockPos, delta) {
 			c.errorCollector.AddError(pos, category.UnlockWithoutLock, unlockMessage)
@@ -65,17 +113,20 @@ func (c *Checker) reportBranchDelta(mutexName string, initial, final *Stats, isR
 	}
 
 	if isRWMutex {
-		rlockMessage := "rwmutex '" + mutexName + "' is rlocked but not runlocked in " + branchType
+		rlockMessage := report.FormatMessage("rwmutex.rlocked_not_runlocked_in", mutexName, branchType)
 		if delta := remainingLockCount(final.rlock, final.deferRUnlock) - remainingLockCount(initial.rlock, initial.deferRUnlock); delta > 0 {
 			for _, pos := range trailingPositions(final.rlockPos, delta) {
-				c.errorCollector.AddError(pos, category.LockWithoutUnlock, rlockMessage)
+				if _, inLoop := c.loopRLocks[pos]; !c.conditionalLocks[pos] && !inLoop {
+					c.errorCollector.AddError(pos, category.LockWithoutUnlock, rlockMessage)
+				}
 			}
 		}
 
-		suppressBorrowedRUnlock := c.unlockDiagnosticSuppressed(mutexName, ReadLockPattern.LockMethods) ||
+		suppressBorrowedRUnlock := siblings.rlock ||
+			c.unlockDiagnosticSuppressed(mutexName, ReadLockPattern.LockMethods) ||
 			c.terminatingTailUnlockSuppressed(mutexName) ||
 			c.lockAcquiredInCallbackArgument(mutexName, ReadLockPattern.LockMethods)
-		runlockMessage := "rwmutex '" + mutexName + "' is runlocked but not rlocked"
+		runlockMessage := report.FormatMessage("rwmutex.runlocked_not_rlocked", mutexName)
 		if delta := final.borrowedRLock - initial.borrowedRLock; delta > 0 && !suppressBorrowedRUnlock {
 			for _, pos := range trailingPositions(final.borrowedRUnlockPos, delta) {
 				c.errorCollector.AddError(pos, category.UnlockWithoutLock, runlockMessage)
@@ -114,21 +165,18 @@ func (c *Checker) reportUnmatchedMutexLocksWithContext(mutexName string, stats *
 	var lockMessage, rlockMessage string
 	if branchType == "" {
 		// For function-level reporting (no context)
-		lockMessage = mutexType + " '" + mutexName + "' is locked but not unlocked"
-		rlockMessage = "rwmutex '" + mutexName + "' is rlocked but not runlocked"
+		lockMessage = report.FormatMessage("mutex.locked_not_unlocked", mutexType, mutexName)
+		rlockMessage = report.FormatMessage("rwmutex.rlocked_not_runlocked", mutexName)
 	} else {
 		// For branch-level reporting (with context)
-		lockMessage = mutexType + " '" + mutexName + "' is locked but not unlocked in " + branchType
-		rlockMessage = "rwmutex '" + mutexName + "' is rlocked but not runlocked in " + branchType
+		lockMessage = report.FormatMessage("mutex.locked_not_unlocked_in", mutexType, mutexName, branchType)
+		rlockMessage = report.FormatMessage("rwmutex.rlocked_not_runlocked_in", mutexName, branchType)
 	}
 
 	// Suppression checks scan the whole package, so only run them when there is
 	// an unmatched lock to report; the balanced common case skips them.
 	if lockPositions := trailingPositions(stats.lockPos, remainingLockCount(stats.lock, stats.deferUnlock)); len(lockPositions) > 0 {
-		suppress := branchType == "" &&
-			(c.lifecycle.returnsHandleFor(mutexName, WriteLockPattern.UnlockMethods) ||
-				c.lifecycle.returnsFuncFor(mutexName, WriteLockPattern.UnlockMethods) ||
-				c.lifecycle.returnsClosureReleasingLock(mutexName, WriteLockPattern.UnlockMethods))
+		suppress := branchType == "" && c.heldLockReleasedElsewhere(mutexName, WriteLockPattern.UnlockMethods)
 		if !suppress {
 			for _, pos := range lockPositions {
 				c.errorCollector.AddError(pos, category.LockWithoutUnlock, lockMessage)
@@ -137,24 +185,22 @@ func (c *Checker) reportUnmatchedMutexLocksWithContext(mutexName string, stats *
 	}
 
 	if len(stats.borrowedUnlockPos) > 0 {
-		suppress := branchType == "" &&
-			(c.unlockDiagnosticSuppressed(mutexName, WriteLockPattern.LockMethods) ||
-				c.lockAcquiredInCallbackArgument(mutexName, WriteLockPattern.LockMethods))
+		suppress := branchType == "" && c.borrowedUnlockAcquiredElsewhere(mutexName, WriteLockPattern.LockMethods)
 		if !suppress {
 			for _, pos := range stats.borrowedUnlockPos {
-				c.errorCollector.AddError(pos, category.UnlockWithoutLock, mutexType+" '"+mutexName+"' is unlocked but not locked")
+				c.errorCollector.AddError(pos, category.UnlockWithoutLock, report.FormatMessage("mutex.unlocked_not_locked", mutexType, mutexName))
 			}
 		}
 	}
 
 	if isRWMutex {
 		if rlockPositions := trailingPositions(stats.rlockPos, remainingLockCount(stats.rlock, stats.deferRUnlock)); len(rlockPositions) > 0 {
-			suppress := branchType == "" &&
-				(c.lifecycle.returnsHandleFor(mutexName, ReadLockPattern.UnlockMethods) ||
-					c.lifecycle.returnsFuncFor(mutexName, ReadLockPattern.UnlockMethods) ||
-					c.lifecycle.returnsClosureReleasingLock(mutexName, ReadLockPattern.UnlockMethods))
+			suppress := branchType == "" && c.heldLockReleasedElsewhere(mutexName, ReadLockPattern.UnlockMethods)
 			if !suppress {
 				for _, pos := range rlockPositions {
+					if _, inLoop := c.loopRLocks[pos]; inLoop {
+						continue
+					}
 					c.errorCollector.AddError(pos, category.LockWithoutUnlock, rlockMessage)
 				}
 			}
@@ -162,18 +208,33 @@ func (c *Checker) reportUnmatchedMutexLocksWithContext(mutexName string, stats *
 
 		if len(stats.borrowedRUnlockPos) > 0 {
 			suppress := branchType == "" &&
-				(c.unlockDiagnosticSuppressed(mutexName, ReadLockPattern.LockMethods) ||
-					c.lockAcquiredInCallbackArgument(mutexName, ReadLockPattern.LockMethods) ||
+				(c.borrowedUnlockAcquiredElsewhere(mutexName, ReadLockPattern.LockMethods) ||
 					c.isReadLockUpgrade(mutexName))
 			if !suppress {
 				for _, pos := range stats.borrowedRUnlockPos {
-					c.errorCollector.AddError(pos, category.UnlockWithoutLock, "rwmutex '"+mutexName+"' is runlocked but not rlocked")
+					c.errorCollector.AddError(pos, category.UnlockWithoutLock, report.FormatMessage("rwmutex.runlocked_not_rlocked", mutexName))
 				}
 			}
 		}
 	}
 }
 
+// heldLockReleasedElsewhere reports whether a lock still held at function exit
+// is handed to the caller, through a returned handle, func or closure that
+// releases it.
+func (c *Checker) heldLockReleasedElsewhere(mutexName string, unlockMethods []string) bool {
+	return c.lifecycle.returnsHandleFor(mutexName, unlockMethods) ||
+		c.lifecycle.returnsFuncFor(mutexName, unlockMethods) ||
+		c.lifecycle.returnsClosureReleasingLock(mutexName, unlockMethods)
+}
+
+// borrowedUnlockAcquiredElsewhere reports whether an unlock with no matching
+// lock in the function releases a lock taken by a caller or callback.
+func (c *Checker) borrowedUnlockAcquiredElsewhere(mutexName string, lockMethods []string) bool {
+	return c.unlockDiagnosticSuppressed(mutexName, lockMethods) ||
+		c.lockAcquiredInCallbackArgument(mutexName, lockMethods)
+}
+
 // reportUnmatchedMutexLocks reports unmatched locks for a specific mutex
 func (c *Checker) reportUnmatchedMutexLocks(mutexName string, stats *Stats, isRWMutex bool) {
 	// Call the context-aware version with empty context for function-level reporting
@@ -187,22 +248,24 @@ func (c *Checker) reportUnmatchedLocks(stats map[string]*Stats) {
 	}
 
 	for mutexName := range c.mutexNames {
-		if c.deferErrors.badDeferUnlock[mutexName] {
+		if c.deferErrors.badDeferUnlock[mutexName] || c.heldOnReturn[mutexName] {
 			continue
 		}
 		c.reportUnmatchedMutexLocks(mutexName, stats[mutexName], false)
 	}
 
 	for rwMutexName := range c.rwMutexNames {
-		if c.deferErrors.badDeferUnlock[rwMutexName] || c.deferErrors.badDeferRUnlock[rwMutexName] {
+		if c.deferErrors.badDeferUnlock[rwMutexName] || c.deferErrors.badDeferRUnlock[rwMutexName] || c.heldOnReturn[rwMutexName] {
 			continue
 		}
 		c.reportUnmatchedMutexLocks(rwMutexName, stats[rwMutexName], true)
 	}
+	c.reportSwappedUnlocks(stats)
 
 	// Report goroutine-parent deadlocks only when the parent exits while still
 	// holding the lock, so the goroutine can never acquire it.
 	cg := newCrossGoroutineDetector(c.mutexNames, c.rwMutexNames, c.commentFilter, c.typesInfo)
+	cg.aliases = c.aliases
 	for _, conflict := range c.goroutineLockConflicts {
 		st := stats[conflict.varName]
 		if st == nil {
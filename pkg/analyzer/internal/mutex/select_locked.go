@@ -0,0 +1,43 @@
+package mutex
+
+import (
+	"go/ast"
+	"maps"
+	"slices"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+)
+
+// reportSelectWhileLocked flags a select without a default case entered while
+// a mutex is still held. Such a select blocks until one of its channels is
+// ready, and if the goroutine that would make it ready needs the same mutex
+// first, neither side can proceed. Whether that goroutine exists is not known
+// here, so the check is opt-in. As in reportWaitWhileLocked, a deferred
+// unlock does not release the lock before the select, so the raw lock count
+// is what matters.
+func (c *Checker) reportSelectWhileLocked(stmt *ast.SelectStmt, stats map[string]*Stats) {
+	if c.rawBodyEffects || stmt == nil || slices.ContainsFunc(selectClauses(stmt.Body), isDefaultClause) {
+		return
+	}
+	for _, name := range slices.Sorted(maps.Keys(stats)) {
+		st := stats[name]
+		if st == nil {
+			continue
+		}
+		switch {
+		case c.mutexNames[name] && st.lock > 0:
+			c.errorCollector.AddError(stmt.Pos(), category.SelectWhileLocked, "mutex '"+name+"' held while blocking in select without default",
+				heldAt(st.lockPos, "mutex", name, "locked")...)
+		case c.rwMutexNames[name] && st.lock > 0:
+			c.errorCollector.AddError(stmt.Pos(), category.SelectWhileLocked, "rwmutex '"+name+"' held while blocking in select without default",
+				heldAt(st.lockPos, "rwmutex", name, "locked")...)
+		case c.rwMutexNames[name] && st.rlock > 0:
+			c.errorCollector.AddError(stmt.Pos(), category.SelectWhileLocked, "rwmutex '"+name+"' rlocked while blocking in select without default",
+				heldAt(st.rlockPos, "rwmutex", name, "rlocked")...)
+		}
+	}
+}
+
+func isDefaultClause(clause branchClause) bool {
+	return clause.isDefault
+}
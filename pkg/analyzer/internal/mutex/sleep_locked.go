@@ -0,0 +1,54 @@
+package mutex
+
+import (
+	"go/ast"
+	"go/types"
+	"maps"
+	"slices"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+)
+
+// reportSleepWhileLocked flags a `time.Sleep(d)` statement reached while a
+// mutex is still held: every goroutine that needs the mutex waits out the
+// sleep too. Backoff loops occasionally do this on purpose, so the check is
+// opt-in. As in reportWaitWhileLocked, a deferred unlock does not release the
+// lock before the sleep, so the raw lock count is what matters.
+func (c *Checker) reportSleepWhileLocked(stmt *ast.ExprStmt, stats map[string]*Stats) {
+	if c.rawBodyEffects || stmt == nil {
+		return
+	}
+	call, ok := common.UnwrapParenExpr(stmt.X).(*ast.CallExpr)
+	if !ok || !c.isTimeSleep(call) || c.commentFilter.ShouldSkipCall(call) {
+		return
+	}
+	for _, name := range slices.Sorted(maps.Keys(stats)) {
+		st := stats[name]
+		if st == nil {
+			continue
+		}
+		switch {
+		case c.mutexNames[name] && st.lock > 0:
+			c.errorCollector.AddError(call.Pos(), category.SleepWhileLocked, "mutex '"+name+"' held across time.Sleep",
+				heldAt(st.lockPos, "mutex", name, "locked")...)
+		case c.rwMutexNames[name] && st.lock > 0:
+			c.errorCollector.AddError(call.Pos(), category.SleepWhileLocked, "rwmutex '"+name+"' held across time.Sleep",
+				heldAt(st.lockPos, "rwmutex", name, "locked")...)
+		case c.rwMutexNames[name] && st.rlock > 0:
+			c.errorCollector.AddError(call.Pos(), category.SleepWhileLocked, "rwmutex '"+name+"' rlocked across time.Sleep",
+				heldAt(st.rlockPos, "rwmutex", name, "rlocked")...)
+		}
+	}
+}
+
+// isTimeSleep reports whether call is time.Sleep, resolved through the type
+// info so an aliased import still matches.
+func (c *Checker) isTimeSleep(call *ast.CallExpr) bool {
+	sel, ok := common.UnwrapParenExpr(call.Fun).(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Sleep" {
+		return false
+	}
+	fn, ok := c.typesInfo.ObjectOf(sel.Sel).(*types.Func)
+	return ok && fn.Pkg() != nil && fn.Pkg().Path() == "time"
+}
@@ -0,0 +1,38 @@
+package mutex
+
+import "github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+
+// reportLocksSplitAcrossGoroutines flags each Lock/RLock the parent still
+// holds that a goroutine it launches releases. The handoff is legal for a
+// sync.Mutex and already balances the parent's stats, so the check is opt-in
+// and only points at the Lock that the goroutine ends up releasing. It must
+// run before applyReleases consumes the matching lock positions.
+func (c *Checker) reportLocksSplitAcrossGoroutines(stats map[string]*Stats, releases crossGoroutineReleases) {
+	if c.rawBodyEffects {
+		return
+	}
+	for varName, positions := range releases.unlocks {
+		st := stats[varName]
+		if st == nil {
+			continue
+		}
+		mutexType := "mutex"
+		if c.rwMutexNames[varName] {
+			mutexType = "rwmutex"
+		}
+		for i := 0; i < len(positions) && i < len(st.lockPos); i++ {
+			c.errorCollector.AddError(st.lockPos[i], category.LockSplitAcrossGoroutines,
+				mutexType+" '"+varName+"' lock/unlock split across goroutines")
+		}
+	}
+	for varName, positions := range releases.runlocks {
+		st := stats[varName]
+		if st == nil {
+			continue
+		}
+		for i := 0; i < len(positions) && i < len(st.rlockPos); i++ {
+			c.errorCollector.AddError(st.rlockPos[i], category.LockSplitAcrossGoroutines,
+				"rwmutex '"+varName+"' rlock/runlock split across goroutines")
+		}
+	}
+}
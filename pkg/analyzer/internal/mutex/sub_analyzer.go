@@ -19,7 +19,7 @@ import (
 // umbrella).
 var SubAnalyzer = &analysis.Analyzer{
 	Name:       "goconcurrencylint_mutex",
-	Doc:        "Detects misuse of sync.Mutex and sync.RWMutex.",
+	Doc:        "Detects misuse of sync.Mutex and sync.RWMutex, including pointer mutex fields that are never assigned.",
 	Run:        run,
 	Requires:   []*analysis.Analyzer{inspect.Analyzer, primitives.Analyzer, filesetup.Analyzer},
 	ResultType: reflect.TypeFor[[]analysis.Diagnostic](),
@@ -31,7 +31,7 @@ func run(pass *analysis.Pass) (any, error) {
 	// function. driver.Run visits functions sequentially, so the lazy init needs
 	// no synchronization.
 	var scope *packageScope
-	return driver.Run(pass, driver.Config[*Checker]{
+	result, err := driver.Run(pass, driver.Config[*Checker]{
 		Guard: primitives.HasMutexes,
 		NewChecker: func(fr *primitives.FunctionResult, ec report.Reporter, cf *commentfilter.CommentFilter, pass *analysis.Pass) *Checker {
 			if scope == nil {
@@ -40,4 +40,18 @@ func run(pass *analysis.Pass) (any, error) {
 			return NewChecker(fr, ec, cf, pass.TypesInfo, scope)
 		},
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Whether a pointer mutex field is ever assigned is a package-wide
+	// question, so it is answered in one pass after the per-function checks,
+	// as are lock order cycles spanning two functions.
+	lockOrder := func(pkg *driver.Package) {
+		if scope != nil {
+			scope.lockOrder.report(pkg.Reporter)
+		}
+	}
+	diags := result.([]analysis.Diagnostic)
+	return append(diags, driver.RunPackage(pass, lockOrder, CheckNilPointerFields)...), nil
 }
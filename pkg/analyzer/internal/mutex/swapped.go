@@ -0,0 +1,140 @@
+package mutex
+
+import (
+	"go/ast"
+	"go/token"
+	"sort"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+)
+
+// reportSwappedUnlocks adds a hint when a lock left held on one mutex and an
+// unlock of another mutex that was never locked sit in the same block, lock
+// first:
+//
+//	mu1.Lock()
+//	...
+//	mu2.Unlock() // meant mu1.Unlock()
+//
+// Both halves are already reported on their own; the hint ties them together
+// as a likely copy-paste slip. It only fires when both primary diagnostics
+// were reported, so pairs excused by a lifecycle or wrapper heuristic stay
+// quiet.
+func (c *Checker) reportSwappedUnlocks(stats map[string]*Stats) {
+	var held, orphaned []swapCandidate
+	for _, name := range sortedMutexNames(c.mutexNames, c.rwMutexNames) {
+		st := stats[name]
+		if st == nil || c.deferErrors.badDeferUnlock[name] {
+			continue
+		}
+		rw := c.rwMutexNames[name]
+		if positions := trailingPositions(st.lockPos, remainingLockCount(st.lock, st.deferUnlock)); len(positions) > 0 &&
+			!c.heldLockReleasedElsewhere(name, WriteLockPattern.UnlockMethods) {
+			for _, pos := range positions {
+				held = append(held, swapCandidate{name: name, pos: pos, rw: rw})
+			}
+		}
+		if len(st.borrowedUnlockPos) > 0 && !c.borrowedUnlockAcquiredElsewhere(name, WriteLockPattern.LockMethods) {
+			for _, pos := range st.borrowedUnlockPos {
+				orphaned = append(orphaned, swapCandidate{name: name, pos: pos, rw: rw})
+			}
+		}
+		if !rw || c.deferErrors.badDeferRUnlock[name] {
+			continue
+		}
+		if positions := trailingPositions(st.rlockPos, remainingLockCount(st.rlock, st.deferRUnlock)); len(positions) > 0 &&
+			!c.heldLockReleasedElsewhere(name, ReadLockPattern.UnlockMethods) {
+			for _, pos := range positions {
+				held = append(held, swapCandidate{name: name, pos: pos, rw: true, read: true})
+			}
+		}
+		if len(st.borrowedRUnlockPos) > 0 &&
+			!c.borrowedUnlockAcquiredElsewhere(name, ReadLockPattern.LockMethods) && !c.isReadLockUpgrade(name) {
+			for _, pos := range st.borrowedRUnlockPos {
+				orphaned = append(orphaned, swapCandidate{name: name, pos: pos, rw: true, read: true})
+			}
+		}
+	}
+	if len(held) == 0 || len(orphaned) == 0 {
+		return
+	}
+
+	blocks := c.statementBlocks()
+	for _, unlock := range orphaned {
+		block, ok := blocks[unlock.pos]
+		if !ok {
+			continue
+		}
+		// Pair with the nearest preceding lock of the same kind in the block.
+		var match *swapCandidate
+		for i := range held {
+			lock := &held[i]
+			if lock.name == unlock.name || lock.read != unlock.read || lock.pos >= unlock.pos || blocks[lock.pos] != block {
+				continue
+			}
+			if match == nil || lock.pos > match.pos {
+				match = lock
+			}
+		}
+		if match == nil {
+			continue
+		}
+		unlockMethod, lockKind, verb := "Unlock", "mutex", "locked"
+		if match.rw {
+			lockKind = "rwmutex"
+		}
+		if unlock.read {
+			unlockMethod, verb = "RUnlock", "rlocked"
+		}
+		c.errorCollector.AddError(unlock.pos, category.UnlockWithoutLock,
+			"possible swapped "+unlockMethod+": '"+unlock.name+"' unlocked where '"+match.name+"' is held",
+			heldAt([]token.Pos{match.pos}, lockKind, match.name, verb)...)
+	}
+}
+
+// swapCandidate is one unmatched lock or unlock considered by
+// reportSwappedUnlocks.
+type swapCandidate struct {
+	name string
+	pos  token.Pos
+	rw   bool
+	read bool
+}
+
+// statementBlocks maps the position of every expression-statement call in the
+// function to the block that directly holds it. Closure bodies are left out:
+// they are analyzed as their own functions.
+func (c *Checker) statementBlocks() map[token.Pos]*ast.BlockStmt {
+	blocks := make(map[token.Pos]*ast.BlockStmt)
+	if c.function == nil || c.function.Body == nil {
+		return blocks
+	}
+	ast.Inspect(c.function.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.BlockStmt:
+			for _, stmt := range node.List {
+				if expr, ok := stmt.(*ast.ExprStmt); ok {
+					blocks[expr.X.Pos()] = node
+				}
+			}
+		}
+		return true
+	})
+	return blocks
+}
+
+// sortedMutexNames returns the tracked mutex and rwmutex names in a stable
+// order, so hints are paired deterministically.
+func sortedMutexNames(mutexNames, rwMutexNames map[string]bool) []string {
+	names := make([]string, 0, len(mutexNames)+len(rwMutexNames))
+	for name := range mutexNames {
+		names = append(names, name)
+	}
+	for name := range rwMutexNames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
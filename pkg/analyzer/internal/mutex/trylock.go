@@ -35,6 +35,7 @@ type tryLockTracker struct {
 
 	mutexNames    map[string]bool
 	rwMutexNames  map[string]bool
+	aliases       common.VarAliases
 	commentFilter *commentfilter.CommentFilter
 	reporter      report.Reporter
 }
@@ -92,7 +93,7 @@ func (t *tryLockTracker) resultFromCall(call *ast.CallExpr) *tryLockResult {
 		return nil
 	}
 
-	varName := common.GetVarName(sel.X)
+	varName := t.aliases.VarName(sel.X)
 	switch sel.Sel.Name {
 	case "TryLock":
 		if t.mutexNames[varName] {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/commentfilter"
+	"golang.org/x/tools/go/analysis"
 )
 
 // These tests exercise tryLockTracker in isolation. That is only possible
@@ -27,7 +28,7 @@ type fakeReport struct {
 	msg string
 }
 
-func (f *fakeReporter) AddError(pos token.Pos, cat category.Category, message string) {
+func (f *fakeReporter) AddError(pos token.Pos, cat category.Category, message string, _ ...analysis.RelatedInformation) {
 	f.calls = append(f.calls, fakeReport{pos: pos, cat: cat, msg: message})
 }
 
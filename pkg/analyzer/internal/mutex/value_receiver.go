@@ -0,0 +1,109 @@
+package mutex
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+)
+
+// reportLockOnValueReceiver flags Lock and RLock calls in a method with a
+// value receiver on a mutex held by value inside that receiver (GCL1017).
+// The receiver is a copy made for the call, so the lock excludes no other
+// caller. A mutex reached through a pointer field is shared with the
+// original and is left alone.
+func (c *Checker) reportLockOnValueReceiver(fn *ast.FuncDecl) {
+	recv := c.valueReceiver(fn)
+	if recv == nil {
+		return
+	}
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || (sel.Sel.Name != "Lock" && sel.Sel.Name != "RLock") || c.commentFilter.ShouldSkipCall(call) {
+			return true
+		}
+		if !c.selectsThroughValues(sel, recv) {
+			return true
+		}
+		kind := "mutex"
+		if common.IsRWMutex(c.lockedType(sel), c.syncPackages...) {
+			kind = "rwmutex"
+		}
+		c.errorCollector.AddError(call.Pos(), category.LockOnValueReceiver,
+			kind+" '"+c.aliases.VarName(sel.X)+"' locked on value receiver copy")
+		return true
+	})
+}
+
+// valueReceiver returns the receiver variable of fn when the method has a
+// named, non-pointer receiver.
+func (c *Checker) valueReceiver(fn *ast.FuncDecl) *types.Var {
+	if c.typesInfo == nil || fn == nil || fn.Body == nil || fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return nil
+	}
+	names := fn.Recv.List[0].Names
+	if len(names) == 0 {
+		return nil
+	}
+	recv, ok := c.typesInfo.Defs[names[0]].(*types.Var)
+	if !ok {
+		return nil
+	}
+	if _, isPtr := recv.Type().Underlying().(*types.Pointer); isPtr {
+		return nil
+	}
+	return recv
+}
+
+// selectsThroughValues reports whether sel is a Lock/RLock on a sync.Mutex
+// or sync.RWMutex that sits in recv itself: every step from recv to the
+// mutex, including promoted methods of an embedded mutex, selects a value
+// field rather than following a pointer.
+func (c *Checker) selectsThroughValues(sel *ast.SelectorExpr, recv *types.Var) bool {
+	method, ok := c.typesInfo.Selections[sel]
+	if !ok || method.Indirect() {
+		return false
+	}
+	locked := c.lockedType(sel)
+	if !common.IsMutex(locked, c.syncPackages...) && !common.IsRWMutex(locked, c.syncPackages...) {
+		return false
+	}
+	expr := common.UnwrapParenExpr(sel.X)
+	for {
+		switch e := expr.(type) {
+		case *ast.Ident:
+			return c.typesInfo.Uses[e] == recv
+		case *ast.SelectorExpr:
+			field, ok := c.typesInfo.Selections[e]
+			if !ok || field.Kind() != types.FieldVal || field.Indirect() {
+				return false
+			}
+			if _, isPtr := field.Type().Underlying().(*types.Pointer); isPtr {
+				return false
+			}
+			expr = common.UnwrapParenExpr(e.X)
+		default:
+			return false
+		}
+	}
+}
+
+// lockedType returns the type of the mutex whose method sel selects: the
+// receiver of the method object, so a promoted Lock of an embedded mutex
+// resolves to sync.Mutex rather than the embedding struct.
+func (c *Checker) lockedType(sel *ast.SelectorExpr) types.Type {
+	fn, ok := c.typesInfo.Uses[sel.Sel].(*types.Func)
+	if !ok {
+		return nil
+	}
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || sig.Recv() == nil {
+		return nil
+	}
+	return sig.Recv().Type()
+}
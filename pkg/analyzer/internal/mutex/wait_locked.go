@@ -0,0 +1,88 @@
+package mutex
+
+import (
+	"go/ast"
+	"maps"
+	"slices"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+)
+
+// reportWaitWhileLocked flags `wg.Wait()` statements reached while a mutex is
+// still held and some goroutine launched by the function acquires that same
+// mutex, so the awaited worker can never finish. The walker owns the
+// flow-sensitive lock counts, so the check lives here rather than in the
+// waitgroup analyzer; a deferred unlock does not release the lock before Wait,
+// so the raw lock count is what matters.
+func (c *Checker) reportWaitWhileLocked(stmt *ast.ExprStmt, stats map[string]*Stats) {
+	if c.rawBodyEffects || stmt == nil || c.function == nil {
+		return
+	}
+	call, ok := common.UnwrapParenExpr(stmt.X).(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 || c.commentFilter.ShouldSkipCall(call) {
+		return
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Wait" || !common.IsWaitGroup(c.typesInfo.TypeOf(sel.X), c.syncPackages...) {
+		return
+	}
+
+	wgName := common.GetVarName(sel.X)
+	for _, name := range slices.Sorted(maps.Keys(stats)) {
+		st := stats[name]
+		if st == nil {
+			continue
+		}
+		switch {
+		case c.mutexNames[name] && st.lock > 0 && c.goroutineAcquires(name, true):
+			c.errorCollector.AddError(call.Pos(), category.WaitWhileLocked, "waitgroup '"+wgName+"' Wait called while mutex '"+name+"' held",
+				heldAt(st.lockPos, "mutex", name, "locked")...)
+		case c.rwMutexNames[name] && st.lock > 0 && c.goroutineAcquires(name, true):
+			c.errorCollector.AddError(call.Pos(), category.WaitWhileLocked, "waitgroup '"+wgName+"' Wait called while rwmutex '"+name+"' held",
+				heldAt(st.lockPos, "rwmutex", name, "locked")...)
+		case c.rwMutexNames[name] && st.rlock > 0 && c.goroutineAcquires(name, false):
+			c.errorCollector.AddError(call.Pos(), category.WaitWhileLocked, "waitgroup '"+wgName+"' Wait called while rwmutex '"+name+"' rlocked",
+				heldAt(st.rlockPos, "rwmutex", name, "rlocked")...)
+		}
+	}
+}
+
+// goroutineAcquires reports whether a `go func() { ... }()` launched by the
+// current function locks varName. A write-held lock blocks both Lock and
+// RLock; a read-held one only blocks Lock.
+func (c *Checker) goroutineAcquires(varName string, exclusiveHeld bool) bool {
+	found := false
+	ast.Inspect(c.function.Body, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		goStmt, ok := n.(*ast.GoStmt)
+		if !ok {
+			return true
+		}
+		lit, ok := goStmt.Call.Fun.(*ast.FuncLit)
+		if !ok {
+			return false
+		}
+		ast.Inspect(lit.Body, func(inner ast.Node) bool {
+			call, ok := inner.(*ast.CallExpr)
+			if !ok || found {
+				return !found
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || c.aliases.VarName(sel.X) != varName {
+				return true
+			}
+			switch sel.Sel.Name {
+			case "Lock":
+				found = true
+			case "RLock":
+				found = exclusiveHeld
+			}
+			return !found
+		})
+		return false
+	})
+	return found
+}
@@ -4,8 +4,6 @@ import (
 	"go/ast"
 	"go/token"
 	"slices"
-
-	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
 )
 
 // analyzeBlock analyzes a block statement starting from the provided state and
@@ -29,6 +27,11 @@ func (c *Checker) analyzeStatementList(stmts []ast.Stmt, initial map[string]*Sta
 		if c.skipBalancedGuardedLock(stmt, stmts[i+1:], skip) {
 			continue
 		}
+		if i+1 < len(stmts) {
+			c.reportEmptyCriticalSection(stmt, stmts[i+1])
+		}
+		c.reportReadOnlyWriteLock(stmt, stmts[i+1:])
+		c.reportUnlockSkippedByPanic(stmt, stmts[i+1:], blockStats)
 		c.analyzeStatementWithTail(stmt, blockStats, terminatingTail[i+1])
 	}
 
@@ -56,12 +59,20 @@ func (c *Checker) terminatingTailByIndex(stmts []ast.Stmt) []bool {
 
 // analyzeStatement analyzes individual statements
 func (c *Checker) analyzeStatement(stmt ast.Stmt, stats map[string]*Stats) {
+	if launch, ok := c.goLaunches[stmt]; ok {
+		c.analyzeGoStatement(launch, stats)
+		return
+	}
 	switch s := stmt.(type) {
 	case *ast.ExprStmt:
 		c.panicDetector.reportPotentialPanicWhileLocked(s, stats)
+		c.reportWaitWhileLocked(s, stats)
+		c.reportSleepWhileLocked(s, stats)
 		c.analyzeExpressionStatement(s, stats)
 	case *ast.AssignStmt:
 		c.analyzeAssignStatement(s, stats)
+	case *ast.IncDecStmt:
+		c.reportWriteUnderRLock(s, stats)
 	case *ast.DeclStmt:
 		c.analyzeDeclStatement(s, stats)
 	case *ast.DeferStmt:
@@ -202,7 +213,7 @@ func (c *Checker) guardedMutexCall(stmt ast.Stmt) (string, string, string, bool)
 			if !ok {
 				return true
 			}
-			candidateVarName := common.GetVarName(sel.X)
+			candidateVarName := c.aliases.VarName(sel.X)
 			if !c.mutexNames[candidateVarName] && !c.rwMutexNames[candidateVarName] {
 				return true
 			}
@@ -9,6 +9,14 @@ import (
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
 )
 
+// MethodPairs treats a mutex field locked in one method and released in
+// another method of the same type as a balanced pair, so neither half is
+// reported on its own. It is off by default, since a leaking method is then
+// excused by any sibling that only releases the field; the umbrella
+// analyzer's -method-pairs flag sets it for types built around Begin/End or
+// Acquire/Release style methods.
+var MethodPairs bool
+
 // wrapperResolver decides whether a lone Lock/Unlock (etc.) call inside a method
 // is a "borrowed" half of a wrapper pair — e.g. a `func (s *S) Lock()` that only
 // calls `s.mu.Lock()` while a sibling `func (s *S) Unlock()` calls
@@ -57,6 +65,10 @@ func (w *wrapperResolver) resolve(varName, methodName string) bool {
 		return false
 	}
 
+	if MethodPairs && w.isMethodPairHalf(varName, methodName, oppositeMethods) {
+		return true
+	}
+
 	if methodName == "RLock" && w.isOneWayReadLatch(varName) {
 		return true
 	}
@@ -287,6 +299,38 @@ func (w *wrapperResolver) typeNameForBaseVar(baseVar string) string {
 	return found
 }
 
+// isMethodPairHalf reports whether varName is a field of the current method's
+// receiver and another method of the same type makes the opposite call on
+// that field without making methodName's own, as an End that only unlocks
+// pairs with a Begin that only locks. A sibling holding both halves balances
+// itself and pairs with nothing.
+func (w *wrapperResolver) isMethodPairHalf(varName, methodName string, oppositeMethods []string) bool {
+	suffix, ok := strings.CutPrefix(varName, common.ReceiverName(w.function))
+	if !ok || !strings.HasPrefix(suffix, ".") {
+		return false
+	}
+	receiverType := common.ReceiverTypeName(w.function)
+	if receiverType == "" {
+		return false
+	}
+	ownMethods := mutexMethodGroup(methodName)
+	for name, fn := range w.receiverMethods[receiverType] {
+		if name == w.function.Name.Name || fn == nil || fn.Body == nil {
+			continue
+		}
+		siblingReceiver := common.ReceiverName(fn)
+		if siblingReceiver == "" {
+			continue
+		}
+		targetVar := siblingReceiver + suffix
+		if functionBodyContainsFieldCall(fn.Body, targetVar, oppositeMethods) &&
+			!functionBodyContainsFieldCall(fn.Body, targetVar, ownMethods) {
+			return true
+		}
+	}
+	return false
+}
+
 func methodNameLooksLikeWrapper(fnName, syncMethod string) bool {
 	return methodNameMatchesAnyHint(fnName, []string{syncMethod})
 }
@@ -0,0 +1,100 @@
+package mutex
+
+import (
+	"go/ast"
+	"go/token"
+	"maps"
+	"slices"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+)
+
+// reportWriteUnderRLock flags an assignment or increment of shared state made
+// while an rwmutex is only read-locked. Other readers may hold the same lock,
+// so the write races with them. Only package variables and fields reached
+// from a receiver, parameter or package variable count as shared; fields of
+// locals declared in the function are usually results being filled in. The
+// write is left alone while any lock is write-held, since that lock may be
+// the one guarding it.
+func (c *Checker) reportWriteUnderRLock(stmt ast.Stmt, stats map[string]*Stats) {
+	if c.rawBodyEffects || c.function == nil {
+		return
+	}
+	var targets []ast.Expr
+	switch s := stmt.(type) {
+	case *ast.AssignStmt:
+		if s.Tok == token.DEFINE {
+			return
+		}
+		targets = s.Lhs
+	case *ast.IncDecStmt:
+		targets = []ast.Expr{s.X}
+	default:
+		return
+	}
+
+	var rlocked string
+	for _, name := range slices.Sorted(maps.Keys(stats)) {
+		st := stats[name]
+		if st == nil {
+			continue
+		}
+		if st.lock > 0 {
+			return
+		}
+		if rlocked == "" && c.rwMutexNames[name] && st.rlock > 0 {
+			rlocked = name
+		}
+	}
+	if rlocked == "" {
+		return
+	}
+
+	for _, target := range targets {
+		if !c.isSharedWriteTarget(target) {
+			continue
+		}
+		c.errorCollector.AddError(target.Pos(), category.WriteUnderRLock,
+			"write under RLock of rwmutex '"+rlocked+"'; use Lock",
+			heldAt(stats[rlocked].rlockPos, "rwmutex", rlocked, "rlocked")...)
+		return
+	}
+}
+
+// isSharedWriteTarget reports whether assigning to target writes a package
+// variable or a field not rooted at a variable local to the function.
+func (c *Checker) isSharedWriteTarget(target ast.Expr) bool {
+	root := writeRoot(target)
+	if _, ok := c.sharedKey(root); !ok {
+		return false
+	}
+	ident := rootIdent(root)
+	if ident == nil {
+		return false
+	}
+	obj := c.typesInfo.ObjectOf(ident)
+	if obj == nil {
+		return false
+	}
+	body := c.function.Body
+	return obj.Pos() < body.Pos() || obj.Pos() >= body.End()
+}
+
+// rootIdent returns the variable a selector chain starts from.
+func rootIdent(expr ast.Expr) *ast.Ident {
+	for {
+		switch e := common.UnwrapParenExpr(expr).(type) {
+		case *ast.Ident:
+			return e
+		case *ast.SelectorExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.StarExpr:
+			expr = e.X
+		default:
+			return nil
+		}
+	}
+}
@@ -49,6 +49,7 @@ type Checker struct {
 	typesInfo      *types.Info
 	scope          *packageScope
 	function       *ast.FuncDecl
+	syncPackages   []string
 }
 
 // NewChecker creates a sync.Once checker. pkg supplies the package-scope Once
@@ -60,6 +61,7 @@ func NewChecker(errorCollector report.Reporter, pkg *primitives.Result, typesInf
 		errorCollector: errorCollector,
 		typesInfo:      typesInfo,
 		scope:          scope,
+		syncPackages:   pkg.SyncPackages,
 	}
 }
 
@@ -76,7 +78,7 @@ func (c *Checker) AnalyzeFunction(fn *ast.FuncDecl) {
 		}
 		// The type check keeps http.Client.Do and friends out, including
 		// when a name collides with a tracked Once.
-		if !common.IsOnce(c.typesInfo.TypeOf(sel.X)) {
+		if !common.IsOnce(c.typesInfo.TypeOf(sel.X), c.syncPackages...) {
 			return true
 		}
 		onceName := common.GetVarName(sel.X)
@@ -406,7 +408,7 @@ func (c *Checker) isDoCallOn(call *ast.CallExpr, target string) bool {
 	if !ok || sel.Sel.Name != "Do" {
 		return false
 	}
-	if !common.IsOnce(c.typesInfo.TypeOf(sel.X)) {
+	if !common.IsOnce(c.typesInfo.TypeOf(sel.X), c.syncPackages...) {
 		return false
 	}
 	return common.GetVarName(sel.X) == target
@@ -20,6 +20,10 @@ import (
 type Checker struct {
 	errorCollector report.Reporter
 	typesInfo      *types.Info
+
+	// syncPackages lists the sync forks configured for the package; see
+	// primitives.Result.SyncPackages.
+	syncPackages []string
 }
 
 // NewChecker creates a sync.Pool checker. typesInfo is the pass-wide type
@@ -40,7 +44,7 @@ func (c *Checker) CheckCall(call *ast.CallExpr) {
 	if !ok || sel.Sel.Name != "Put" || len(call.Args) != 1 {
 		return
 	}
-	if !common.IsPool(c.typesInfo.TypeOf(sel.X)) {
+	if !common.IsPool(c.typesInfo.TypeOf(sel.X), c.syncPackages...) {
 		return
 	}
 
@@ -71,7 +75,7 @@ func (c *Checker) CheckCompositeLit(cl *ast.CompositeLit) {
 	if fn == nil {
 		return
 	}
-	if !common.IsPool(c.typesInfo.TypeOf(cl)) {
+	if !common.IsPool(c.typesInfo.TypeOf(cl), c.syncPackages...) {
 		return
 	}
 	c.checkNewValue(fn)
@@ -103,7 +107,7 @@ func (c *Checker) CheckAssign(assign *ast.AssignStmt) {
 		if !ok || sel.Sel.Name != "New" || i >= len(assign.Rhs) {
 			continue
 		}
-		if !common.IsPool(c.typesInfo.TypeOf(sel.X)) {
+		if !common.IsPool(c.typesInfo.TypeOf(sel.X), c.syncPackages...) {
 			continue
 		}
 		if fn, ok := assign.Rhs[i].(*ast.FuncLit); ok {
@@ -0,0 +1,214 @@
+package pool
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+)
+
+// pooledGet is one `v := p.Get()` (optionally type-asserted) inside a function.
+type pooledGet struct {
+	pool  string
+	value types.Object
+	call  *ast.CallExpr
+}
+
+// CheckFunc flags v := p.Get() (GCL5002) when the function never hands v back
+// with p.Put(v), deferred or not. It parallels the WaitGroup Add/Done balance
+// but is a heuristic, so the check is opt-in. To stay quiet on ownership
+// transfers it skips values that escape the function: returned, sent on a
+// channel, stored elsewhere, or passed to a same-package function that may
+// release them.
+func (c *Checker) CheckFunc(fn *ast.FuncDecl) {
+	if fn.Body == nil {
+		return
+	}
+	for _, get := range c.pooledGets(fn.Body) {
+		if c.putsBack(fn.Body, get) || c.escapes(fn.Body, get.value) {
+			continue
+		}
+		c.errorCollector.AddError(get.call.Pos(), category.PoolGetWithoutPut,
+			"sync.Pool '"+get.pool+"' Get without corresponding Put")
+	}
+}
+
+// pooledGets collects the Get results bound to a named local variable.
+func (c *Checker) pooledGets(body *ast.BlockStmt) []pooledGet {
+	var gets []pooledGet
+	record := func(lhs *ast.Ident, rhs ast.Expr) {
+		if lhs == nil || lhs.Name == "_" {
+			return
+		}
+		call, pool, ok := c.poolGetCall(rhs)
+		if !ok {
+			return
+		}
+		if obj := c.typesInfo.ObjectOf(lhs); obj != nil {
+			gets = append(gets, pooledGet{pool: pool, value: obj, call: call})
+		}
+	}
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			if len(node.Lhs) == len(node.Rhs) {
+				for i, lhs := range node.Lhs {
+					ident, _ := lhs.(*ast.Ident)
+					record(ident, node.Rhs[i])
+				}
+			} else if len(node.Rhs) == 1 && len(node.Lhs) == 2 {
+				// buf, ok := p.Get().(*T)
+				ident, _ := node.Lhs[0].(*ast.Ident)
+				record(ident, node.Rhs[0])
+			}
+		case *ast.ValueSpec:
+			for i, name := range node.Names {
+				if i < len(node.Values) {
+					record(name, node.Values[i])
+				}
+			}
+		}
+		return true
+	})
+	return gets
+}
+
+// poolGetCall unwraps parentheses and a type assertion and reports whether
+// expr is p.Get() on a real sync.Pool, returning the call and the pool name.
+func (c *Checker) poolGetCall(expr ast.Expr) (*ast.CallExpr, string, bool) {
+	expr = common.UnwrapParenExpr(expr)
+	if assert, ok := expr.(*ast.TypeAssertExpr); ok {
+		expr = common.UnwrapParenExpr(assert.X)
+	}
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return nil, "", false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Get" || !common.IsPool(c.typesInfo.TypeOf(sel.X), c.syncPackages...) {
+		return nil, "", false
+	}
+	return call, common.GetVarName(sel.X), true
+}
+
+// putsBack reports whether body calls get.pool.Put(v) anywhere, including
+// inside deferred calls and closures.
+func (c *Checker) putsBack(body *ast.BlockStmt, get pooledGet) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) != 1 {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Put" || common.GetVarName(sel.X) != get.pool {
+			return true
+		}
+		if !common.IsPool(c.typesInfo.TypeOf(sel.X), c.syncPackages...) {
+			return true
+		}
+		found = c.refersTo(call.Args[0], get.value)
+		return !found
+	})
+	return found
+}
+
+// escapes reports whether v leaves the function's ownership, in which case a
+// missing Put is someone else's responsibility.
+func (c *Checker) escapes(body *ast.BlockStmt, v types.Object) bool {
+	escaped := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if escaped {
+			return false
+		}
+		switch node := n.(type) {
+		case *ast.ReturnStmt:
+			escaped = c.anyRefersTo(node.Results, v)
+		case *ast.SendStmt:
+			escaped = c.refersTo(node.Value, v)
+		case *ast.AssignStmt:
+			for i, rhs := range node.Rhs {
+				// `_ = v` only silences the compiler; it stores v nowhere.
+				if len(node.Lhs) == len(node.Rhs) && isBlank(node.Lhs[i]) {
+					continue
+				}
+				if c.refersTo(rhs, v) {
+					escaped = true
+				}
+			}
+		case *ast.CompositeLit:
+			for _, elt := range node.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					elt = kv.Value
+				}
+				if c.refersTo(elt, v) {
+					escaped = true
+				}
+			}
+		case *ast.CallExpr:
+			escaped = c.mayReleaseArg(node, v.Pkg()) && c.anyRefersTo(node.Args, v)
+		}
+		return !escaped
+	})
+	return escaped
+}
+
+// mayReleaseArg reports whether call could hand its arguments back to the
+// pool: any callee that is not a function or method from another package than
+// pkg, so same-package release helpers and function values count as hand-offs
+// while fmt.Fprintf(buf, ...) and the like do not.
+func (c *Checker) mayReleaseArg(call *ast.CallExpr, pkg *types.Package) bool {
+	if tv, ok := c.typesInfo.Types[call.Fun]; ok && (tv.IsType() || tv.IsBuiltin()) {
+		return false
+	}
+	var ident *ast.Ident
+	switch fun := common.UnwrapParenExpr(call.Fun).(type) {
+	case *ast.Ident:
+		ident = fun
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	}
+	if ident == nil {
+		return true
+	}
+	fn, ok := c.typesInfo.ObjectOf(ident).(*types.Func)
+	if !ok {
+		return true
+	}
+	return fn.Pkg() == pkg
+}
+
+func isBlank(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == "_"
+}
+
+func (c *Checker) anyRefersTo(exprs []ast.Expr, v types.Object) bool {
+	for _, expr := range exprs {
+		if c.refersTo(expr, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// refersTo reports whether expr is v itself, possibly parenthesized, address
+// taken or converted through a type assertion.
+func (c *Checker) refersTo(expr ast.Expr, v types.Object) bool {
+	for {
+		switch e := common.UnwrapParenExpr(expr).(type) {
+		case *ast.UnaryExpr:
+			expr = e.X
+		case *ast.TypeAssertExpr:
+			expr = e.X
+		case *ast.Ident:
+			return c.typesInfo.ObjectOf(e) == v
+		default:
+			return false
+		}
+	}
+}
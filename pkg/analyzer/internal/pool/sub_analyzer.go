@@ -6,6 +6,7 @@ import (
 
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/report"
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/filesetup"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/primitives"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
 	"golang.org/x/tools/go/ast/inspector"
@@ -16,15 +17,16 @@ import (
 // Result so the umbrella Analyzer re-emits them (and analysistest observes them
 // through the umbrella).
 //
-// The checks are keyed off Put call sites and Pool.New assignments, so this
-// sub-analyzer skips the per-function driver skeleton entirely: it walks the
-// relevant nodes directly and cheap-rejects before touching type information,
-// keeping it near-free on large packages.
+// The checks are keyed off Put call sites, Pool.New assignments and the
+// Get/Put pairing inside each function, so this sub-analyzer skips the
+// per-function driver skeleton entirely: it walks the relevant nodes directly
+// and cheap-rejects before touching type information, keeping it near-free on
+// large packages.
 var SubAnalyzer = &analysis.Analyzer{
 	Name:       "goconcurrencylint_pool",
-	Doc:        "Detects non-pointer values placed in a sync.Pool (Put argument or New return), which box and allocate on every call.",
+	Doc:        "Detects non-pointer values placed in a sync.Pool (Put argument or New return), which box and allocate on every call, and pooled values that are never Put back.",
 	Run:        run,
-	Requires:   []*analysis.Analyzer{inspect.Analyzer, filesetup.Analyzer},
+	Requires:   []*analysis.Analyzer{inspect.Analyzer, primitives.Analyzer, filesetup.Analyzer},
 	ResultType: reflect.TypeFor[[]analysis.Diagnostic](),
 }
 
@@ -33,14 +35,16 @@ func run(pass *analysis.Pass) (any, error) {
 	files := pass.ResultOf[filesetup.Analyzer].(*filesetup.Result)
 	ec := &report.ErrorCollector{}
 	checker := NewChecker(ec, pass.TypesInfo)
+	checker.syncPackages = pass.ResultOf[primitives.Analyzer].(*primitives.Result).SyncPackages
 
 	nodeFilter := []ast.Node{
 		(*ast.CallExpr)(nil),
 		(*ast.CompositeLit)(nil),
 		(*ast.AssignStmt)(nil),
+		(*ast.FuncDecl)(nil),
 	}
 	insp.Preorder(nodeFilter, func(n ast.Node) {
-		if files.IsGenerated(pass.Fset.File(n.Pos())) {
+		if files.Skips(pass.Fset, n.Pos()) {
 			return
 		}
 		switch node := n.(type) {
@@ -54,6 +58,8 @@ func run(pass *analysis.Pass) (any, error) {
 			checker.CheckCompositeLit(node)
 		case *ast.AssignStmt:
 			checker.CheckAssign(node)
+		case *ast.FuncDecl:
+			checker.CheckFunc(node)
 		}
 	})
 
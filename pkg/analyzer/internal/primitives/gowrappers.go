@@ -0,0 +1,179 @@
+package primitives
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/config"
+)
+
+// GoWrappers is a comma-separated list of functions that run their
+// function-literal argument on a new goroutine, such as a supervised pool's
+// Go method. A bare name ("Go") matches any function or method of that name.
+// A qualified one names the callee through the types it is declared with,
+// whatever the call looks like: "workerpool.Go" is the function Go of a
+// package named workerpool, and "pool.Go" or "workerpool.pool.Go" the
+// method Go of the type pool, called on a value or a pointer. It is bound to
+// the umbrella analyzer's -go-wrappers flag; when it is empty the
+// go-wrappers list of .goconcurrencylint.yaml applies instead.
+var GoWrappers string
+
+// asyncFuncs lists standard library functions that always run their
+// function-literal argument on another goroutine, keyed by package path and
+// name. They are recognized whether or not GoWrappers is set.
+var asyncFuncs = map[string]bool{
+	"time.AfterFunc": true,
+}
+
+// goWrapperMatcher reports whether a call starts a goroutine through one of
+// the configured wrappers or a known asynchronous function.
+type goWrapperMatcher struct {
+	names        map[string]bool
+	qualified    map[string]bool
+	info         *types.Info
+	syncPackages []string
+}
+
+// newGoWrapperMatcher returns the matcher for GoWrappers, or for the
+// go-wrappers list of opts when the flag is empty. It returns nil when no
+// wrapper is configured and pkg imports none of asyncFuncs' packages, so
+// function bodies are not walked for calls that cannot occur.
+func newGoWrapperMatcher(opts *config.Options, pkg *types.Package, info *types.Info, syncPackages []string) *goWrapperMatcher {
+	var list []string
+	if GoWrappers != "" {
+		list = strings.Split(GoWrappers, ",")
+	} else if opts != nil {
+		list = opts.GoWrappers
+	}
+	m := &goWrapperMatcher{names: make(map[string]bool), qualified: make(map[string]bool), info: info, syncPackages: syncPackages}
+	for _, name := range list {
+		name = strings.TrimSpace(name)
+		switch {
+		case name == "":
+		case strings.Contains(name, "."):
+			m.qualified[name] = true
+		default:
+			m.names[name] = true
+		}
+	}
+	if len(m.names) == 0 && len(m.qualified) == 0 && !importsAsyncFunc(pkg) {
+		return nil
+	}
+	return m
+}
+
+// importsAsyncFunc reports whether pkg imports the package of any of
+// asyncFuncs.
+func importsAsyncFunc(pkg *types.Package) bool {
+	if pkg == nil {
+		return false
+	}
+	for _, imp := range pkg.Imports() {
+		for name := range asyncFuncs {
+			if strings.HasPrefix(name, imp.Path()+".") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// funcLit returns the function literal a wrapper call runs, or nil when
+// call is not a wrapper call whose last argument is a literal. WaitGroup.Go
+// has its own semantics and never counts as a wrapper.
+func (m *goWrapperMatcher) funcLit(call *ast.CallExpr) *ast.FuncLit {
+	if len(call.Args) == 0 {
+		return nil
+	}
+	lit, ok := call.Args[len(call.Args)-1].(*ast.FuncLit)
+	if !ok {
+		return nil
+	}
+	switch fun := common.UnwrapParenExpr(call.Fun).(type) {
+	case *ast.Ident:
+		if m.names[fun.Name] || m.isQualified(fun) {
+			return lit
+		}
+	case *ast.SelectorExpr:
+		if m.info != nil && common.IsWaitGroup(m.info.TypeOf(fun.X), m.syncPackages...) {
+			return nil
+		}
+		if m.names[fun.Sel.Name] || m.isQualified(fun.Sel) || m.isAsyncFunc(fun.Sel) {
+			return lit
+		}
+	}
+	return nil
+}
+
+// isQualified reports whether sel names a function or method matched by a
+// qualified entry: pkg.Func for a function, Type.Method or pkg.Type.Method
+// for a method, pkg being the name of the declaring package.
+func (m *goWrapperMatcher) isQualified(sel *ast.Ident) bool {
+	if len(m.qualified) == 0 || m.info == nil {
+		return false
+	}
+	fn, ok := m.info.Uses[sel].(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return false
+	}
+	recv := fn.Signature().Recv()
+	if recv == nil {
+		return m.qualified[fn.Pkg().Name()+"."+fn.Name()]
+	}
+	typ := recv.Type()
+	if ptr, ok := types.Unalias(typ).(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok {
+		return false
+	}
+	method := named.Obj().Name() + "." + fn.Name()
+	return m.qualified[method] || m.qualified[fn.Pkg().Name()+"."+method]
+}
+
+// isAsyncFunc reports whether sel names one of asyncFuncs.
+func (m *goWrapperMatcher) isAsyncFunc(sel *ast.Ident) bool {
+	if m.info == nil {
+		return false
+	}
+	fn, ok := m.info.Uses[sel].(*types.Func)
+	return ok && fn.Pkg() != nil && asyncFuncs[fn.Pkg().Path()+"."+fn.Name()]
+}
+
+// goLaunches maps every statement of body that calls a wrapper, either on
+// its own or as the single value of an assignment such as
+// `t := time.AfterFunc(d, func() {...})`, to the go statement running the
+// wrapper's literal. The go statement is not part of the tree: its Go
+// position is the call's and its Fun the literal itself, so a checker that
+// meets the mapped statement handles it as it would `go func() {...}()`.
+func (m *goWrapperMatcher) goLaunches(body *ast.BlockStmt) map[ast.Stmt]*ast.GoStmt {
+	if m == nil || body == nil {
+		return nil
+	}
+	launches := make(map[ast.Stmt]*ast.GoStmt)
+	ast.Inspect(body, func(n ast.Node) bool {
+		var expr ast.Expr
+		switch s := n.(type) {
+		case *ast.ExprStmt:
+			expr = s.X
+		case *ast.AssignStmt:
+			if len(s.Rhs) == 1 {
+				expr = common.UnwrapParenExpr(s.Rhs[0])
+			}
+		default:
+			return true
+		}
+		call, ok := expr.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if lit := m.funcLit(call); lit != nil {
+			launches[n.(ast.Stmt)] = &ast.GoStmt{Go: call.Pos(), Call: &ast.CallExpr{Fun: lit, Lparen: lit.End(), Rparen: call.Rparen}}
+		}
+		return true
+	})
+	return launches
+}
@@ -0,0 +1,88 @@
+package primitives
+
+import (
+	"go/types"
+	"path"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/config"
+)
+
+// The lock and unlock methods of a custom locker type when the configuration
+// names locker types but no methods.
+var (
+	defaultLockMethods   = []string{"Lock", "RLock"}
+	defaultUnlockMethods = []string{"Unlock", "RUnlock"}
+)
+
+// TypeMatcher recognizes the custom locker types listed under locker-types
+// in .goconcurrencylint.yaml, such as a semaphore with Acquire and Release
+// methods. Variables of a matching type are tracked as mutexes, and Canonical
+// maps their lock-methods and unlock-methods onto Lock and Unlock so the
+// balance checks apply to them unchanged. A nil *TypeMatcher matches nothing.
+type TypeMatcher struct {
+	globs  []string
+	lock   map[string]bool
+	unlock map[string]bool
+}
+
+// newTypeMatcher returns the matcher for opts, or nil when opts names no
+// locker types.
+func newTypeMatcher(opts *config.Options) *TypeMatcher {
+	if opts == nil || len(opts.LockerTypes) == 0 {
+		return nil
+	}
+	lockMethods, unlockMethods := opts.LockMethods, opts.UnlockMethods
+	if len(lockMethods) == 0 {
+		lockMethods = defaultLockMethods
+	}
+	if len(unlockMethods) == 0 {
+		unlockMethods = defaultUnlockMethods
+	}
+	m := &TypeMatcher{
+		globs:  opts.LockerTypes,
+		lock:   make(map[string]bool, len(lockMethods)),
+		unlock: make(map[string]bool, len(unlockMethods)),
+	}
+	for _, name := range lockMethods {
+		m.lock[name] = true
+	}
+	for _, name := range unlockMethods {
+		m.unlock[name] = true
+	}
+	return m
+}
+
+// Match reports whether typ, or the type it points to, is a named type whose
+// "package.Name" matches one of the locker-types globs, e.g. "sema.Weighted"
+// or "*.Semaphore".
+func (m *TypeMatcher) Match(typ types.Type) bool {
+	if m == nil || typ == nil {
+		return false
+	}
+	named, ok := common.DerefOnceAndUnalias(typ).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	name := named.Obj().Pkg().Name() + "." + named.Obj().Name()
+	for _, glob := range m.globs {
+		if ok, _ := path.Match(glob, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Canonical returns "Lock" for a configured lock method, "Unlock" for a
+// configured unlock method, and method itself otherwise.
+func (m *TypeMatcher) Canonical(method string) string {
+	switch {
+	case m == nil:
+		return method
+	case m.lock[method]:
+		return "Lock"
+	case m.unlock[method]:
+		return "Unlock"
+	}
+	return method
+}
@@ -9,16 +9,35 @@
 package primitives
 
 import (
+	"fmt"
 	"go/ast"
 	"go/token"
 	"go/types"
 	"maps"
+	"path"
 	"reflect"
+	"strings"
 
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/config"
 	"golang.org/x/tools/go/analysis"
 )
 
+// TrackLockers classifies variables of interface type sync.Locker as
+// mutexes, so their Lock/Unlock balance is checked like a sync.Mutex. It is
+// bound to the umbrella analyzer's -track-lockers flag; off by default,
+// because a Locker is often an alias of a mutex that is also tracked under
+// its own name.
+var TrackLockers bool
+
+// IgnoreNames is a comma-separated list of path.Match globs naming
+// primitives that are never tracked. A glob is matched against the whole
+// tracked name, so "mu" skips a variable called mu and "*.mu" skips the mu
+// field of any struct. It is bound to the umbrella analyzer's -ignore-names
+// flag; when it is empty the ignore-names list of .goconcurrencylint.yaml
+// applies instead.
+var IgnoreNames string
+
 // Result lists sync primitive variable names declared at package scope.
 // Map values are always true; the map shape is preserved from the
 // pre-refactor implementation for compatibility with downstream callers
@@ -28,6 +47,24 @@ type Result struct {
 	RWMutexes  map[string]bool
 	WaitGroups map[string]bool
 	Onces      map[string]bool
+
+	// Lockers recognizes the custom locker types configured for the
+	// package; nil when there are none.
+	Lockers *TypeMatcher
+
+	// SyncPackages lists the extra-sync-packages configured for the
+	// package: import paths of sync forks whose types count as sync
+	// primitives. Pass it to the common.Is* helpers.
+	SyncPackages []string
+
+	// wrappers recognizes calls that run a function literal on a new
+	// goroutine: the configured go-wrappers and time.AfterFunc. It is nil
+	// when the package can contain neither.
+	wrappers *goWrapperMatcher
+
+	// ignored holds the globs of IgnoreNames in effect for the package,
+	// applied again to every FunctionResult built from this Result.
+	ignored []string
 }
 
 // FunctionResult lists sync primitive names visible inside a function:
@@ -35,7 +72,12 @@ type Result struct {
 // in the body. LocalWaitGroups holds the function-local subset of
 // WaitGroups (before merging the package scope) and PackageWaitGroups
 // holds the package-level subset; the waitgroup checker needs both to
-// decide whether Wait must be present.
+// decide whether Wait must be present. WaitGroupSlices holds the local
+// slices and arrays of WaitGroups, keyed as "wgs[]" since the element an
+// index selects is not known statically. ShadowingWaitGroups maps each
+// WaitGroup a nested block declares under a name an enclosing scope already
+// binds to another WaitGroup to that block; the checker analyzes the block
+// apart from the rest of the function, where calls on it are hidden.
 type FunctionResult struct {
 	Mutexes           map[string]bool
 	RWMutexes         map[string]bool
@@ -43,6 +85,26 @@ type FunctionResult struct {
 	Onces             map[string]bool
 	LocalWaitGroups   map[string]bool
 	PackageWaitGroups map[string]bool
+	WaitGroupSlices   map[string]bool
+
+	// Lockers is the package Result's matcher, through which the mutex
+	// checker maps a custom locker's methods onto Lock and Unlock.
+	Lockers *TypeMatcher
+
+	// SyncPackages is the package Result's list of sync forks.
+	SyncPackages []string
+
+	ShadowingWaitGroups map[types.Object]*ast.BlockStmt
+
+	// MutexAliases maps a local pointer declared as `p := &mu` and never
+	// assigned again to the mutex or rwmutex it points at. The alias is left
+	// out of Mutexes and RWMutexes, so p.Lock() counts as a Lock of mu.
+	MutexAliases common.VarAliases
+
+	// GoLaunches maps each statement that starts a goroutine through a
+	// go-wrapper or time.AfterFunc to the go statement it stands for, so the
+	// checkers handle it wherever they handle *ast.GoStmt.
+	GoLaunches map[ast.Stmt]*ast.GoStmt
 }
 
 // Analyzer computes the package-scope primitives once per package.
@@ -56,11 +118,31 @@ var Analyzer = &analysis.Analyzer{
 }
 
 func run(pass *analysis.Pass) (any, error) {
+	opts, err := config.ForPass(pass)
+	if err != nil {
+		return nil, err
+	}
+	var syncPackages []string
+	list := IgnoreNames
+	if opts != nil {
+		syncPackages = opts.ExtraSyncPackages
+		if list == "" {
+			list = strings.Join(opts.IgnoreNames, ",")
+		}
+	}
+	ignored, err := ignoreGlobs(list)
+	if err != nil {
+		return nil, err
+	}
 	res := &Result{
-		Mutexes:    map[string]bool{},
-		RWMutexes:  map[string]bool{},
-		WaitGroups: map[string]bool{},
-		Onces:      map[string]bool{},
+		Mutexes:      map[string]bool{},
+		RWMutexes:    map[string]bool{},
+		WaitGroups:   map[string]bool{},
+		Onces:        map[string]bool{},
+		Lockers:      newTypeMatcher(opts),
+		SyncPackages: syncPackages,
+		wrappers:     newGoWrapperMatcher(opts, pass.Pkg, pass.TypesInfo, syncPackages),
+		ignored:      ignored,
 	}
 
 	scope := pass.Pkg.Scope()
@@ -75,23 +157,67 @@ func run(pass *analysis.Pass) (any, error) {
 		}
 		classify(name, varObj.Type(), res.maps())
 	}
+	res.maps().drop(ignored)
 
 	return res, nil
 }
 
+// ignoreGlobs splits the -ignore-names list and validates each glob.
+func ignoreGlobs(list string) ([]string, error) {
+	var globs []string
+	for _, glob := range strings.Split(list, ",") {
+		glob = strings.TrimSpace(glob)
+		if glob == "" {
+			continue
+		}
+		if _, err := path.Match(glob, ""); err != nil {
+			return nil, fmt.Errorf("-ignore-names: invalid glob %q: %w", glob, err)
+		}
+		globs = append(globs, glob)
+	}
+	return globs, nil
+}
+
+// isIgnored reports whether name matches one of globs.
+func isIgnored(globs []string, name string) bool {
+	for _, glob := range globs {
+		if ok, _ := path.Match(glob, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
 // maps bundles this Result's per-kind name maps for classify.
 func (r *Result) maps() primitiveMaps {
-	return primitiveMaps{mu: r.Mutexes, rw: r.RWMutexes, wg: r.WaitGroups, once: r.Onces}
+	return primitiveMaps{mu: r.Mutexes, rw: r.RWMutexes, wg: r.WaitGroups, once: r.Onces, lockers: r.Lockers, syncPackages: r.SyncPackages}
 }
 
 func (fr *FunctionResult) maps() primitiveMaps {
-	return primitiveMaps{mu: fr.Mutexes, rw: fr.RWMutexes, wg: fr.WaitGroups, once: fr.Onces}
+	return primitiveMaps{mu: fr.Mutexes, rw: fr.RWMutexes, wg: fr.WaitGroups, once: fr.Onces, lockers: fr.Lockers, syncPackages: fr.SyncPackages}
 }
 
 // primitiveMaps bundles the per-kind name maps so classify keeps a single
-// signature as new primitives are added.
+// signature as new primitives are added. lockers routes custom locker types
+// into mu; syncPackages lists the sync forks whose types count as sync.
 type primitiveMaps struct {
 	mu, rw, wg, once map[string]bool
+	lockers          *TypeMatcher
+	syncPackages     []string
+}
+
+// drop removes every name matching one of globs from the maps.
+func (m primitiveMaps) drop(globs []string) {
+	if len(globs) == 0 {
+		return
+	}
+	for _, names := range []map[string]bool{m.mu, m.rw, m.wg, m.once} {
+		for name := range names {
+			if isIgnored(globs, name) {
+				delete(names, name)
+			}
+		}
+	}
 }
 
 // ForFunction returns the primitives visible inside fn, merging the
@@ -100,34 +226,54 @@ type primitiveMaps struct {
 // the merge so callers can tell locals apart from package-level vars.
 func ForFunction(fn *ast.FuncDecl, pass *analysis.Pass, pkg *Result) *FunctionResult {
 	fr := &FunctionResult{
-		Mutexes:    map[string]bool{},
-		RWMutexes:  map[string]bool{},
-		WaitGroups: map[string]bool{},
-		Onces:      map[string]bool{},
-	}
-
-	// Function parameters: include mutex/rwmutex/once but intentionally not
-	// waitgroups (Done-only worker functions would produce false positives).
-	if fn.Type != nil && fn.Type.Params != nil {
-		for _, field := range fn.Type.Params.List {
-			typ := pass.TypesInfo.TypeOf(field.Type)
-			if typ == nil {
+		Mutexes:         map[string]bool{},
+		RWMutexes:       map[string]bool{},
+		WaitGroups:      map[string]bool{},
+		Onces:           map[string]bool{},
+		WaitGroupSlices: map[string]bool{},
+		Lockers:         pkg.Lockers,
+		SyncPackages:    pkg.SyncPackages,
+
+		ShadowingWaitGroups: map[types.Object]*ast.BlockStmt{},
+	}
+
+	// Function parameters and named results: include mutex/rwmutex/once but
+	// intentionally not waitgroups (Done-only worker functions would produce
+	// false positives).
+	if fn.Type != nil {
+		for _, list := range []*ast.FieldList{fn.Type.Params, fn.Type.Results} {
+			if list == nil {
 				continue
 			}
-			for _, name := range field.Names {
-				switch {
-				case common.IsMutex(typ):
-					fr.Mutexes[name.Name] = true
-				case common.IsRWMutex(typ):
-					fr.RWMutexes[name.Name] = true
-				case common.IsOnce(typ):
-					fr.Onces[name.Name] = true
+			for _, field := range list.List {
+				typ := pass.TypesInfo.TypeOf(field.Type)
+				if typ == nil {
+					continue
+				}
+				for _, name := range field.Names {
+					switch {
+					case common.IsMutex(typ, fr.SyncPackages...):
+						fr.Mutexes[name.Name] = true
+					case common.IsRWMutex(typ, fr.SyncPackages...):
+						fr.RWMutexes[name.Name] = true
+					case common.IsOnce(typ, fr.SyncPackages...):
+						fr.Onces[name.Name] = true
+					case TrackLockers && common.IsLocker(typ, fr.SyncPackages...), fr.Lockers.Match(typ):
+						fr.Mutexes[name.Name] = true
+					}
 				}
 			}
 		}
 	}
 
 	scanBody(fn.Body, pass, fr)
+	findShadowingWaitGroups(fn.Body, pass.TypesInfo, fr)
+	fr.maps().drop(pkg.ignored)
+	for name := range fr.WaitGroupSlices {
+		if isIgnored(pkg.ignored, strings.TrimSuffix(name, "[]")) {
+			delete(fr.WaitGroupSlices, name)
+		}
+	}
 
 	// Snapshot locals before merging package-scope.
 	localWG := make(map[string]bool, len(fr.WaitGroups))
@@ -139,18 +285,100 @@ func ForFunction(fn *ast.FuncDecl, pass *analysis.Pass, pkg *Result) *FunctionRe
 	maps.Copy(fr.Onces, pkg.Onces)
 	fr.LocalWaitGroups = localWG
 	fr.PackageWaitGroups = pkg.WaitGroups
+	fr.MutexAliases = findMutexAliases(fn.Body, pass.TypesInfo, fr)
+	fr.GoLaunches = pkg.wrappers.goLaunches(fn.Body)
 
 	return fr
 }
 
+// findMutexAliases returns the locals of body declared as the address of a
+// tracked mutex or rwmutex variable, `p := &mu` or `var p = &mu`, whose
+// value never changes afterwards, mapped to the mutex name, and drops them
+// from fr's name maps.
+func findMutexAliases(body *ast.BlockStmt, info *types.Info, fr *FunctionResult) common.VarAliases {
+	aliases := common.VarAliases{}
+	if body == nil || info == nil {
+		return aliases
+	}
+
+	candidates := map[types.Object]string{}
+	bind := func(ident *ast.Ident, value ast.Expr) {
+		addr, ok := common.UnwrapParenExpr(value).(*ast.UnaryExpr)
+		if !ok || addr.Op != token.AND {
+			return
+		}
+		// Only a plain variable is resolved; `p := &s.mu` keeps reporting
+		// under p, the name the function actually locks through.
+		target, ok := common.UnwrapParenExpr(addr.X).(*ast.Ident)
+		if !ok || (!fr.Mutexes[target.Name] && !fr.RWMutexes[target.Name]) {
+			return
+		}
+		if obj := info.Defs[ident]; obj != nil {
+			candidates[obj] = target.Name
+		}
+	}
+	changed := map[types.Object]bool{}
+	declared := map[string]int{}
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.Ident:
+			if info.Defs[node] != nil {
+				declared[node.Name]++
+			}
+		case *ast.ValueSpec:
+			if len(node.Names) == len(node.Values) {
+				for i, name := range node.Names {
+					bind(name, node.Values[i])
+				}
+			}
+		case *ast.AssignStmt:
+			for i, lhs := range node.Lhs {
+				ident, ok := lhs.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				if node.Tok == token.DEFINE && len(node.Lhs) == len(node.Rhs) && info.Defs[ident] != nil {
+					bind(ident, node.Rhs[i])
+					continue
+				}
+				if obj := info.Uses[ident]; obj != nil {
+					changed[obj] = true
+				}
+			}
+		case *ast.UnaryExpr:
+			if ident, ok := node.X.(*ast.Ident); ok && node.Op == token.AND {
+				if obj := info.Uses[ident]; obj != nil {
+					changed[obj] = true
+				}
+			}
+		}
+		return true
+	})
+
+	for obj, target := range candidates {
+		name := obj.Name()
+		// A name declared twice in the function may stand for different
+		// variables in different scopes; keying by name cannot tell them
+		// apart.
+		if changed[obj] || declared[name] > 1 || name == target {
+			continue
+		}
+		aliases[name] = target
+		delete(fr.Mutexes, name)
+		delete(fr.RWMutexes, name)
+	}
+	return aliases
+}
+
 // HasMutexes reports whether any mutex or rwmutex name is in scope.
 func HasMutexes(fr *FunctionResult) bool {
 	return len(fr.Mutexes) > 0 || len(fr.RWMutexes) > 0
 }
 
-// HasWaitGroups reports whether any waitgroup name is in scope.
+// HasWaitGroups reports whether any waitgroup name, or local slice of
+// waitgroups, is in scope.
 func HasWaitGroups(fr *FunctionResult) bool {
-	return len(fr.WaitGroups) > 0
+	return len(fr.WaitGroups) > 0 || len(fr.WaitGroupSlices) > 0
 }
 
 // HasOnces reports whether any sync.Once name is in scope.
@@ -171,6 +399,7 @@ func scanBody(body *ast.BlockStmt, pass *analysis.Pass, fr *FunctionResult) {
 					continue
 				}
 				classify(name.Name, typ, fr.maps())
+				classifyCollection(name.Name, typ, fr)
 			}
 
 		case *ast.AssignStmt:
@@ -184,23 +413,59 @@ func scanBody(body *ast.BlockStmt, pass *analysis.Pass, fr *FunctionResult) {
 				}
 				if typ := pass.TypesInfo.TypeOf(node.Rhs[i]); typ != nil {
 					classify(ident.Name, typ, fr.maps())
+					classifyCollection(ident.Name, typ, fr)
 				}
 			}
 
 		case *ast.SelectorExpr:
-			if selection, ok := pass.TypesInfo.Selections[node]; ok && selection.Kind() == types.FieldVal {
+			// Field accesses are keyed by their selector path (s.mu,
+			// s.inner.mu) from the field's own type, so anonymous struct
+			// types work the same as named ones.
+			selection, ok := pass.TypesInfo.Selections[node]
+			if !ok {
+				break
+			}
+			switch selection.Kind() {
+			case types.FieldVal:
 				fieldType := selection.Type()
 				parentName := common.GetVarName(node.X)
 				if parentName != "?" {
 					compoundName := parentName + "." + node.Sel.Name
 					classify(compoundName, fieldType, fr.maps())
 				}
+			case types.MethodVal:
+				// A method promoted from an embedded mutex, at any depth,
+				// locks the embedding value itself: s.RLock() is keyed as s.
+				if recv := promotedMutex(selection, fr.SyncPackages); recv != nil {
+					if name := common.GetVarName(node.X); name != "?" {
+						classify(name, recv, fr.maps())
+					}
+				}
 			}
 		}
 		return true
 	})
 }
 
+// promotedMutex returns the sync.Mutex or sync.RWMutex type whose method
+// selection selects through one or more embedded fields, or nil when the
+// method is declared on the selected type itself or on another type.
+// syncPackages lists the sync forks whose mutexes count as well.
+func promotedMutex(selection *types.Selection, syncPackages []string) types.Type {
+	if len(selection.Index()) < 2 {
+		return nil
+	}
+	sig, ok := selection.Obj().Type().(*types.Signature)
+	if !ok || sig.Recv() == nil {
+		return nil
+	}
+	recv := sig.Recv().Type()
+	if !common.IsMutex(recv, syncPackages...) && !common.IsRWMutex(recv, syncPackages...) {
+		return nil
+	}
+	return recv
+}
+
 // variableType extracts the type information for a variable specification.
 func variableType(vs *ast.ValueSpec, pass *analysis.Pass) types.Type {
 	if vs.Type != nil {
@@ -226,13 +491,95 @@ func variableType(vs *ast.ValueSpec, pass *analysis.Pass) types.Type {
 // *FunctionResult (per-function) without duplication.
 func classify(name string, typ types.Type, into primitiveMaps) {
 	switch {
-	case common.IsMutex(typ):
+	case common.IsMutex(typ, into.syncPackages...):
 		into.mu[name] = true
-	case common.IsRWMutex(typ):
+	case common.IsRWMutex(typ, into.syncPackages...):
 		into.rw[name] = true
-	case common.IsWaitGroup(typ):
+	case common.IsWaitGroup(typ, into.syncPackages...):
 		into.wg[name] = true
-	case common.IsOnce(typ):
+	case common.IsOnce(typ, into.syncPackages...):
 		into.once[name] = true
+	case TrackLockers && common.IsLocker(typ, into.syncPackages...), into.lockers.Match(typ):
+		into.mu[name] = true
+	}
+}
+
+// classifyCollection records name as a WaitGroup slice when typ is a slice
+// or array of WaitGroup values. Pointer elements are left out: each aliases
+// a WaitGroup tracked under its own name, whose calls they would split off.
+func classifyCollection(name string, typ types.Type, fr *FunctionResult) {
+	if name == "_" {
+		return
+	}
+	var elem types.Type
+	switch t := typ.Underlying().(type) {
+	case *types.Slice:
+		elem = t.Elem()
+	case *types.Array:
+		elem = t.Elem()
+	default:
+		return
+	}
+	if _, ok := elem.(*types.Pointer); !ok && common.IsWaitGroup(elem, fr.SyncPackages...) {
+		fr.WaitGroupSlices[name+"[]"] = true
 	}
 }
+
+// findShadowingWaitGroups records in fr every WaitGroup declared in a block
+// of body that shadows another WaitGroup of the same name, whether
+// a parameter, an outer local or a package-level variable. Only blocks with
+// their own scope in the function's own flow count: a declaration in an if
+// or for header, directly in a function body or inside a closure is left to
+// the enclosing analysis.
+func findShadowingWaitGroups(body *ast.BlockStmt, info *types.Info, fr *FunctionResult) {
+	if body == nil || info == nil || info.Scopes == nil {
+		return
+	}
+	blocks := map[*types.Scope]*ast.BlockStmt{}
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.BlockStmt:
+			if scope := info.Scopes[node]; scope != nil {
+				blocks[scope] = node
+			}
+		}
+		return true
+	})
+	if len(blocks) == 0 {
+		return
+	}
+	ast.Inspect(body, func(n ast.Node) bool {
+		var names []*ast.Ident
+		switch node := n.(type) {
+		case *ast.ValueSpec:
+			names = node.Names
+		case *ast.AssignStmt:
+			if node.Tok != token.DEFINE {
+				return true
+			}
+			for _, lhs := range node.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok {
+					names = append(names, ident)
+				}
+			}
+		}
+		for _, ident := range names {
+			obj, ok := info.Defs[ident].(*types.Var)
+			if !ok || !common.IsWaitGroup(obj.Type(), fr.SyncPackages...) {
+				continue
+			}
+			block := blocks[obj.Parent()]
+			if block == nil || obj.Parent().Parent() == nil {
+				continue
+			}
+			if _, outer := obj.Parent().Parent().LookupParent(ident.Name, ident.Pos()); outer != nil {
+				if v, ok := outer.(*types.Var); ok && common.IsWaitGroup(v.Type(), fr.SyncPackages...) {
+					fr.ShadowingWaitGroups[obj] = block
+				}
+			}
+		}
+		return true
+	})
+}
@@ -2,11 +2,14 @@ package primitives
 
 import (
 	"go/ast"
+	"go/importer"
 	"go/parser"
 	"go/token"
 	"go/types"
 	"testing"
 
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/config"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/tools/go/analysis"
 )
@@ -85,3 +88,370 @@ func TestFunc() {
 	assert.False(t, HasMutexes(fr), "Should not have mutexes")
 	assert.False(t, HasWaitGroups(fr), "Should not have waitgroups")
 }
+
+func TestForFunctionAnonymousStructFields(t *testing.T) {
+	src := `package p
+
+import "sync"
+
+func TestFunc() {
+	s := struct{ mu sync.Mutex }{}
+	s.mu.Lock()
+
+	var r struct{ inner struct{ rw sync.RWMutex } }
+	r.inner.rw.RLock()
+}
+`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", src, 0)
+	assert.NoError(t, err)
+
+	info := &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	_, err = conf.Check("p", fset, []*ast.File{file}, info)
+	assert.NoError(t, err)
+
+	fn := file.Decls[1].(*ast.FuncDecl)
+	pkg := &Result{
+		Mutexes:    map[string]bool{},
+		RWMutexes:  map[string]bool{},
+		WaitGroups: map[string]bool{},
+		Onces:      map[string]bool{},
+	}
+	fr := ForFunction(fn, &analysis.Pass{TypesInfo: info}, pkg)
+
+	assert.True(t, fr.Mutexes["s.mu"], "anonymous struct field should be keyed by its selector")
+	assert.True(t, fr.RWMutexes["r.inner.rw"], "nested anonymous struct field should be keyed by its full selector")
+}
+
+func TestForFunctionWaitGroupSlices(t *testing.T) {
+	src := `package p
+
+import "sync"
+
+func TestFunc(n int) {
+	wgs := make([]sync.WaitGroup, n)
+	var arr [2]sync.WaitGroup
+	ptrs := []*sync.WaitGroup{}
+	ints := make([]int, n)
+	wgs[0].Add(1)
+	arr[0].Add(1)
+	_, _ = ptrs, ints
+}
+`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", src, 0)
+	assert.NoError(t, err)
+
+	info := &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	_, err = conf.Check("p", fset, []*ast.File{file}, info)
+	assert.NoError(t, err)
+
+	fn := file.Decls[1].(*ast.FuncDecl)
+	pkg := &Result{
+		Mutexes:    map[string]bool{},
+		RWMutexes:  map[string]bool{},
+		WaitGroups: map[string]bool{},
+		Onces:      map[string]bool{},
+	}
+	fr := ForFunction(fn, &analysis.Pass{TypesInfo: info}, pkg)
+
+	assert.Equal(t, map[string]bool{"wgs[]": true, "arr[]": true}, fr.WaitGroupSlices,
+		"only slices and arrays of WaitGroup values are tracked")
+	assert.Empty(t, fr.WaitGroups, "elements are not tracked as plain waitgroups")
+	assert.True(t, HasWaitGroups(fr), "a waitgroup slice alone should make the function relevant")
+}
+
+func TestForFunctionPointerMutexFields(t *testing.T) {
+	src := `package p
+
+import "sync"
+
+type server struct {
+	mu    *sync.Mutex
+	cache *sync.RWMutex
+}
+
+func (s *server) TestFunc() {
+	s.mu.Lock()
+	s.cache.RLock()
+}
+`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", src, 0)
+	assert.NoError(t, err)
+
+	info := &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	_, err = conf.Check("p", fset, []*ast.File{file}, info)
+	assert.NoError(t, err)
+
+	fn := file.Decls[2].(*ast.FuncDecl)
+	pkg := &Result{
+		Mutexes:    map[string]bool{},
+		RWMutexes:  map[string]bool{},
+		WaitGroups: map[string]bool{},
+		Onces:      map[string]bool{},
+	}
+	fr := ForFunction(fn, &analysis.Pass{TypesInfo: info}, pkg)
+
+	assert.True(t, fr.Mutexes["s.mu"], "*sync.Mutex field should be classified as a mutex")
+	assert.True(t, fr.RWMutexes["s.cache"], "*sync.RWMutex field should be classified as an rwmutex")
+}
+
+func TestForFunctionPromotedMutexMethods(t *testing.T) {
+	src := `package p
+
+import "sync"
+
+type inner struct{ sync.RWMutex }
+
+type outer struct{ inner }
+
+type wrapped struct{ sync.Mutex }
+
+func (w *wrapped) Lock() {}
+
+func TestFunc(s *outer, w *wrapped) {
+	s.RLock()
+	w.Lock()
+}
+`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", src, 0)
+	assert.NoError(t, err)
+
+	info := &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	_, err = conf.Check("p", fset, []*ast.File{file}, info)
+	assert.NoError(t, err)
+
+	fn := file.Decls[len(file.Decls)-1].(*ast.FuncDecl)
+	pkg := &Result{
+		Mutexes:    map[string]bool{},
+		RWMutexes:  map[string]bool{},
+		WaitGroups: map[string]bool{},
+		Onces:      map[string]bool{},
+	}
+	fr := ForFunction(fn, &analysis.Pass{TypesInfo: info}, pkg)
+
+	assert.Equal(t, map[string]bool{"s": true}, fr.RWMutexes, "RLock promoted two levels keys the embedding value")
+	assert.Empty(t, fr.Mutexes, "a Lock declared on the embedding type is not the mutex's")
+}
+
+func TestForFunctionNamedResults(t *testing.T) {
+	src := `package p
+
+import "sync"
+
+func TestFunc(in *sync.Mutex, wg *sync.WaitGroup) (mu *sync.Mutex, rw *sync.RWMutex, out *sync.WaitGroup) {
+	return
+}
+`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", src, 0)
+	assert.NoError(t, err)
+
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	_, err = conf.Check("p", fset, []*ast.File{file}, info)
+	assert.NoError(t, err)
+
+	fn := file.Decls[1].(*ast.FuncDecl)
+	pkg := &Result{
+		Mutexes:    map[string]bool{},
+		RWMutexes:  map[string]bool{},
+		WaitGroups: map[string]bool{},
+		Onces:      map[string]bool{},
+	}
+	fr := ForFunction(fn, &analysis.Pass{TypesInfo: info}, pkg)
+
+	assert.Equal(t, map[string]bool{"in": true, "mu": true}, fr.Mutexes)
+	assert.Equal(t, map[string]bool{"rw": true}, fr.RWMutexes)
+	assert.Empty(t, fr.WaitGroups, "waitgroup parameters and results stay untracked")
+}
+
+func TestForFunctionShadowingWaitGroups(t *testing.T) {
+	src := `package p
+
+import "sync"
+
+var pkgWG sync.WaitGroup
+
+func TestFunc(items []int) {
+	var wg sync.WaitGroup
+	{
+		var wg sync.WaitGroup
+		_ = wg
+	}
+	for range items {
+		var other sync.WaitGroup
+		_ = other
+	}
+	if true {
+		pkgWG := sync.WaitGroup{}
+		_ = pkgWG
+	}
+	go func() {
+		{
+			var wg sync.WaitGroup
+			_ = wg
+		}
+	}()
+	_ = wg
+}
+`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", src, 0)
+	assert.NoError(t, err)
+
+	info := &types.Info{
+		Types:  make(map[ast.Expr]types.TypeAndValue),
+		Defs:   make(map[*ast.Ident]types.Object),
+		Uses:   make(map[*ast.Ident]types.Object),
+		Scopes: make(map[ast.Node]*types.Scope),
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	_, err = conf.Check("p", fset, []*ast.File{file}, info)
+	assert.NoError(t, err)
+
+	fn := file.Decls[2].(*ast.FuncDecl)
+	pkg := &Result{
+		Mutexes:    map[string]bool{},
+		RWMutexes:  map[string]bool{},
+		WaitGroups: map[string]bool{"pkgWG": true},
+		Onces:      map[string]bool{},
+	}
+	fr := ForFunction(fn, &analysis.Pass{TypesInfo: info}, pkg)
+
+	bare := fn.Body.List[1].(*ast.BlockStmt)
+	ifBody := fn.Body.List[3].(*ast.IfStmt).Body
+	innerWG := info.Defs[bare.List[0].(*ast.DeclStmt).Decl.(*ast.GenDecl).Specs[0].(*ast.ValueSpec).Names[0]]
+	innerPkgWG := info.Defs[ifBody.List[0].(*ast.AssignStmt).Lhs[0].(*ast.Ident)]
+	assert.Equal(t, map[types.Object]*ast.BlockStmt{innerWG: bare, innerPkgWG: ifBody}, fr.ShadowingWaitGroups,
+		"only WaitGroups shadowing one of the same name, outside closures, are recorded")
+}
+
+func TestForFunctionMutexAliases(t *testing.T) {
+	src := `package p
+
+import "sync"
+
+type store struct{ lock sync.Mutex }
+
+func TestFunc(s *store, other *sync.Mutex) {
+	var mu sync.Mutex
+	var rw sync.RWMutex
+	p := &mu
+	var r = &rw
+	f := &s.lock
+	q := &mu
+	q = other
+	p.Lock()
+	r.RLock()
+	f.Lock()
+	q.Lock()
+}
+`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", src, 0)
+	assert.NoError(t, err)
+
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	_, err = conf.Check("p", fset, []*ast.File{file}, info)
+	assert.NoError(t, err)
+
+	fn := file.Decls[len(file.Decls)-1].(*ast.FuncDecl)
+	pkg := &Result{
+		Mutexes:    map[string]bool{},
+		RWMutexes:  map[string]bool{},
+		WaitGroups: map[string]bool{},
+		Onces:      map[string]bool{},
+	}
+	fr := ForFunction(fn, &analysis.Pass{TypesInfo: info}, pkg)
+
+	assert.Equal(t, common.VarAliases{"p": "mu", "r": "rw"}, fr.MutexAliases)
+	assert.False(t, fr.Mutexes["p"], "an alias is tracked as the mutex it points at")
+	assert.False(t, fr.RWMutexes["r"], "an alias is tracked as the rwmutex it points at")
+	assert.True(t, fr.Mutexes["f"], "an alias of a field keeps its own name")
+	assert.True(t, fr.Mutexes["q"], "a reassigned pointer keeps its own name")
+}
+
+func TestGoWrapperMatcherQualified(t *testing.T) {
+	src := `package p
+
+type pool struct{}
+
+func (*pool) Go(func()) {}
+
+type other struct{}
+
+func (other) Go(func()) {}
+
+func Run(func()) {}
+
+func TestFunc(p *pool, pool other) {
+	p.Go(func() {})
+	pool.Go(func() {})
+	Run(func() {})
+}
+`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", src, 0)
+	assert.NoError(t, err)
+
+	info := &types.Info{
+		Defs: make(map[*ast.Ident]types.Object),
+		Uses: make(map[*ast.Ident]types.Object),
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	typesPkg, err := conf.Check("p", fset, []*ast.File{file}, info)
+	assert.NoError(t, err)
+
+	fn := file.Decls[len(file.Decls)-1].(*ast.FuncDecl)
+	opts := &config.Options{GoWrappers: []string{"pool.Go", "p.Run"}}
+	launches := newGoWrapperMatcher(opts, typesPkg, info, nil).goLaunches(fn.Body)
+
+	stmts := fn.Body.List
+	assert.Contains(t, launches, stmts[0], "the method is matched through the receiver's type")
+	assert.NotContains(t, launches, stmts[1], "a variable named like the type does not match")
+	assert.Contains(t, launches, stmts[2], "a function is matched through its package name")
+}
@@ -0,0 +1,120 @@
+// Package syncmap detects misuse of sync.Map.
+package syncmap
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/report"
+)
+
+// Checker reports a sync.Map key that is read with Load and then written with
+// Store in the same function. Each call is atomic, but the pair is not: a
+// concurrent Store between them is lost. LoadOrStore and CompareAndSwap are
+// the atomic forms, so a function using either on the map is left alone, as
+// is one that serializes the update with a mutex.
+type Checker struct {
+	errorCollector report.Reporter
+	typesInfo      *types.Info
+
+	// syncPackages lists the sync forks configured for the package; see
+	// primitives.Result.SyncPackages.
+	syncPackages []string
+}
+
+// NewChecker creates a sync.Map checker. typesInfo is the pass-wide type
+// information used to confirm a receiver really is a sync.Map and to match
+// keys by the variable they name.
+func NewChecker(errorCollector report.Reporter, typesInfo *types.Info) *Checker {
+	return &Checker{
+		errorCollector: errorCollector,
+		typesInfo:      typesInfo,
+	}
+}
+
+// mapCall is one Load or Store on a sync.Map inside a function.
+type mapCall struct {
+	name string
+	key  ast.Expr
+	call *ast.CallExpr
+}
+
+// CheckFunc flags m.Store(k, ...) (GCL8001) when the same function earlier
+// calls m.Load(k) on the same key.
+func (c *Checker) CheckFunc(fn *ast.FuncDecl) {
+	if fn.Body == nil {
+		return
+	}
+
+	var loads, stores []mapCall
+	atomic := make(map[string]bool)
+	locked := false
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		recv := c.typesInfo.TypeOf(sel.X)
+		if common.IsMutex(recv, c.syncPackages...) || common.IsRWMutex(recv, c.syncPackages...) {
+			locked = locked || sel.Sel.Name == "Lock"
+			return true
+		}
+		if !common.IsSyncMap(recv, c.syncPackages...) || len(call.Args) == 0 {
+			return true
+		}
+		name := common.GetVarName(sel.X)
+		switch sel.Sel.Name {
+		case "Load":
+			loads = append(loads, mapCall{name: name, key: call.Args[0], call: call})
+		case "Store":
+			stores = append(stores, mapCall{name: name, key: call.Args[0], call: call})
+		case "LoadOrStore", "CompareAndSwap", "CompareAndDelete", "Swap":
+			atomic[name] = true
+		}
+		return true
+	})
+	if locked {
+		return
+	}
+
+	for _, store := range stores {
+		if store.name == "" || atomic[store.name] {
+			continue
+		}
+		for _, load := range loads {
+			if load.name != store.name || load.call.Pos() > store.call.Pos() || !c.sameKey(load.key, store.key) {
+				continue
+			}
+			c.errorCollector.AddError(store.call.Pos(), category.SyncMapNonAtomicUpdate,
+				"sync.Map '"+store.name+"' non-atomic read-modify-write",
+				report.Related(load.call.Pos(), "key loaded here"))
+			break
+		}
+	}
+}
+
+// sameKey reports whether a and b denote the same key: the same variable, or
+// the same constant value.
+func (c *Checker) sameKey(a, b ast.Expr) bool {
+	a, b = common.UnwrapParenExpr(a), common.UnwrapParenExpr(b)
+	if tvA, ok := c.typesInfo.Types[a]; ok && tvA.Value != nil {
+		tvB, ok := c.typesInfo.Types[b]
+		return ok && tvB.Value != nil && tvA.Value.ExactString() == tvB.Value.ExactString()
+	}
+	identA, ok := a.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	identB, ok := b.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	obj := c.typesInfo.ObjectOf(identA)
+	return obj != nil && obj == c.typesInfo.ObjectOf(identB)
+}
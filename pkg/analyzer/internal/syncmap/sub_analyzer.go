@@ -0,0 +1,37 @@
+package syncmap
+
+import (
+	"go/ast"
+	"reflect"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/report"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/driver"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/filesetup"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/primitives"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+)
+
+// SubAnalyzer drives the sync.Map checks as an independent analysis.Analyzer.
+// Like the other sub-analyzers it returns its diagnostics as Result so the
+// umbrella Analyzer re-emits them.
+//
+// The primitives scan does not list sync.Map values, so no guard can pick
+// out the functions using one: every function declaration is searched for a
+// Load followed by a Store on the same key.
+var SubAnalyzer = &analysis.Analyzer{
+	Name:       "goconcurrencylint_syncmap",
+	Doc:        "Detects non-atomic read-modify-write of a sync.Map key (Load followed by Store instead of LoadOrStore or CompareAndSwap).",
+	Run:        run,
+	Requires:   []*analysis.Analyzer{inspect.Analyzer, primitives.Analyzer, filesetup.Analyzer},
+	ResultType: reflect.TypeFor[[]analysis.Diagnostic](),
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	syncPackages := pass.ResultOf[primitives.Analyzer].(*primitives.Result).SyncPackages
+	return driver.RunFuncs(pass, func(reporter report.Reporter) func(*ast.FuncDecl) {
+		checker := NewChecker(reporter, pass.TypesInfo)
+		checker.syncPackages = syncPackages
+		return checker.CheckFunc
+	})
+}
@@ -0,0 +1,146 @@
+package waitgroup
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+)
+
+// reportAddExceedsGoroutines reports a single literal Add(n) in the main
+// flow, followed by a Wait, when the goroutines launched on the WaitGroup,
+// together with any Done in the main flow, cannot call Done n times. It only
+// applies when every Done is countable: none sits in a loop, in a closure
+// that is not called in place, or behind a method value such as
+// done := wg.Done. It reports whether a diagnostic was emitted, so the
+// generic unmatched-Add report can step aside.
+func (b *balanceValidator) reportAddExceedsGoroutines(wgName string, stats *Stats) bool {
+	if len(stats.addCalls) != 1 || len(stats.goCalls) > 0 {
+		return false
+	}
+	add := stats.addCalls[0]
+	if !add.known || add.value <= 0 || !b.isInMainFunctionFlow(add.pos) {
+		return false
+	}
+	// Without a Wait the shortfall leaks the counter but blocks nothing;
+	// the generic unmatched-Add report covers it.
+	if b.nextWaitAfter(stats, add.pos) == token.NoPos {
+		return false
+	}
+	counter := &doneCounter{b: b, wgName: wgName, add: add}
+	counter.walk(b.function.Body, false)
+	if counter.unbounded || counter.goroutines == 0 || counter.dones >= add.value {
+		return false
+	}
+	b.reporter.AddError(add.at, category.AddExceedsGoroutines,
+		"waitgroup '"+wgName+"' Add("+strconv.Itoa(add.value)+") exceeds spawned goroutines")
+	return true
+}
+
+// doneCounter computes an upper bound on the Done calls a function body can
+// make on one WaitGroup. unbounded is set as soon as the count cannot be
+// bounded statically.
+type doneCounter struct {
+	b      *balanceValidator
+	wgName string
+	add    addCall
+
+	dones      int
+	goroutines int
+	unbounded  bool
+}
+
+// walk counts the Done calls under root; inLoop reports whether root may run
+// more than once.
+func (d *doneCounter) walk(root ast.Node, inLoop bool) {
+	ast.Inspect(root, func(n ast.Node) bool {
+		if d.unbounded || n == nil {
+			return false
+		}
+		switch node := n.(type) {
+		case *ast.ForStmt, *ast.RangeStmt:
+			if node != root {
+				d.walk(node, true)
+				return false
+			}
+		case *ast.GoStmt:
+			d.walkGoroutine(node, inLoop)
+			return false
+		case *ast.FuncLit:
+			// A closure not called in place may run any number of times.
+			if referencesWaitGroup(d.b.names, node, d.wgName) {
+				d.unbounded = true
+			}
+			return false
+		case *ast.SelectorExpr:
+			// A method value such as done := wg.Done hides later calls.
+			if node.Sel.Name == "Done" && d.b.names.of(node.X) == d.wgName {
+				d.unbounded = true
+			}
+		case *ast.CallExpr:
+			if node.Pos() == d.add.pos && inLoop {
+				d.unbounded = true
+				return false
+			}
+			if d.b.callInvokesDone(node, d.wgName) {
+				d.countDone(inLoop)
+				return false
+			}
+			if fnLit, ok := node.Fun.(*ast.FuncLit); ok {
+				d.checkLiteralArgs(node)
+				d.walk(fnLit.Body, inLoop)
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// walkGoroutine counts the Done calls goStmt can make and records it as a
+// Done-capable goroutine when there is at least one.
+func (d *doneCounter) walkGoroutine(goStmt *ast.GoStmt, inLoop bool) {
+	before := d.dones
+	if fnLit, ok := goStmt.Call.Fun.(*ast.FuncLit); ok {
+		// A WaitGroup handed to the literal may be called Done under the
+		// parameter's name.
+		d.checkLiteralArgs(goStmt.Call)
+		d.walk(fnLit.Body, inLoop)
+	} else if info, related := d.b.goroutineDoneInfo(goStmt, d.wgName); related && info.hasAnyDone {
+		d.countDone(inLoop)
+	}
+	if d.dones > before {
+		d.goroutines++
+	}
+}
+
+// checkLiteralArgs gives up when a call passes the WaitGroup to a function literal.
+func (d *doneCounter) checkLiteralArgs(call *ast.CallExpr) {
+	for _, arg := range call.Args {
+		if referencesWaitGroup(d.b.names, arg, d.wgName) {
+			d.unbounded = true
+		}
+	}
+}
+
+func (d *doneCounter) countDone(inLoop bool) {
+	if inLoop {
+		d.unbounded = true
+		return
+	}
+	d.dones++
+}
+
+// referencesWaitGroup reports whether n mentions wgName anywhere, as in
+// wg.Done(), &wg or s.wg.
+func referencesWaitGroup(names varNames, n ast.Node, wgName string) bool {
+	found := false
+	ast.Inspect(n, func(n ast.Node) bool {
+		switch expr := n.(type) {
+		case *ast.Ident, *ast.SelectorExpr:
+			found = names.of(expr.(ast.Expr)) == wgName
+		}
+		return !found
+	})
+	return found
+}
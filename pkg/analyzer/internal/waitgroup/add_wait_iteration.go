@@ -0,0 +1,40 @@
+package waitgroup
+
+import (
+	"strings"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+)
+
+// checkAddWaitSameIteration flags a Wait on a local WaitGroup whose loop body
+// also calls Add on it before the Wait, so every iteration fills and drains
+// the same counter. The group is declared outside the loop, which makes it a
+// shared barrier reused across rounds rather than one scoped to each batch.
+// The check is opt-in (GCL2026): the pattern is correct as long as every
+// worker finishes within its iteration. Add calls inside goroutines, loops
+// whose body always exits, and groups declared inside the loop body are left
+// alone.
+func (c *Checker) checkAddWaitSameIteration(stats map[string]*Stats, balance *balanceValidator) {
+	for wgName, st := range stats {
+		if !c.localWaitGroupNames[wgName] || strings.Contains(wgName, ".") {
+			continue
+		}
+		for _, waitPos := range st.waitCalls {
+			body := c.enclosingLoopBody(waitPos)
+			if body == nil || !balance.isInMainFunctionFlow(waitPos) || c.worker.blockAlwaysTerminates(body) {
+				continue
+			}
+			if obj := balance.waitGroupReceiverObjectAt(wgName, "Wait", waitPos); obj == nil ||
+				(body.Pos() <= obj.Pos() && obj.Pos() < body.End()) {
+				continue
+			}
+			for _, add := range st.addCalls {
+				if add.pos < waitPos && callsInside([]addCall{add}, body) && balance.isInMainFunctionFlow(add.pos) {
+					c.errorCollector.AddError(waitPos, category.AddWaitSameIteration,
+						"waitgroup '"+wgName+"' Add and Wait in same loop iteration")
+					break
+				}
+			}
+		}
+	}
+}
@@ -20,19 +20,35 @@ type Checker struct {
 	waitGroupNames             map[string]bool
 	localWaitGroupNames        map[string]bool
 	packageLevelWaitGroupNames map[string]bool
+	waitGroupSlices            map[string]bool
 	errorCollector             report.Reporter
 	function                   *ast.FuncDecl
 	commentFilter              *commentfilter.CommentFilter
 	typesInfo                  *types.Info
+	fset                       *token.FileSet
 	functionDecls              map[token.Pos]*ast.FuncDecl
 	escape                     *escapeAnalyzer
 	iteration                  *iterationEstimator
 	worker                     *workerDoneAnalyzer
+	goroutineIndex             *goroutineIndex
+	goroutineIndexFn           *ast.FuncDecl
+	allWaitGroupNames          map[string]bool
+	shadowing                  map[types.Object]*ast.BlockStmt
+	names                      varNames
+	goLaunches                 map[ast.Stmt]*ast.GoStmt
+	syncPackages               []string
+
+	// goroutineIndexBuilds counts the indexes goroutines has built, one per
+	// scope analyzed; more would mean a check re-walks the body.
+	goroutineIndexBuilds int
 }
 
 // addCall represents an Add() call with its position and value
 type addCall struct {
-	pos   token.Pos
+	pos token.Pos
+	// at is where diagnostics about the call are reported: the method name
+	// when the WaitGroup is reached through a selector chain.
+	at    token.Pos
 	value int
 	known bool
 }
@@ -57,19 +73,41 @@ func NewChecker(fr *primitives.FunctionResult, errorCollector report.Reporter, c
 		waitGroupNames:             fr.WaitGroups,
 		localWaitGroupNames:        fr.LocalWaitGroups,
 		packageLevelWaitGroupNames: fr.PackageWaitGroups,
+		waitGroupSlices:            fr.WaitGroupSlices,
+		allWaitGroupNames:          fr.WaitGroups,
+		shadowing:                  fr.ShadowingWaitGroups,
+		goLaunches:                 fr.GoLaunches,
+		syncPackages:               fr.SyncPackages,
 		errorCollector:             errorCollector,
 		commentFilter:              cf,
 		// analysis.Pass normally provides TypesInfo; abort detection keeps
 		// conservative fallbacks for direct tests and defensive callers.
 		typesInfo:     pass.TypesInfo,
+		fset:          pass.Fset,
 		functionDecls: buildFunctionDeclMap(pass.Files),
 	}
 }
 
-// AnalyzeFunction analyzes WaitGroup usage in a function
+// AnalyzeFunction analyzes WaitGroup usage in a function. A block that
+// shadows a WaitGroup of the enclosing scope is analyzed on its own.
 func (c *Checker) AnalyzeFunction(fn *ast.FuncDecl) {
+	if len(c.shadowing) == 0 {
+		c.analyzeScope(fn)
+		return
+	}
+	for _, scope := range c.shadowScopes(fn) {
+		c.waitGroupNames = scope.names
+		c.names = varNames{info: c.typesInfo, hidden: scope.hidden}
+		c.analyzeScope(scope.fn)
+	}
+}
+
+// analyzeScope runs every WaitGroup check over fn, a whole function or a
+// block returned by shadowScopes.
+func (c *Checker) analyzeScope(fn *ast.FuncDecl) {
 	c.function = fn
 	c.worker = newWorkerDoneAnalyzer(fn, c.waitGroupNames, c.commentFilter, c.typesInfo, c.errorCollector)
+	c.worker.names = c.names
 	c.iteration = newIterationEstimator(fn, c.typesInfo, c.commentFilter)
 	c.escape = newEscapeAnalyzer(
 		fn,
@@ -79,8 +117,10 @@ func (c *Checker) AnalyzeFunction(fn *ast.FuncDecl) {
 		c.worker.isLocallyCreatedChannel,
 		func(fun ast.Expr) *ast.FuncDecl { return resolveFunctionExpr(fun, c.typesInfo, c.functionDecls) },
 	)
+	c.escape.names = c.names
 	stats := c.collectStats()
 	c.validateUsage(stats)
+	c.reportIndexedWaitGroups()
 }
 
 // Includes generated files so relatedWaitGroupForCall can resolve helpers
@@ -145,17 +185,18 @@ func (c *Checker) handleAddCall(call *ast.CallExpr, wgName string, stats map[str
 			addValue = constantValue
 			addKnown = true
 			if addValue < 0 {
-				c.errorCollector.AddError(call.Pos(), category.AddNegative, "waitgroup '"+wgName+"' has negative Add("+strconv.Itoa(addValue)+")")
+				c.errorCollector.AddError(common.MethodPos(call), category.AddNegative, "waitgroup '"+wgName+"' has negative Add("+strconv.Itoa(addValue)+")")
 			}
 			// Require a compile-time constant: the len(ident) heuristic above
 			// can underestimate when the collection is mutated through a closure.
 			if addValue == 0 && common.IsConstantIntExpr(call.Args[0], c.typesInfo) {
-				c.errorCollector.AddError(call.Pos(), category.AddZero, "waitgroup '"+wgName+"' Add(0) is a no-op")
+				c.errorCollector.AddError(common.MethodPos(call), category.AddZero, "waitgroup '"+wgName+"' Add(0) is a no-op")
 			}
 		}
 	}
 	stats[wgName].addCalls = append(stats[wgName].addCalls, addCall{
 		pos:   call.Pos(),
+		at:    common.MethodPos(call),
 		value: addValue,
 		known: addKnown,
 	})
@@ -204,19 +245,19 @@ func (c *Checker) handleWaitCall(call *ast.CallExpr, wgName string, stats map[st
 }
 
 // isWaitGroupArgument checks if an argument represents a WaitGroup being passed.
-func isWaitGroupArgument(arg ast.Expr, wgName string) bool {
+func isWaitGroupArgument(names varNames, arg ast.Expr, wgName string) bool {
 	if unary, ok := arg.(*ast.UnaryExpr); ok && unary.Op == token.AND {
-		if common.GetVarName(unary.X) == wgName {
+		if names.of(unary.X) == wgName {
 			return true
 		}
 	}
 
-	if common.GetVarName(arg) == wgName {
+	if names.of(arg) == wgName {
 		return true
 	}
 
 	if sel, ok := arg.(*ast.SelectorExpr); ok {
-		if common.GetVarName(sel.X) == wgName {
+		if names.of(sel.X) == wgName {
 			methodName := sel.Sel.Name
 			if methodName == "Done" || methodName == "Add" || methodName == "Wait" {
 				return true
@@ -226,7 +267,7 @@ func isWaitGroupArgument(arg ast.Expr, wgName string) bool {
 
 	if call, ok := arg.(*ast.CallExpr); ok {
 		if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
-			if common.GetVarName(sel.X) == wgName {
+			if names.of(sel.X) == wgName {
 				return true
 			}
 		}
@@ -283,7 +324,7 @@ func (c *Checker) currentFunctionShadowsPackageLevelWaitGroup(wgName string) boo
 
 	for _, field := range c.function.Type.Params.List {
 		typ := c.typesInfo.TypeOf(field.Type)
-		if !common.IsWaitGroup(typ) {
+		if !common.IsWaitGroup(typ, c.syncPackages...) {
 			continue
 		}
 		for _, name := range field.Names {
@@ -303,7 +344,7 @@ func (c *Checker) relatedWaitGroupForCall(call *ast.CallExpr, wgName string) (*a
 	}
 
 	if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
-		receiverExprName := common.GetVarName(sel.X)
+		receiverExprName := c.names.of(sel.X)
 		if receiverExprName != "" && receiverExprName != "?" {
 			prefix := receiverExprName + "."
 			if strings.HasPrefix(wgName, prefix) {
@@ -346,8 +387,8 @@ func (c *Checker) relatedWaitGroupForCall(call *ast.CallExpr, wgName string) (*a
 		}
 
 		for i := 0; i < fieldArity && argIndex < len(call.Args); i++ {
-			if !isWaitGroupArgument(call.Args[argIndex], wgName) {
-				if calleeWGName, ok := calleeWaitGroupNameForArg(call.Args[argIndex], wgName, field, i); ok {
+			if !isWaitGroupArgument(c.names, call.Args[argIndex], wgName) {
+				if calleeWGName, ok := calleeWaitGroupNameForArg(c.names, call.Args[argIndex], wgName, field, i); ok {
 					return fn, calleeWGName, true
 				}
 				argIndex++
@@ -454,8 +495,8 @@ func functionBodyUsesWaitGroupName(body *ast.BlockStmt, wgName string) bool {
 	return found
 }
 
-func calleeWaitGroupNameForArg(arg ast.Expr, wgName string, field *ast.Field, fieldIndex int) (string, bool) {
-	argName := common.GetVarName(arg)
+func calleeWaitGroupNameForArg(names varNames, arg ast.Expr, wgName string, field *ast.Field, fieldIndex int) (string, bool) {
+	argName := names.of(arg)
 	if argName == "" || argName == "?" {
 		return "", false
 	}
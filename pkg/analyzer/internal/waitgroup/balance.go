@@ -6,29 +6,50 @@ import (
 	"go/types"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/commentfilter"
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/report"
+	"golang.org/x/tools/go/analysis"
 )
 
+// Values accepted by AddAttribution.
+const (
+	AttributeFirst = "first"
+	AttributeLast  = "last"
+)
+
+// AddAttribution selects which Add an unmatched-Add report points at when a
+// WaitGroup has several Adds but too few Dones: "first" (the default) reports
+// the first Add the Dones, matched in source order, cannot cover; "last"
+// reports the latest Adds until the shortfall is accounted for. It is bound
+// to the umbrella analyzer's -add-attribution flag.
+var AddAttribution = AttributeFirst
+
 type balanceValidatorConfig struct {
 	function                     *ast.FuncDecl
 	waitGroupNames               map[string]bool
 	localWaitGroupNames          map[string]bool
+	names                        varNames
 	commentFilter                *commentfilter.CommentFilter
 	reporter                     report.Reporter
 	typesInfo                    *types.Info
+	syncPackages                 []string
 	escape                       *escapeAnalyzer
 	isInGoroutine                inGoroutineChecker
 	isNodeInGoroutine            func(ast.Node) bool
+	goroutines                   func() *goroutineIndex
 	callInvokesDone              doneCallChecker
 	goroutineDoneInfo            goroutineDoneAnalyzer
 	isSimpleDeferDone            deferDoneDetector
 	findRelatedAddCall           func(*ast.GoStmt, string) token.Pos
+	missingDoneFix               func(addCall, string) []analysis.SuggestedFix
 	hasUnreachableDone           func(*ast.BlockStmt, string) bool
 	waitInEarlyExitBranch        func(token.Pos) bool
+	waitUnreachable              func(token.Pos) bool
+	callAborts                   func(*ast.CallExpr) bool
 	estimateForIterations        func(*ast.ForStmt) int
 	estimateForIterationsKnown   func(*ast.ForStmt) (int, bool)
 	estimateRangeIterations      func(*ast.RangeStmt) int
@@ -39,12 +60,26 @@ type balanceValidatorConfig struct {
 // with releases (Done/defer Done) and waits in the current function.
 type balanceValidator struct {
 	balanceValidatorConfig
+	index *goroutineIndex
 }
 
 func newBalanceValidator(config balanceValidatorConfig) *balanceValidator {
 	return &balanceValidator{balanceValidatorConfig: config}
 }
 
+// goroutineIndex returns the checker's goroutine index, or one built from
+// the function body when the validator was configured without it.
+func (b *balanceValidator) goroutineIndex() *goroutineIndex {
+	if b.index == nil {
+		if b.goroutines != nil {
+			b.index = b.goroutines()
+		} else {
+			b.index = newGoroutineIndex(b.function.Body, nil)
+		}
+	}
+	return b.index
+}
+
 func (b *balanceValidator) validateBalance(wgName string, stats *Stats) {
 	// Count Done calls from main flow (not in goroutines)
 	mainFlowDoneCount := b.countMainFlowDoneCalls(wgName)
@@ -70,7 +105,9 @@ func (b *balanceValidator) validateBalance(wgName string, stats *Stats) {
 		// structurally orphaned. Claiming "Add without corresponding Done" there is
 		// misleading; the "Done is not guaranteed on every path" concern is owned by
 		// the more precise deferred-Done and cancellation checks. Suppress here.
-		if !b.hasUnguaranteedGoroutineDone(wgName) {
+		// A literal Add that no count of Done calls can reach is reported as
+		// such instead, whichever the guarantees.
+		if !b.reportAddExceedsGoroutines(wgName, stats) && !b.hasUnguaranteedGoroutineDone(wgName) {
 			b.reportUnmatchedAdds(wgName, stats, totalDone)
 		}
 	}
@@ -169,22 +206,12 @@ func (b *balanceValidator) hasUnguaranteedGoroutineDone(wgName string) bool {
 	if b.function == nil || b.function.Body == nil {
 		return false
 	}
-	found := false
-	ast.Inspect(b.function.Body, func(n ast.Node) bool {
-		if found {
-			return false
-		}
-		goStmt, ok := n.(*ast.GoStmt)
-		if !ok {
-			return true
-		}
+	for _, goStmt := range b.goroutineIndex().goStmts {
 		if info, related := b.goroutineDoneInfo(goStmt, wgName); related && info.hasAnyDone && !info.hasGuaranteedDone {
-			found = true
-			return false
+			return true
 		}
-		return true
-	})
-	return found
+	}
+	return false
 }
 
 // checkWaitGroupBalance validates that Add and Done calls are properly balanced
@@ -252,7 +279,7 @@ func (b *balanceValidator) countGuaranteedDoneInStatements(stmts []ast.Stmt, wgN
 				relatedAdd := b.findRelatedAddCall(s, wgName)
 				if relatedAdd != token.NoPos {
 					b.reporter.AddError(relatedAdd, category.AddWithoutDone,
-						"waitgroup '"+wgName+"' has Add without corresponding Done")
+						report.FormatMessage("waitgroup.add_without_done", wgName))
 				}
 			}
 
@@ -313,12 +340,40 @@ func (b *balanceValidator) countGuaranteedDoneInStatements(stmts []ast.Stmt, wgN
 	return count
 }
 
-// reportUnmatchedAdds reports Add calls that don't have corresponding Done calls
+// reportUnmatchedAdds reports Add calls that don't have corresponding Done
+// calls. Dones are matched against Adds in source order by default, so the
+// first Add they cannot cover is reported; with AddAttribution set to "last"
+// the shortfall is pinned on the latest Adds instead. When several Adds feed
+// the counter the message states the totals and which strategy was used.
 func (b *balanceValidator) reportUnmatchedAdds(wgName string, stats *Stats, totalExpectedDone int) {
 	sort.Slice(stats.addCalls, func(i, j int) bool {
 		return stats.addCalls[i].pos < stats.addCalls[j].pos
 	})
 
+	message := report.FormatMessage("waitgroup.add_without_done", wgName)
+	if len(stats.addCalls) > 1 {
+		id := "waitgroup.add_without_done_first"
+		if AddAttribution == AttributeLast {
+			id = "waitgroup.add_without_done_last"
+		}
+		message = report.FormatMessage(id, wgName, strconv.Itoa(stats.totalAdd), strconv.Itoa(totalExpectedDone))
+	}
+
+	if AddAttribution == AttributeLast {
+		deficit := stats.totalAdd - totalExpectedDone
+		for _, addCall := range slices.Backward(stats.addCalls) {
+			if deficit <= 0 {
+				break
+			}
+			if !addCall.known && b.addCoveredByVariableDoneLoop(addCall.pos, wgName) {
+				continue
+			}
+			b.reportAddWithoutDone(addCall, wgName, message)
+			deficit -= addCall.value
+		}
+		return
+	}
+
 	remainingDone := totalExpectedDone
 	for _, addCall := range stats.addCalls {
 		if remainingDone >= addCall.value {
@@ -326,9 +381,21 @@ func (b *balanceValidator) reportUnmatchedAdds(wgName string, stats *Stats, tota
 		} else if !addCall.known && b.addCoveredByVariableDoneLoop(addCall.pos, wgName) {
 			continue
 		} else {
-			b.reporter.AddError(addCall.pos, category.AddWithoutDone, "waitgroup '"+wgName+"' has Add without corresponding Done")
+			b.reportAddWithoutDone(addCall, wgName, message)
+		}
+	}
+}
+
+// reportAddWithoutDone reports an unmatched Add, attaching a fix that defers
+// Done in the goroutine it starts when the reporter can carry one.
+func (b *balanceValidator) reportAddWithoutDone(add addCall, wgName, message string) {
+	if fr, ok := b.reporter.(report.FixReporter); ok && b.missingDoneFix != nil {
+		if fixes := b.missingDoneFix(add, wgName); fixes != nil {
+			fr.AddErrorWithFixes(add.at, category.AddWithoutDone, message, fixes)
+			return
 		}
 	}
+	b.reporter.AddError(add.at, category.AddWithoutDone, message)
 }
 
 func (b *balanceValidator) addCoveredByVariableDoneLoop(addPos token.Pos, wgName string) bool {
@@ -500,6 +567,6 @@ func (b *balanceValidator) reportExcessDones(wgName string, stats *Stats, totalE
 	startIndex := len(mainFlowDoneCalls) - excessCount
 
 	for i := startIndex; i < len(mainFlowDoneCalls) && i >= 0; i++ {
-		b.reporter.AddError(mainFlowDoneCalls[i], category.DoneWithoutAdd, "waitgroup '"+wgName+"' has Done without corresponding Add")
+		b.reporter.AddError(mainFlowDoneCalls[i], category.DoneWithoutAdd, report.FormatMessage("waitgroup.done_without_add", wgName))
 	}
 }
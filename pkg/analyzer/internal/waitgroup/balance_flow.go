@@ -3,17 +3,25 @@ package waitgroup
 import (
 	"go/ast"
 	"go/token"
+	"go/types"
+	"math"
+	"sort"
 	"strings"
 
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/report"
+	"golang.org/x/tools/go/analysis"
 )
 
-// checkAddAfterWait detects Add calls that occur after Wait calls
+// checkAddAfterWait detects Add calls that occur after Wait calls. Both
+// paths visit each Add once per Wait before it, so the positions already
+// reported are shared between them and each Add or Go is reported once.
 func (b *balanceValidator) checkAddAfterWait(stats map[string]*Stats) {
+	reported := make(map[token.Pos]bool)
 	for wgName, st := range stats {
-		b.checkAddAfterWaitInGoroutines(wgName, st)
-		b.checkAddAfterWaitInMainFlow(wgName, st)
+		b.checkAddAfterWaitInGoroutines(wgName, st, reported)
+		b.checkAddAfterWaitInMainFlow(wgName, st, reported)
 	}
 }
 
@@ -24,12 +32,24 @@ func (b *balanceValidator) checkWaitBeforeDoneSameGoroutine(stats map[string]*St
 				continue
 			}
 			if b.pendingMainFlowAddsBeforeWait(st, waitPos) > 0 && b.hasMainFlowReleaseAfterWait(st, waitPos) {
-				b.reporter.AddError(waitPos, category.WaitDeadlock, "waitgroup '"+wgName+"' waits with pending Add in the same goroutine")
+				b.reporter.AddError(waitPos, category.WaitDeadlock, "waitgroup '"+wgName+"' waits with pending Add in the same goroutine",
+					b.mainFlowAddsBefore(wgName, st, waitPos)...)
 			}
 		}
 	}
 }
 
+// mainFlowAddsBefore points at the main-flow Add calls preceding waitPos.
+func (b *balanceValidator) mainFlowAddsBefore(wgName string, st *Stats, waitPos token.Pos) []analysis.RelatedInformation {
+	var related []analysis.RelatedInformation
+	for _, add := range st.addCalls {
+		if add.pos < waitPos && add.value > 0 && b.isInMainFunctionFlow(add.pos) {
+			related = append(related, report.Related(add.pos, "waitgroup '"+wgName+"' Add called here"))
+		}
+	}
+	return related
+}
+
 func (b *balanceValidator) pendingMainFlowAddsBeforeWait(st *Stats, waitPos token.Pos) int {
 	pending := 0
 	for _, add := range st.addCalls {
@@ -67,22 +87,7 @@ func (b *balanceValidator) isInMainFunctionFlow(pos token.Pos) bool {
 }
 
 func (b *balanceValidator) isInNestedFunctionLiteral(pos token.Pos) bool {
-	found := false
-	ast.Inspect(b.function.Body, func(n ast.Node) bool {
-		if found {
-			return false
-		}
-		fnLit, ok := n.(*ast.FuncLit)
-		if !ok {
-			return true
-		}
-		if nodeContainsPos(fnLit.Body, pos) {
-			found = true
-			return false
-		}
-		return true
-	})
-	return found
+	return b.goroutineIndex().inFunctionLiteral(pos)
 }
 
 func (b *balanceValidator) hasRelatedGoroutineBeforeWait(wgName string, waitPos token.Pos) bool {
@@ -106,21 +111,18 @@ func (b *balanceValidator) hasRelatedGoroutineBeforeWait(wgName string, waitPos
 }
 
 // checkAddAfterWaitInGoroutines checks for Add after Wait in goroutines
-func (b *balanceValidator) checkAddAfterWaitInGoroutines(wgName string, st *Stats) {
+func (b *balanceValidator) checkAddAfterWaitInGoroutines(wgName string, st *Stats, reported map[token.Pos]bool) {
 	for _, waitPos := range st.waitCalls {
-		ast.Inspect(b.function.Body, func(n ast.Node) bool {
-			if goStmt, ok := n.(*ast.GoStmt); ok {
-				if goStmt.Pos() > waitPos {
-					b.checkAddInGoroutine(goStmt, wgName)
-				}
+		for _, goStmt := range b.goroutineIndex().goStmts {
+			if goStmt.Pos() > waitPos {
+				b.checkAddInGoroutine(goStmt, wgName, reported)
 			}
-			return true
-		})
+		}
 	}
 }
 
 // checkAddInGoroutine checks for Add calls within a specific goroutine
-func (b *balanceValidator) checkAddInGoroutine(goStmt *ast.GoStmt, wgName string) {
+func (b *balanceValidator) checkAddInGoroutine(goStmt *ast.GoStmt, wgName string, reported map[token.Pos]bool) {
 	if fnLit, ok := goStmt.Call.Fun.(*ast.FuncLit); ok {
 		ast.Inspect(fnLit.Body, func(inner ast.Node) bool {
 			if call, ok := inner.(*ast.CallExpr); ok {
@@ -129,15 +131,17 @@ func (b *balanceValidator) checkAddInGoroutine(goStmt *ast.GoStmt, wgName string
 				}
 
 				if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
-					if common.GetVarName(sel.X) != wgName {
+					if b.names.of(sel.X) != wgName || reported[call.Pos()] {
 						return true
 					}
 
 					switch sel.Sel.Name {
 					case "Add":
-						b.reporter.AddError(call.Pos(), category.AddAfterWait, "waitgroup '"+wgName+"' Add called after Wait")
+						reported[call.Pos()] = true
+						b.reporter.AddError(common.MethodPos(call), category.AddAfterWait, "waitgroup '"+wgName+"' Add called after Wait")
 					case "Go":
-						b.reporter.AddError(call.Pos(), category.GoAfterWait, "waitgroup '"+wgName+"' Go called after Wait")
+						reported[call.Pos()] = true
+						b.reporter.AddError(common.MethodPos(call), category.GoAfterWait, "waitgroup '"+wgName+"' Go called after Wait")
 					}
 				}
 			}
@@ -147,7 +151,7 @@ func (b *balanceValidator) checkAddInGoroutine(goStmt *ast.GoStmt, wgName string
 }
 
 // checkAddAfterWaitInMainFlow detects Add calls in the main execution flow that occur after Wait
-func (b *balanceValidator) checkAddAfterWaitInMainFlow(wgName string, st *Stats) {
+func (b *balanceValidator) checkAddAfterWaitInMainFlow(wgName string, st *Stats, reported map[token.Pos]bool) {
 	if strings.Contains(wgName, ".") {
 		return
 	}
@@ -194,16 +198,20 @@ func (b *balanceValidator) checkAddAfterWaitInMainFlow(wgName string, st *Stats)
 		if !hasOperationsBefore {
 			for _, add := range st.addCalls {
 				// Only report Add calls in main flow after this empty Wait
-				if add.pos > wait && !b.isInGoroutine(add.pos) {
+				if add.pos > wait && !b.isInGoroutine(add.pos) && !reported[add.pos] {
 					if add.value == 1 && b.hasDeferredDoneAfter(wgName, add.pos) {
 						continue
 					}
-					b.reporter.AddError(add.pos, category.AddAfterWait, "waitgroup '"+wgName+"' Add called after Wait")
+					reported[add.pos] = true
+					b.reporter.AddError(add.at, category.AddAfterWait, "waitgroup '"+wgName+"' Add called after Wait",
+						report.Related(wait, "waitgroup '"+wgName+"' Wait called here"))
 				}
 			}
 			for _, goPos := range st.goCalls {
-				if goPos > wait && !b.isInGoroutine(goPos) {
-					b.reporter.AddError(goPos, category.GoAfterWait, "waitgroup '"+wgName+"' Go called after Wait")
+				if goPos > wait && !b.isInGoroutine(goPos) && !reported[goPos] {
+					reported[goPos] = true
+					b.reporter.AddError(goPos, category.GoAfterWait, "waitgroup '"+wgName+"' Go called after Wait",
+						report.Related(wait, "waitgroup '"+wgName+"' Wait called here"))
 				}
 			}
 		}
@@ -226,7 +234,7 @@ func (b *balanceValidator) goroutineOnlyWaitsOnWaitGroup(goStmt *ast.GoStmt, wgN
 		}
 
 		sel, ok := call.Fun.(*ast.SelectorExpr)
-		if !ok || common.GetVarName(sel.X) != wgName {
+		if !ok || b.names.of(sel.X) != wgName {
 			return true
 		}
 
@@ -268,3 +276,127 @@ func (b *balanceValidator) hasDeferredDoneAfter(wgName string, after token.Pos)
 
 	return found
 }
+
+// flowEvent is one main-flow Add, related go statement, or Wait, ordered by
+// position for checkAddAfterGoroutineStart.
+type flowEvent struct {
+	pos   token.Pos
+	kind  string // "Add", "go" or "Wait"
+	value int    // Add delta; unknown deltas count as unbounded
+}
+
+// checkAddAfterGoroutineStart flags a main-flow Add that follows a go
+// statement whose goroutine always calls Done on the same WaitGroup but was not
+// covered by an earlier Add. The goroutine may finish before the Add lands.
+// Walking the events in source order keeps `wg.Add(2); go a(); go b()` and
+// `for { wg.Add(1); go f() }` quiet while flagging `go f(); wg.Add(1)`.
+func (b *balanceValidator) checkAddAfterGoroutineStart(stats map[string]*Stats) {
+	for wgName, st := range stats {
+		events := b.addGoWaitEvents(wgName, st)
+		lastWait := token.NoPos
+		for _, ev := range events {
+			if ev.kind == "Wait" {
+				lastWait = ev.pos
+			}
+		}
+
+		pending := 0
+		var uncounted []token.Pos
+		for _, ev := range events {
+			switch ev.kind {
+			case "go":
+				if pending > 0 {
+					pending--
+				} else {
+					uncounted = append(uncounted, ev.pos)
+				}
+			case "Add":
+				if len(uncounted) > 0 && ev.pos < lastWait {
+					related := make([]analysis.RelatedInformation, 0, len(uncounted))
+					for _, goPos := range uncounted {
+						related = append(related, report.Related(goPos, "goroutine started here"))
+					}
+					b.reporter.AddError(ev.pos, category.AddAfterGoroutineStart,
+						"waitgroup '"+wgName+"' Add after goroutine started (race)", related...)
+				}
+				pending = max(pending+ev.value-len(uncounted), 0)
+				uncounted = nil
+			case "Wait":
+				pending = 0
+				uncounted = nil
+			}
+		}
+	}
+}
+
+// addGoWaitEvents collects wgName's main-flow Adds and Waits and the main-flow
+// go statements whose goroutine always calls Done on it without first
+// waiting on a channel, sorted by position.
+func (b *balanceValidator) addGoWaitEvents(wgName string, st *Stats) []flowEvent {
+	var events []flowEvent
+	for _, add := range st.addCalls {
+		if (add.known && add.value <= 0) || !b.isInMainFunctionFlow(add.pos) {
+			continue
+		}
+		value := add.value
+		if !add.known {
+			value = math.MaxInt32
+		}
+		events = append(events, flowEvent{pos: add.pos, kind: "Add", value: value})
+	}
+	for _, waitPos := range st.waitCalls {
+		if b.isInMainFunctionFlow(waitPos) {
+			events = append(events, flowEvent{pos: waitPos, kind: "Wait"})
+		}
+	}
+	ast.Inspect(b.function.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.GoStmt:
+			if b.commentFilter.ShouldSkipStatement(node) {
+				return false
+			}
+			// A goroutine that waits on a channel (e.g. context
+			// cancellation) may be ordered after the Add by the send, so only
+			// goroutines that can run to Done unprompted count.
+			if b.goroutineReceives(node) {
+				return false
+			}
+			if doneInfo, related := b.goroutineDoneInfo(node, wgName); related && doneInfo.hasGuaranteedDone {
+				events = append(events, flowEvent{pos: node.Pos(), kind: "go"})
+			}
+			return false
+		}
+		return true
+	})
+	sort.Slice(events, func(i, j int) bool { return events[i].pos < events[j].pos })
+	return events
+}
+
+// goroutineReceives reports whether the goroutine literal started by goStmt
+// receives from a channel, selects, or ranges over a channel.
+func (b *balanceValidator) goroutineReceives(goStmt *ast.GoStmt) bool {
+	fnLit, ok := goStmt.Call.Fun.(*ast.FuncLit)
+	if !ok {
+		return false
+	}
+	found := false
+	ast.Inspect(fnLit.Body, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		switch node := n.(type) {
+		case *ast.SelectStmt:
+			found = true
+		case *ast.UnaryExpr:
+			found = node.Op == token.ARROW
+		case *ast.RangeStmt:
+			if typ := b.typesInfo.TypeOf(node.X); typ != nil {
+				_, found = typ.Underlying().(*types.Chan)
+			}
+		}
+		return !found
+	})
+	return found
+}
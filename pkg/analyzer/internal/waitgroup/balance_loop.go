@@ -7,13 +7,17 @@ import (
 
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/report"
 )
 
 // checkLoopAddDoneBalance checks for Add/Done balance issues in loops
 func (b *balanceValidator) checkLoopAddDoneBalance() {
 	ast.Inspect(b.function.Body, func(n ast.Node) bool {
-		if forStmt, ok := n.(*ast.ForStmt); ok {
-			b.analyzeLoopBalance(forStmt)
+		switch loop := n.(type) {
+		case *ast.ForStmt:
+			b.analyzeLoopBalance(loop)
+		case *ast.RangeStmt:
+			b.checkVariableAddInLoop(loop.Body)
 		}
 		return true
 	})
@@ -26,16 +30,32 @@ type loopAnalysis struct {
 	conditionalDones   int
 }
 
-// analyzeLoopBalance analyzes Add/Done balance within a single loop
+// analyzeLoopBalance analyzes Add/Done balance within a single loop. A
+// goroutine started on every iteration that is guaranteed to call Done, such
+// as one deferring it or releasing the group in both branches of an if,
+// counts as an unconditional Done; the Done statements inside it are still
+// tallied where they stand.
 func (b *balanceValidator) analyzeLoopBalance(forStmt *ast.ForStmt) {
 	loopStats := make(map[string]*loopAnalysis)
 
 	ast.Inspect(forStmt.Body, func(n ast.Node) bool {
 		switch node := n.(type) {
+		case *ast.GoStmt:
+			if b.isInConditional(node, forStmt.Body) {
+				return true
+			}
+			for wgName := range b.waitGroupNames {
+				if info, related := b.goroutineDoneInfo(node, wgName); related && info.hasGuaranteedDone {
+					if loopStats[wgName] == nil {
+						loopStats[wgName] = &loopAnalysis{}
+					}
+					loopStats[wgName].unconditionalDones++
+				}
+			}
 		case *ast.ExprStmt:
 			if call, ok := node.X.(*ast.CallExpr); ok {
 				if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
-					wgName := common.GetVarName(sel.X)
+					wgName := b.names.of(sel.X)
 					if b.waitGroupNames[wgName] {
 						if loopStats[wgName] == nil {
 							loopStats[wgName] = &loopAnalysis{}
@@ -43,7 +63,7 @@ func (b *balanceValidator) analyzeLoopBalance(forStmt *ast.ForStmt) {
 
 						switch sel.Sel.Name {
 						case "Add":
-							loopStats[wgName].addCalls = append(loopStats[wgName].addCalls, call.Pos())
+							loopStats[wgName].addCalls = append(loopStats[wgName].addCalls, common.MethodPos(call))
 						case "Done":
 							if b.isInConditional(call, forStmt.Body) {
 								loopStats[wgName].conditionalDones++
@@ -63,10 +83,60 @@ func (b *balanceValidator) analyzeLoopBalance(forStmt *ast.ForStmt) {
 			if stats.unconditionalDones == 0 && stats.conditionalDones > 0 {
 				for _, addPos := range stats.addCalls {
 					b.reporter.AddError(addPos, category.AddWithoutDone,
-						"waitgroup '"+wgName+"' has Add without corresponding Done")
+						report.FormatMessage("waitgroup.add_without_done", wgName))
+				}
+			}
+		}
+	}
+
+	b.checkVariableAddInLoop(forStmt.Body)
+}
+
+// checkVariableAddInLoop flags wg.Add(x) with a non-constant x (GCL2021) in a
+// loop body that also releases the WaitGroup a fixed number of times per
+// iteration, such as wg.Add(i) next to one goroutine with defer wg.Done().
+// Calls inside nested loops belong to those loops and are left to their own
+// visit, so wg.Add(len(batch)) paired with a Done per batch item is not
+// flagged. It is a heuristic, so the check is opt-in.
+func (b *balanceValidator) checkVariableAddInLoop(body *ast.BlockStmt) {
+	if body == nil {
+		return
+	}
+	variableAdds := make(map[string][]token.Pos)
+	dones := make(map[string]int)
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.ForStmt, *ast.RangeStmt:
+			return false
+		case *ast.CallExpr:
+			sel, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			wgName := b.names.of(sel.X)
+			if !b.waitGroupNames[wgName] {
+				return true
+			}
+			switch sel.Sel.Name {
+			case "Add":
+				if len(node.Args) == 1 && !common.IsConstantIntExpr(node.Args[0], b.typesInfo) {
+					variableAdds[wgName] = append(variableAdds[wgName], node.Pos())
 				}
+			case "Done":
+				dones[wgName]++
 			}
 		}
+		return true
+	})
+
+	for wgName, positions := range variableAdds {
+		if dones[wgName] == 0 {
+			continue
+		}
+		for _, pos := range positions {
+			b.reporter.AddError(pos, category.VariableAddInLoop,
+				report.FormatMessage("waitgroup.variable_add_in_loop", wgName))
+		}
 	}
 }
 
@@ -159,7 +229,7 @@ func (b *balanceValidator) checkLiteralAddLoopGoroutineMismatch(stats map[string
 		if launched <= 1 || launched == positiveAdds[0].value {
 			continue
 		}
-		b.reporter.AddError(positiveAdds[0].pos, category.AddLoopCountMismatch,
+		b.reporter.AddError(positiveAdds[0].at, category.AddLoopCountMismatch,
 			"waitgroup '"+wgName+"' Add count "+strconv.Itoa(positiveAdds[0].value)+" does not match "+strconv.Itoa(launched)+" goroutines launched")
 	}
 }
@@ -46,6 +46,7 @@ func newTestBalanceValidator(t *testing.T, src string) (*balanceValidator, *ast.
 		findRelatedAddCall:    testFindRelatedAddCall(fn),
 		hasUnreachableDone:    func(*ast.BlockStmt, string) bool { return false },
 		waitInEarlyExitBranch: func(token.Pos) bool { return false },
+		waitUnreachable:       func(token.Pos) bool { return false },
 		estimateForIterations: func(*ast.ForStmt) int { return 1 },
 		estimateForIterationsKnown: func(*ast.ForStmt) (int, bool) {
 			return 0, false
@@ -150,7 +151,7 @@ func f() {
 }`)
 	stats := map[string]*Stats{
 		"wg": {
-			addCalls: []addCall{{pos: methodCallPos(fn, "wg", "Add", 0), value: 1, known: true}},
+			addCalls: []addCall{{pos: methodCallPos(fn, "wg", "Add", 0), at: methodCallPos(fn, "wg", "Add", 0), value: 1, known: true}},
 			totalAdd: 1,
 		},
 	}
@@ -176,7 +177,7 @@ func f() {
 }`)
 	stats := map[string]*Stats{
 		"wg": {
-			addCalls: []addCall{{pos: methodCallPos(fn, "wg", "Add", 0), value: 1, known: true}},
+			addCalls: []addCall{{pos: methodCallPos(fn, "wg", "Add", 0), at: methodCallPos(fn, "wg", "Add", 0), value: 1, known: true}},
 			totalAdd: 1,
 		},
 	}
@@ -208,7 +209,7 @@ func f() {
 	}
 	stats := map[string]*Stats{
 		"wg": {
-			addCalls: []addCall{{pos: methodCallPos(fn, "wg", "Add", 0), value: 1, known: true}},
+			addCalls: []addCall{{pos: methodCallPos(fn, "wg", "Add", 0), at: methodCallPos(fn, "wg", "Add", 0), value: 1, known: true}},
 			totalAdd: 1,
 		},
 	}
@@ -234,7 +235,7 @@ func f() {
 }`)
 	stats := map[string]*Stats{
 		"wg": {
-			addCalls: []addCall{{pos: methodCallPos(fn, "wg", "Add", 0), value: 2, known: true}},
+			addCalls: []addCall{{pos: methodCallPos(fn, "wg", "Add", 0), at: methodCallPos(fn, "wg", "Add", 0), value: 2, known: true}},
 			totalAdd: 2,
 		},
 	}
@@ -263,7 +264,7 @@ func f() {
 	balance.estimateForIterations = func(*ast.ForStmt) int { return 3 }
 	stats := map[string]*Stats{
 		"wg": {
-			addCalls: []addCall{{pos: methodCallPos(fn, "wg", "Add", 0), value: 1, known: true}},
+			addCalls: []addCall{{pos: methodCallPos(fn, "wg", "Add", 0), at: methodCallPos(fn, "wg", "Add", 0), value: 1, known: true}},
 			totalAdd: 1,
 		},
 	}
@@ -287,7 +288,7 @@ func f() {
 }`)
 	stats := map[string]*Stats{
 		"wg": {
-			addCalls:  []addCall{{pos: methodCallPos(fn, "wg", "Add", 0), value: 1, known: true}},
+			addCalls:  []addCall{{pos: methodCallPos(fn, "wg", "Add", 0), at: methodCallPos(fn, "wg", "Add", 0), value: 1, known: true}},
 			waitCalls: []token.Pos{methodCallPos(fn, "wg", "Wait", 0)},
 			totalAdd:  1,
 		},
@@ -303,3 +304,46 @@ func f() {
 		t.Fatalf("category = %q, want %q", reporter.calls[0].cat, category.AddAfterWait)
 	}
 }
+
+// Each Add after Wait must be reported once, however many empty Waits come
+// before it and whichever path (main flow or goroutine) finds it first.
+func TestBalanceValidator_AddAfterWaitReportedOnce(t *testing.T) {
+	balance, fn := newTestBalanceValidator(t, `package p
+func f() {
+	wg.Wait()
+	wg.Wait()
+	wg.Add(1)
+	go func() {
+		wg.Add(1)
+	}()
+}`)
+	stats := map[string]*Stats{
+		"wg": {
+			addCalls: []addCall{
+				{pos: methodCallPos(fn, "wg", "Add", 0), at: methodCallPos(fn, "wg", "Add", 0), value: 1, known: true},
+				{pos: methodCallPos(fn, "wg", "Add", 1), at: methodCallPos(fn, "wg", "Add", 1), value: 1, known: true},
+			},
+			waitCalls: []token.Pos{methodCallPos(fn, "wg", "Wait", 0), methodCallPos(fn, "wg", "Wait", 1)},
+			totalAdd:  2,
+		},
+	}
+
+	balance.checkAddAfterWait(stats)
+
+	reporter := balanceReporter(balance)
+	perPos := make(map[token.Pos]int)
+	for _, call := range reporter.calls {
+		if call.cat != category.AddAfterWait {
+			t.Fatalf("category = %q, want %q", call.cat, category.AddAfterWait)
+		}
+		perPos[call.pos]++
+	}
+	if len(perPos) != 2 {
+		t.Fatalf("expected both Adds reported, got %d positions", len(perPos))
+	}
+	for pos, n := range perPos {
+		if n != 1 {
+			t.Errorf("Add at %d reported %d times, want once", pos, n)
+		}
+	}
+}
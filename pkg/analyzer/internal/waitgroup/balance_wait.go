@@ -8,6 +8,8 @@ import (
 
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/report"
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/ast/astutil"
 )
 
@@ -28,11 +30,173 @@ func (b *balanceValidator) checkWaitWithoutAdd(stats map[string]*Stats) {
 					b.hasAddInLocalClosure(targetObj, waitPos)) {
 				continue
 			}
-			b.reporter.AddError(waitPos, category.WaitWithoutAdd, "waitgroup '"+wgName+"' Wait called without any Add")
+			b.reporter.AddError(waitPos, category.WaitWithoutAdd, report.FormatMessage("waitgroup.wait_without_add", wgName))
 		}
 	}
 }
 
+// checkAddWithoutWait flags a local WaitGroup whose Add is paired with a
+// worker goroutine calling Done, but which the function never waits on: the
+// function returns while the workers are still running. A WaitGroup handed to
+// another function (or returned, stored, sent) may be waited on there, so the
+// escape leniency used by the balance check applies here too.
+func (b *balanceValidator) checkAddWithoutWait(stats map[string]*Stats) {
+	for wgName, st := range stats {
+		if !b.localWaitGroupNames[wgName] || strings.Contains(wgName, ".") ||
+			len(st.addCalls) == 0 || len(st.waitCalls) > 0 {
+			continue
+		}
+		if b.referencesWaitMethod(wgName) || !b.hasGoroutineDone(wgName) {
+			continue
+		}
+		if b.escape != nil && b.escape.isWaitGroupPassedToOtherFunctions(wgName) {
+			continue
+		}
+		for _, add := range st.addCalls {
+			if b.isInGoroutine(add.pos) {
+				continue
+			}
+			b.reporter.AddError(add.at, category.AddWithoutWait, report.FormatMessage("waitgroup.add_without_wait", wgName))
+		}
+	}
+}
+
+// referencesWaitMethod reports whether wgName.Wait appears anywhere in the
+// function, including deferred calls, closures and method values that the
+// collected waitCalls do not cover.
+func (b *balanceValidator) referencesWaitMethod(wgName string) bool {
+	found := false
+	ast.Inspect(b.function.Body, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		sel, ok := n.(*ast.SelectorExpr)
+		if ok && sel.Sel.Name == "Wait" && b.names.of(sel.X) == wgName {
+			found = true
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// hasGoroutineDone reports whether any goroutine launched in the function
+// calls Done on wgName on at least one path.
+func (b *balanceValidator) hasGoroutineDone(wgName string) bool {
+	found := false
+	ast.Inspect(b.function.Body, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		goStmt, ok := n.(*ast.GoStmt)
+		if !ok {
+			return true
+		}
+		if info, related := b.goroutineDoneInfo(goStmt, wgName); related && info.hasAnyDone {
+			found = true
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// checkNoOpMainFlowPair flags a local WaitGroup whose Add and Done calls all
+// sit in the function's own flow with no goroutine touching it (GCL2022,
+// opt-in). The calls cancel out on the same goroutine, so the WaitGroup
+// synchronizes nothing. Unbalanced totals are left to the balance checks.
+func (b *balanceValidator) checkNoOpMainFlowPair(stats map[string]*Stats) {
+	for wgName, st := range stats {
+		if !b.localWaitGroupNames[wgName] || strings.Contains(wgName, ".") || len(st.addCalls) == 0 {
+			continue
+		}
+		dones := append(append([]token.Pos{}, st.doneCalls...), st.deferDoneCalls...)
+		if len(dones) == 0 || !b.allInMainFlow(st.addCalls, dones) || waitInterleaved(st) {
+			continue
+		}
+		added := 0
+		for _, add := range st.addCalls {
+			if !add.known {
+				added = -1
+				break
+			}
+			added += add.value
+		}
+		if added != len(dones) || b.hasRelatedGoroutine(wgName) {
+			continue
+		}
+		if b.escape != nil && b.escape.isWaitGroupPassedToOtherFunctions(wgName) {
+			continue
+		}
+		related := make([]analysis.RelatedInformation, 0, len(dones))
+		for _, done := range dones {
+			related = append(related, report.Related(done, "waitgroup '"+wgName+"' Done called here"))
+		}
+		b.reporter.AddError(st.addCalls[0].at, category.NoOpMainFlowPair,
+			report.FormatMessage("waitgroup.main_flow_add_done", wgName), related...)
+	}
+}
+
+// allInMainFlow reports whether every Add and Done position lies in the
+// function's own flow, outside goroutines and function literals.
+func (b *balanceValidator) allInMainFlow(adds []addCall, dones []token.Pos) bool {
+	for _, add := range adds {
+		if !b.isInMainFunctionFlow(add.pos) {
+			return false
+		}
+	}
+	for _, done := range dones {
+		if !b.isInMainFunctionFlow(done) {
+			return false
+		}
+	}
+	return true
+}
+
+// waitInterleaved reports whether a Wait runs before the Adds and Dones have
+// all completed. Such orderings are Add-after-Wait or same-goroutine
+// deadlocks, which their own checks report.
+func waitInterleaved(st *Stats) bool {
+	for _, wait := range st.waitCalls {
+		if len(st.deferDoneCalls) > 0 {
+			return true
+		}
+		for _, add := range st.addCalls {
+			if wait < add.pos {
+				return true
+			}
+		}
+		for _, done := range st.doneCalls {
+			if wait < done {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasRelatedGoroutine reports whether any goroutine launched in the function
+// references wgName, directly in a literal body or through a called helper.
+func (b *balanceValidator) hasRelatedGoroutine(wgName string) bool {
+	found := false
+	ast.Inspect(b.function.Body, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		goStmt, ok := n.(*ast.GoStmt)
+		if !ok {
+			return true
+		}
+		if goroutineRelatedToWaitGroup(b.names, goStmt, wgName) {
+			found = true
+		} else if _, related := b.goroutineDoneInfo(goStmt, wgName); related {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
 // hasAddInLocalClosure reports whether a WaitGroup has Add called inside a
 // function literal assigned to a local variable. This is intentionally
 // permissive: it does not prove the closure is invoked. Only closures whose
@@ -145,7 +309,7 @@ func (b *balanceValidator) waitGroupReceiverObjectAt(wgName, method string, pos
 			return true
 		}
 		sel, ok := call.Fun.(*ast.SelectorExpr)
-		if !ok || sel.Sel.Name != method || common.GetVarName(sel.X) != wgName {
+		if !ok || sel.Sel.Name != method || b.names.of(sel.X) != wgName {
 			return true
 		}
 		obj = b.receiverObject(sel.X)
@@ -246,34 +410,27 @@ func (b *balanceValidator) isWaitGroupAliasedOrCopiedExpr(expr ast.Expr) bool {
 		}
 		return b.isWaitGroupAliasedOrCopiedExpr(e.X)
 	}
-	return common.IsWaitGroup(b.typesInfo.TypeOf(expr))
+	return common.IsWaitGroup(b.typesInfo.TypeOf(expr), b.syncPackages...)
 }
 
 func (b *balanceValidator) isWaitGroupFieldExpr(expr ast.Expr) bool {
 	_, ok := expr.(*ast.SelectorExpr)
-	return ok && common.IsWaitGroup(b.typesInfo.TypeOf(expr))
+	return ok && common.IsWaitGroup(b.typesInfo.TypeOf(expr), b.syncPackages...)
 }
 
 // checkUnreachableDone checks for Done calls that are unreachable due to early returns
 func (b *balanceValidator) checkUnreachableDone() {
 	for wgName := range b.waitGroupNames {
-		ast.Inspect(b.function.Body, func(n ast.Node) bool {
-			goStmt, ok := n.(*ast.GoStmt)
-			if !ok {
-				return true
+		for _, goStmt := range b.goroutineIndex().goStmts {
+			fnLit, ok := goStmt.Call.Fun.(*ast.FuncLit)
+			if !ok || !b.hasUnreachableDone(fnLit.Body, wgName) {
+				continue
 			}
-
-			if fnLit, ok := goStmt.Call.Fun.(*ast.FuncLit); ok {
-				if b.hasUnreachableDone(fnLit.Body, wgName) {
-					addPos := b.findRelatedAddCall(goStmt, wgName)
-					if addPos != token.NoPos {
-						b.reporter.AddError(addPos, category.AddWithoutDone,
-							"waitgroup '"+wgName+"' has Add without corresponding Done")
-					}
-				}
+			addPos := b.findRelatedAddCall(goStmt, wgName)
+			if addPos != token.NoPos {
+				b.reporter.AddError(addPos, category.AddWithoutDone,
+					report.FormatMessage("waitgroup.add_without_done", wgName))
 			}
-
-			return true
-		})
+		}
 	}
 }
@@ -3,8 +3,6 @@ package waitgroup
 import (
 	"go/ast"
 	"go/token"
-
-	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
 )
 
 // findDeferDoneCalls identifies defer Done calls to avoid counting them as regular Done calls.
@@ -21,7 +19,7 @@ func (c *Checker) findDeferDoneCalls(stats map[string]*Stats) {
 
 		if call, ok := deferStmt.Call.Fun.(*ast.SelectorExpr); ok {
 			if call.Sel.Name == "Done" {
-				wgName := common.GetVarName(call.X)
+				wgName := c.names.of(call.X)
 				if c.waitGroupNames[wgName] {
 					stats[wgName].deferDoneCalls = append(stats[wgName].deferDoneCalls, deferStmt.Call.Pos())
 				}
@@ -46,7 +44,7 @@ func (c *Checker) findDoneInFunctionLiteral(body *ast.BlockStmt, stats map[strin
 			}
 
 			if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Done" {
-				wgName := common.GetVarName(sel.X)
+				wgName := c.names.of(sel.X)
 				if c.waitGroupNames[wgName] {
 					stats[wgName].deferDoneCalls = append(stats[wgName].deferDoneCalls, call.Pos())
 				}
@@ -64,6 +62,12 @@ func (c *Checker) collectCalls(stats map[string]*Stats) {
 
 // traverseWithContext traverses the AST while maintaining context about for loops
 func (c *Checker) traverseWithContext(n ast.Node, forStack []*ast.ForStmt, stats map[string]*Stats, alreadyReported map[token.Pos]bool) {
+	if stmt, ok := n.(ast.Stmt); ok {
+		if launch, ok := c.goLaunches[stmt]; ok {
+			c.handleGoStatement(launch, forStack, stats, alreadyReported)
+			return
+		}
+	}
 	switch node := n.(type) {
 	case *ast.ForStmt:
 		c.handleForStatement(node, forStack, stats, alreadyReported)
@@ -212,7 +216,7 @@ func (c *Checker) handleExpressionStatement(stmt *ast.ExprStmt, stats map[string
 		return
 	}
 
-	wgName := common.GetVarName(sel.X)
+	wgName := c.names.of(sel.X)
 	if !c.waitGroupNames[wgName] {
 		return
 	}
@@ -0,0 +1,159 @@
+package waitgroup
+
+import (
+	"go/ast"
+	"go/token"
+	"slices"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/report"
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkDoneAfterConditionalAdd flags the Done calls that only a
+// conditional Add covers. An Add under an if without an else is skipped when
+// the condition is false, yet every Done outside any branch still runs, so
+// on that path the counter goes negative and Done panics. Dones made by a
+// goroutine the function always launches count as unconditional when the
+// goroutine is guaranteed to reach them. Groups with an Add of unknown value,
+// an Add outside the main flow, an Add or Done in a loop, or a group handed to
+// other code are left to the balance checks.
+func (c *Checker) checkDoneAfterConditionalAdd(stats map[string]*Stats, balance *balanceValidator) {
+	for wgName, st := range stats {
+		if c.escape != nil && c.escape.isWaitGroupPassedToOtherFunctions(wgName) {
+			continue
+		}
+		unconditionalAdd, conditionalAdd := 0, 0
+		var conditionalAdds []token.Pos
+		countable := true
+		for _, add := range st.addCalls {
+			if !add.known || add.value <= 0 || !balance.isInMainFunctionFlow(add.pos) || c.enclosingLoopBody(add.pos) != nil {
+				countable = false
+				break
+			}
+			if !balance.isInBranchingControlFlow(add.pos) {
+				unconditionalAdd += add.value
+				continue
+			}
+			if ifStmt, ok := c.outermostBranch(add.pos).(*ast.IfStmt); !ok || ifStmt.Else != nil {
+				countable = false
+				break
+			}
+			conditionalAdd += add.value
+			conditionalAdds = append(conditionalAdds, add.pos)
+		}
+		if !countable || conditionalAdd == 0 {
+			continue
+		}
+		dones, ok := c.unconditionalDones(wgName, st, balance)
+		// More Dones than every Add together is the excess-Done report's.
+		if !ok || len(dones) <= unconditionalAdd || len(dones) > unconditionalAdd+conditionalAdd {
+			continue
+		}
+		related := make([]analysis.RelatedInformation, 0, len(conditionalAdds))
+		for _, pos := range conditionalAdds {
+			related = append(related, report.Related(pos, "waitgroup '"+wgName+"' Add called conditionally here"))
+		}
+		c.errorCollector.AddError(dones[unconditionalAdd], category.DoneAfterConditionalAdd,
+			"waitgroup '"+wgName+"' Done may exceed Add (negative counter) when Add is conditional", related...)
+	}
+}
+
+// unconditionalDones returns, in source order, the Done calls on wgName that
+// run on every path through the function: main-flow and deferred Dones
+// outside any branch, and the first Done of each goroutine launched outside
+// any branch that is guaranteed to call it. ok is false when a Done sits in a
+// loop, so the number of calls cannot be counted.
+func (c *Checker) unconditionalDones(wgName string, st *Stats, balance *balanceValidator) ([]token.Pos, bool) {
+	var dones []token.Pos
+	for _, pos := range slices.Concat(st.doneCalls, st.deferDoneCalls) {
+		if !balance.isInMainFunctionFlow(pos) {
+			continue
+		}
+		if c.enclosingLoopBody(pos) != nil {
+			return nil, false
+		}
+		if !balance.isInBranchingControlFlow(pos) {
+			dones = append(dones, pos)
+		}
+	}
+	ok := true
+	ast.Inspect(c.function.Body, func(n ast.Node) bool {
+		if !ok {
+			return false
+		}
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.GoStmt:
+			info, related := c.goroutineDoneInfo(node, wgName)
+			if !related || !info.hasAnyDone {
+				return false
+			}
+			if c.enclosingLoopBody(node.Pos()) != nil {
+				ok = false
+				return false
+			}
+			if info.hasGuaranteedDone && !balance.isInBranchingControlFlow(node.Pos()) {
+				dones = append(dones, c.firstDoneIn(node, wgName))
+			}
+			return false
+		}
+		return true
+	})
+	if !ok {
+		return nil, false
+	}
+	slices.Sort(dones)
+	return dones, true
+}
+
+// firstDoneIn returns the position of the first Done on wgName in the body of
+// a goroutine literal, or the go statement itself for a named worker.
+func (c *Checker) firstDoneIn(goStmt *ast.GoStmt, wgName string) token.Pos {
+	lit, ok := goStmt.Call.Fun.(*ast.FuncLit)
+	if !ok {
+		return goStmt.Pos()
+	}
+	pos := goStmt.Pos()
+	ast.Inspect(lit.Body, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok && pos == goStmt.Pos() && c.worker.callInvokesDone(call, wgName) {
+			pos = call.Pos()
+		}
+		return pos == goStmt.Pos()
+	})
+	return pos
+}
+
+// outermostBranch returns the outermost if, switch or select statement of the
+// function's own flow whose body holds pos, or nil when there is none.
+func (c *Checker) outermostBranch(pos token.Pos) ast.Stmt {
+	var branch ast.Stmt
+	ast.Inspect(c.function.Body, func(n ast.Node) bool {
+		if branch != nil || n == nil || pos < n.Pos() || pos >= n.End() {
+			return false
+		}
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.IfStmt:
+			if nodeContainsPos(node.Body, pos) || nodeContainsPos(node.Else, pos) {
+				branch = node
+			}
+		case *ast.SwitchStmt:
+			if nodeContainsPos(node.Body, pos) {
+				branch = node
+			}
+		case *ast.TypeSwitchStmt:
+			if nodeContainsPos(node.Body, pos) {
+				branch = node
+			}
+		case *ast.SelectStmt:
+			if nodeContainsPos(node.Body, pos) {
+				branch = node
+			}
+		}
+		return branch == nil
+	})
+	return branch
+}
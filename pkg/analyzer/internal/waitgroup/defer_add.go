@@ -0,0 +1,35 @@
+package waitgroup
+
+import (
+	"go/ast"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+)
+
+// checkDeferAdd flags `defer wg.Add(n)`. The deferred call only raises the
+// counter when the function returns, after the goroutines it was meant to
+// cover have started and usually after Wait has already run. A negative
+// constant count is a deferred Done and stays with the Done checks.
+func (c *Checker) checkDeferAdd() {
+	ast.Inspect(c.function.Body, func(n ast.Node) bool {
+		deferStmt, ok := n.(*ast.DeferStmt)
+		if !ok || c.commentFilter.ShouldSkipCall(deferStmt.Call) {
+			return true
+		}
+		sel, ok := common.UnwrapParenExpr(deferStmt.Call.Fun).(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Add" || len(deferStmt.Call.Args) != 1 {
+			return true
+		}
+		wgName := c.names.of(sel.X)
+		if !c.waitGroupNames[wgName] {
+			return true
+		}
+		if value, ok := c.addValueAt(deferStmt.Call.Args[0], deferStmt.Call.Pos()); ok && value < 0 {
+			return true
+		}
+		c.errorCollector.AddError(deferStmt.Call.Pos(), category.AddInDefer,
+			"waitgroup '"+wgName+"' Add in defer (runs at function exit)")
+		return true
+	})
+}
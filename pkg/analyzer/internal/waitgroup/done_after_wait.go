@@ -0,0 +1,84 @@
+package waitgroup
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/report"
+)
+
+// checkDoneAfterWait flags a Done in the function's own flow that runs after
+// a Wait, with every Add and Go of the group before that Wait. Once Wait has
+// returned the counter is zero, so the Done panics on a negative counter;
+// when the count was padded to cover it, Wait never returns instead. The
+// Wait must precede the Done in the same statement list, so every path to
+// the Done passes through it. Waits with no goroutine releasing the group
+// before them are left to the pending-Add deadlock report, and a group
+// handed to other code may be re-armed there.
+func (c *Checker) checkDoneAfterWait(stats map[string]*Stats, balance *balanceValidator) {
+	for wgName, st := range stats {
+		if c.escape != nil && c.escape.isWaitGroupPassedToOtherFunctions(wgName) {
+			continue
+		}
+		lastStart := lastStartPos(st)
+		for _, donePos := range st.doneCalls {
+			if donePos < lastStart || !balance.isInMainFunctionFlow(donePos) {
+				continue
+			}
+			for _, waitPos := range st.waitCalls {
+				if waitPos < lastStart || waitPos > donePos || !balance.isInMainFunctionFlow(waitPos) ||
+					!balance.hasRelatedGoroutineBeforeWait(wgName, waitPos) || !c.waitPrecedesInList(waitPos, donePos) {
+					continue
+				}
+				c.errorCollector.AddError(donePos, category.DoneAfterWait,
+					"waitgroup '"+wgName+"' Done after Wait",
+					report.Related(waitPos, "waitgroup '"+wgName+"' Wait called here"))
+				break
+			}
+		}
+	}
+}
+
+// lastStartPos returns the position of the last Add or Go on the group, or
+// token.NoPos when there is none.
+func lastStartPos(st *Stats) token.Pos {
+	last := token.NoPos
+	for _, add := range st.addCalls {
+		last = max(last, add.pos)
+	}
+	for _, goPos := range st.goCalls {
+		last = max(last, goPos)
+	}
+	return last
+}
+
+// waitPrecedesInList reports whether the Wait at waitPos is a statement of
+// its own in a list of the function's own flow, and a later statement of the
+// same list holds pos.
+func (c *Checker) waitPrecedesInList(waitPos, pos token.Pos) bool {
+	found := false
+	ast.Inspect(c.function.Body, func(n ast.Node) bool {
+		if found || n == nil || !nodeContainsPos(n, waitPos) {
+			return false
+		}
+		if _, ok := n.(*ast.FuncLit); ok {
+			return false
+		}
+		stmts := stmtListOf(n)
+		for i, stmt := range stmts {
+			expr, ok := stmt.(*ast.ExprStmt)
+			if !ok || expr.X.Pos() != waitPos {
+				continue
+			}
+			for _, later := range stmts[i+1:] {
+				if nodeContainsPos(later, pos) {
+					found = true
+				}
+			}
+			return false
+		}
+		return true
+	})
+	return found
+}
@@ -3,8 +3,6 @@ package waitgroup
 import (
 	"go/ast"
 	"go/token"
-
-	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
 )
 
 // doneCallInfo contains information about Done calls in a block
@@ -14,13 +12,13 @@ type doneCallInfo struct {
 }
 
 // goroutineRelatedToWaitGroup checks if a goroutine is related to a WaitGroup
-func goroutineRelatedToWaitGroup(goStmt *ast.GoStmt, wgName string) bool {
+func goroutineRelatedToWaitGroup(names varNames, goStmt *ast.GoStmt, wgName string) bool {
 	if fnLit, ok := goStmt.Call.Fun.(*ast.FuncLit); ok {
 		found := false
 		ast.Inspect(fnLit.Body, func(n ast.Node) bool {
 			if call, ok := n.(*ast.CallExpr); ok {
 				if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
-					if common.GetVarName(sel.X) == wgName {
+					if names.of(sel.X) == wgName {
 						found = true
 						return false
 					}
@@ -35,7 +33,7 @@ func goroutineRelatedToWaitGroup(goStmt *ast.GoStmt, wgName string) bool {
 
 func (c *Checker) goroutineDoneInfo(goStmt *ast.GoStmt, wgName string) (doneCallInfo, bool) {
 	if fnLit, ok := goStmt.Call.Fun.(*ast.FuncLit); ok {
-		if !goroutineRelatedToWaitGroup(goStmt, wgName) {
+		if !goroutineRelatedToWaitGroup(c.names, goStmt, wgName) {
 			return doneCallInfo{}, false
 		}
 		return c.analyzeDoneCallsWithVisited(fnLit.Body, wgName, make(map[token.Pos]bool)), true
@@ -49,7 +47,7 @@ func (c *Checker) analyzeDoneCallsWithVisited(block *ast.BlockStmt, wgName strin
 	// Tracks whether a prior branch could exit early, making subsequent statements conditional
 	mightExitEarly := false
 
-	for _, stmt := range block.List {
+	for i, stmt := range block.List {
 		if c.commentFilter.ShouldSkipStatement(stmt) {
 			continue
 		}
@@ -61,7 +59,19 @@ func (c *Checker) analyzeDoneCallsWithVisited(block *ast.BlockStmt, wgName strin
 
 		switch s := stmt.(type) {
 		case *ast.DeferStmt:
-			if c.worker.isSimpleDeferDone(s, wgName) || c.worker.isCallbackDeferDone(s, wgName) || c.worker.isDeferPanicRecoveryPattern(s, wgName) || c.worker.isDeferFuncWithDone(s, wgName) {
+			// A Done inside `if r := recover(); r != nil` only runs when the
+			// goroutine panics; on the normal path it is as good as missing.
+			if c.worker.isDeferPanicRecoveryPattern(s, wgName) {
+				if c.worker.recoveryDoneGuaranteed(s, block.List[i+1:], wgName) {
+					info.hasAnyDone = true
+					if !mightExitEarly {
+						info.hasGuaranteedDone = true
+						return info
+					}
+				}
+				continue
+			}
+			if c.worker.isSimpleDeferDone(s, wgName) || c.worker.isCallbackDeferDone(s, wgName) || c.worker.isDeferFuncWithDone(s, wgName) {
 				info.hasAnyDone = true
 				if !mightExitEarly {
 					info.hasGuaranteedDone = true
@@ -71,12 +81,8 @@ func (c *Checker) analyzeDoneCallsWithVisited(block *ast.BlockStmt, wgName strin
 
 		case *ast.ExprStmt:
 			// Check for channel receive on a local channel with no sends (potentially blocking forever)
-			if unary, ok := s.X.(*ast.UnaryExpr); ok && unary.Op == token.ARROW {
-				chanName := common.GetVarName(unary.X)
-				if chanName != "" && chanName != "?" &&
-					c.worker.isLocallyCreatedChannel(chanName) && !c.worker.hasChannelSends(chanName) {
-					mightExitEarly = true
-				}
+			if !NoBlockingHeuristic && c.worker.blocksForever(s) {
+				mightExitEarly = true
 			}
 
 			// Direct Done() call
@@ -113,6 +119,10 @@ func (c *Checker) analyzeDoneCallsWithVisited(block *ast.BlockStmt, wgName strin
 					elseInfo = c.analyzeDoneCallsWithVisited(elseBlock, wgName, visited)
 					elseTerminates = c.worker.blockAlwaysTerminates(elseBlock)
 				} else if elseIf, ok := s.Else.(*ast.IfStmt); ok {
+					// An else-if chain is analyzed as a nested if, so it
+					// guarantees Done only when it ends in a bare else that
+					// does: without one, the path matching no condition
+					// skips every Done.
 					elseBlock := &ast.BlockStmt{List: []ast.Stmt{elseIf}}
 					elseInfo = c.analyzeDoneCallsWithVisited(elseBlock, wgName, visited)
 					elseTerminates = c.worker.blockAlwaysTerminates(elseBlock)
@@ -0,0 +1,205 @@
+package waitgroup
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/commentfilter"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/primitives"
+	"golang.org/x/tools/go/analysis"
+)
+
+// goroutineDoneInfoFor analyzes the first go statement of f, the first
+// declaration in src. The WaitGroup is a local stand-in so src type-checks
+// without importing sync.
+func goroutineDoneInfoFor(t *testing.T, src string) doneCallInfo {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "done_guaranteed_test.go", src+`
+type waitGroup struct{}
+
+func (*waitGroup) Add(int) {}
+func (*waitGroup) Done()   {}
+func (*waitGroup) Wait()   {}
+
+var wg waitGroup
+
+func work() {}
+`, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	typesInfo := &types.Info{
+		Types:      map[ast.Expr]types.TypeAndValue{},
+		Defs:       map[*ast.Ident]types.Object{},
+		Uses:       map[*ast.Ident]types.Object{},
+		Selections: map[*ast.SelectorExpr]*types.Selection{},
+	}
+	if _, err := new(types.Config).Check("p", fset, []*ast.File{file}, typesInfo); err != nil {
+		t.Fatalf("type-check: %v", err)
+	}
+	fn := file.Decls[0].(*ast.FuncDecl)
+	var goStmt *ast.GoStmt
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		if s, ok := n.(*ast.GoStmt); ok && goStmt == nil {
+			goStmt = s
+		}
+		return goStmt == nil
+	})
+	if goStmt == nil {
+		t.Fatal("go statement not found")
+	}
+
+	names := map[string]bool{"wg": true}
+	reporter := &fakeWaitGroupReporter{}
+	cf := commentfilter.NewCommentFilter(fset, file)
+	c := NewChecker(&primitives.FunctionResult{WaitGroups: names, LocalWaitGroups: names}, reporter, cf,
+		&analysis.Pass{Fset: fset, Files: []*ast.File{file}, TypesInfo: typesInfo})
+	c.function = fn
+	c.worker = newWorkerDoneAnalyzer(fn, names, cf, typesInfo, reporter)
+	info, related := c.goroutineDoneInfo(goStmt, "wg")
+	if !related {
+		t.Fatal("goroutine not related to wg")
+	}
+	return info
+}
+
+func TestGoroutineDoneInfo_ElseIfChain(t *testing.T) {
+	tests := []struct {
+		name       string
+		branches   string
+		guaranteed bool
+	}{
+		{
+			name:       "chain without final else",
+			branches:   "if a { wg.Done() } else if b { wg.Done() }",
+			guaranteed: false,
+		},
+		{
+			name:       "long chain without final else",
+			branches:   "if a { wg.Done() } else if b { wg.Done() } else if c { wg.Done() }",
+			guaranteed: false,
+		},
+		{
+			name:       "chain with final else",
+			branches:   "if a { wg.Done() } else if b { wg.Done() } else { wg.Done() }",
+			guaranteed: true,
+		},
+		{
+			name:       "final else without Done",
+			branches:   "if a { wg.Done() } else if b { wg.Done() } else { work() }",
+			guaranteed: false,
+		},
+		{
+			name:       "inner else if without Done",
+			branches:   "if a { wg.Done() } else if b { work() } else { wg.Done() }",
+			guaranteed: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := goroutineDoneInfoFor(t, `package p
+func f(a, b, c bool) {
+	wg.Add(1)
+	go func() {
+		`+tt.branches+`
+	}()
+	wg.Wait()
+}
+`)
+			if !info.hasAnyDone {
+				t.Fatal("hasAnyDone = false, want true")
+			}
+			if info.hasGuaranteedDone != tt.guaranteed {
+				t.Fatalf("hasGuaranteedDone = %v, want %v", info.hasGuaranteedDone, tt.guaranteed)
+			}
+		})
+	}
+}
+
+func TestGoroutineDoneInfo_RecoverGuardedDone(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		guaranteed bool
+	}{
+		{
+			name:       "Done only when recovering",
+			body:       "defer func() { if r := recover(); r != nil { wg.Done() } }(); work()",
+			guaranteed: false,
+		},
+		{
+			name:       "Done only when recovering, body panics",
+			body:       `defer func() { if r := recover(); r != nil { wg.Done() } }(); panic("fail")`,
+			guaranteed: true,
+		},
+		{
+			name:       "Done also after the recover branch",
+			body:       "defer func() { if r := recover(); r != nil { work() }; wg.Done() }(); work()",
+			guaranteed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := goroutineDoneInfoFor(t, `package p
+func f() {
+	wg.Add(1)
+	go func() {
+		`+tt.body+`
+	}()
+	wg.Wait()
+}
+`)
+			if info.hasGuaranteedDone != tt.guaranteed {
+				t.Fatalf("hasGuaranteedDone = %v, want %v", info.hasGuaranteedDone, tt.guaranteed)
+			}
+		})
+	}
+}
+
+func TestGoroutineDoneInfo_SelectCancelReturn(t *testing.T) {
+	tests := []struct {
+		name       string
+		cases      string
+		guaranteed bool
+	}{
+		{
+			name:       "cancel case returns, default calls Done",
+			cases:      "case <-done: return; default: wg.Done()",
+			guaranteed: false,
+		},
+		{
+			name:       "cancel case returns, receive calls Done",
+			cases:      "case <-done: return; case <-ch: wg.Done()",
+			guaranteed: false,
+		},
+		{
+			name:       "every case calls Done",
+			cases:      "case <-done: wg.Done(); return; default: wg.Done()",
+			guaranteed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := goroutineDoneInfoFor(t, `package p
+func f(done, ch chan struct{}) {
+	wg.Add(1)
+	go func() {
+		select { `+tt.cases+` }
+	}()
+	wg.Wait()
+}
+`)
+			if !info.hasAnyDone {
+				t.Fatal("hasAnyDone = false, want true")
+			}
+			if info.hasGuaranteedDone != tt.guaranteed {
+				t.Fatalf("hasGuaranteedDone = %v, want %v", info.hasGuaranteedDone, tt.guaranteed)
+			}
+		})
+	}
+}
@@ -8,7 +8,7 @@ import (
 
 func (w *workerDoneAnalyzer) callInvokesDone(call *ast.CallExpr, wgName string) bool {
 	if sel, ok := call.Fun.(*ast.SelectorExpr); ok &&
-		sel.Sel.Name == "Done" && common.GetVarName(sel.X) == wgName {
+		sel.Sel.Name == "Done" && w.names.of(sel.X) == wgName {
 		return true
 	}
 
@@ -22,7 +22,7 @@ func (w *workerDoneAnalyzer) callInvokesDone(call *ast.CallExpr, wgName string)
 // isSimpleDeferDone checks if a defer statement is a simple defer wg.Done()
 func (w *workerDoneAnalyzer) isSimpleDeferDone(deferStmt *ast.DeferStmt, wgName string) bool {
 	if call, ok := deferStmt.Call.Fun.(*ast.SelectorExpr); ok {
-		return call.Sel.Name == "Done" && common.GetVarName(call.X) == wgName
+		return call.Sel.Name == "Done" && w.names.of(call.X) == wgName
 	}
 	return false
 }
@@ -72,7 +72,7 @@ func (w *workerDoneAnalyzer) isDeferPanicRecoveryPattern(deferStmt *ast.DeferStm
 				ast.Inspect(ifStmt.Body, func(inner ast.Node) bool {
 					if call, ok := inner.(*ast.CallExpr); ok {
 						if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
-							if sel.Sel.Name == "Done" && common.GetVarName(sel.X) == wgName {
+							if sel.Sel.Name == "Done" && w.names.of(sel.X) == wgName {
 								hasDoneInRecovery = true
 								return false
 							}
@@ -88,6 +88,34 @@ func (w *workerDoneAnalyzer) isDeferPanicRecoveryPattern(deferStmt *ast.DeferStm
 	return hasPanicRecovery && hasDoneInRecovery
 }
 
+// recoveryDoneGuaranteed reports whether the Done of a recover-guarded
+// deferred function runs on every path. The recover branch alone only fires
+// when the goroutine panics, so the function must also call Done outside it,
+// or one of the statements that follow the defer must panic unconditionally.
+func (w *workerDoneAnalyzer) recoveryDoneGuaranteed(deferStmt *ast.DeferStmt, rest []ast.Stmt, wgName string) bool {
+	fnLit, ok := deferStmt.Call.Fun.(*ast.FuncLit)
+	if !ok {
+		return false
+	}
+	for _, stmt := range fnLit.Body.List {
+		if expr, ok := stmt.(*ast.ExprStmt); ok {
+			if call, ok := expr.X.(*ast.CallExpr); ok && w.callInvokesDone(call, wgName) {
+				return true
+			}
+		}
+	}
+	for _, stmt := range rest {
+		if expr, ok := stmt.(*ast.ExprStmt); ok {
+			if call, ok := expr.X.(*ast.CallExpr); ok {
+				if ident, ok := call.Fun.(*ast.Ident); ok && w.isBuiltinPanic(ident) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
 // isDeferFuncWithDone checks if a defer has a function literal that calls Done
 func (w *workerDoneAnalyzer) isDeferFuncWithDone(deferStmt *ast.DeferStmt, wgName string) bool {
 	fnLit, ok := deferStmt.Call.Fun.(*ast.FuncLit)
@@ -0,0 +1,84 @@
+package waitgroup
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/report"
+)
+
+// checkDoneSkippedOnCancel flags a goroutine literal that calls Done on a
+// waited-on group, yet has a select case receiving a cancellation signal —
+// ctx.Done() or a channel the function closes — that always leaves without
+// calling it. Once the signal fires the goroutine exits still holding its
+// share of the counter, so the Wait joining it never returns. The balance
+// checks stay silent on such goroutines because some path does reach Done.
+// Goroutines guaranteed to call Done, e.g. through a defer, are fine.
+func (c *Checker) checkDoneSkippedOnCancel(stats map[string]*Stats) {
+	for wgName, st := range stats {
+		if len(st.waitCalls) == 0 {
+			continue
+		}
+		if c.escape != nil && c.escape.isWaitGroupPassedToOtherFunctions(wgName) {
+			continue
+		}
+		for _, goStmt := range c.goroutines().goStmts {
+			lit, ok := goStmt.Call.Fun.(*ast.FuncLit)
+			if !ok {
+				continue
+			}
+			info, related := c.goroutineDoneInfo(goStmt, wgName)
+			if !related || !info.hasAnyDone || info.hasGuaranteedDone {
+				continue
+			}
+			cc := c.cancelClauseSkippingDone(lit.Body, wgName)
+			if cc == nil {
+				continue
+			}
+			c.errorCollector.AddError(cc.Pos(), category.DoneSkippedOnCancel,
+				"waitgroup '"+wgName+"' Done skipped when the goroutine is cancelled",
+				report.Related(goStmt.Pos(), "goroutine started here"),
+				report.Related(st.waitCalls[0], "waitgroup '"+wgName+"' Wait blocks here"))
+		}
+	}
+}
+
+// cancelClauseSkippingDone returns the first select case in body, outside
+// nested function literals, that receives a cancellation signal and always
+// leaves the goroutine without calling Done on wgName, or nil.
+func (c *Checker) cancelClauseSkippingDone(body *ast.BlockStmt, wgName string) *ast.CommClause {
+	var found *ast.CommClause
+	ast.Inspect(body, func(n ast.Node) bool {
+		if found != nil {
+			return false
+		}
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CommClause:
+			if !c.commClauseReceivesDoneSignal(node) {
+				return true
+			}
+			caseBlock := &ast.BlockStmt{List: node.Body}
+			if c.clauseLeavesGoroutine(node) &&
+				!c.analyzeDoneCallsWithVisited(caseBlock, wgName, make(map[token.Pos]bool)).hasAnyDone {
+				found = node
+			}
+		}
+		return found == nil
+	})
+	return found
+}
+
+// clauseLeavesGoroutine reports whether a statement of cc's own body returns
+// or aborts the goroutine. A break or goto only leaves the select, and the
+// code it reaches may still call Done.
+func (c *Checker) clauseLeavesGoroutine(cc *ast.CommClause) bool {
+	for _, stmt := range cc.Body {
+		if _, ok := stmt.(*ast.BranchStmt); !ok && c.worker.isTerminatingStatement(stmt) {
+			return true
+		}
+	}
+	return false
+}
@@ -25,6 +25,7 @@ type escapeAnalyzer struct {
 	analyzeDoneCalls             doneCallAnalyzer
 	isLocallyCreatedChannel      localChannelChecker
 	resolveFunction              functionResolver
+	names                        varNames
 }
 
 func newEscapeAnalyzer(
@@ -64,7 +65,10 @@ func (e *escapeAnalyzer) isWaitGroupPassedToOtherFunctions(wgName string) bool {
 				found = true
 				return false
 			}
-			for _, arg := range node.Args {
+			for i, arg := range node.Args {
+				if e.isIgnoredDoneCallback(node, i, wgName) {
+					continue
+				}
 				if e.exprEscapesWaitGroup(arg, wgName, localCallbacks, make(map[string]bool)) {
 					found = true
 					return false
@@ -102,6 +106,62 @@ func (e *escapeAnalyzer) isWaitGroupPassedToOtherFunctions(wgName string) bool {
 	return found
 }
 
+// isIgnoredDoneCallback reports whether argument argIndex of call is the
+// method value wgName.Done handed to a function of this package that never
+// mentions the parameter receiving it. Such a callee cannot call Done, so
+// passing it the method value is not a hand-off of the WaitGroup.
+func (e *escapeAnalyzer) isIgnoredDoneCallback(call *ast.CallExpr, argIndex int, wgName string) bool {
+	sel, ok := call.Args[argIndex].(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Done" || e.names.of(sel.X) != wgName || e.resolveFunction == nil {
+		return false
+	}
+	fn := e.resolveFunction(call.Fun)
+	if fn == nil || fn.Body == nil || fn.Type.Params == nil {
+		return false
+	}
+	param, ok := paramForArg(fn.Type.Params, argIndex)
+	if !ok {
+		return false
+	}
+	if param == "" || param == "_" {
+		return true
+	}
+	used := false
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && ident.Name == param {
+			used = true
+		}
+		return !used
+	})
+	return !used
+}
+
+// paramForArg returns the name of the parameter that receives argument
+// argIndex, "" when it is unnamed. The second result is false when there is
+// no such parameter or it is variadic.
+func paramForArg(params *ast.FieldList, argIndex int) (string, bool) {
+	index := 0
+	for _, field := range params.List {
+		if _, variadic := field.Type.(*ast.Ellipsis); variadic {
+			return "", false
+		}
+		if len(field.Names) == 0 {
+			if index == argIndex {
+				return "", true
+			}
+			index++
+			continue
+		}
+		for _, name := range field.Names {
+			if index == argIndex {
+				return name.Name, true
+			}
+			index++
+		}
+	}
+	return "", false
+}
+
 func (e *escapeAnalyzer) sendEscapesWaitGroup(send *ast.SendStmt, wgName string, callbacks map[string]ast.Expr) bool {
 	if send == nil || !e.exprEscapesWaitGroup(send.Value, wgName, callbacks, make(map[string]bool)) {
 		return false
@@ -148,7 +208,7 @@ func (e *escapeAnalyzer) exprEscapesWaitGroup(expr ast.Expr, wgName string, call
 		return false
 	}
 
-	if isWaitGroupArgument(expr, wgName) {
+	if isWaitGroupArgument(e.names, expr, wgName) {
 		return true
 	}
 
@@ -158,7 +218,7 @@ func (e *escapeAnalyzer) exprEscapesWaitGroup(expr ast.Expr, wgName string, call
 
 	found := false
 	ast.Inspect(expr, func(n ast.Node) bool {
-		if exprNode, ok := n.(ast.Expr); ok && isWaitGroupArgument(exprNode, wgName) {
+		if exprNode, ok := n.(ast.Expr); ok && isWaitGroupArgument(e.names, exprNode, wgName) {
 			found = true
 			return false
 		}
@@ -170,7 +230,7 @@ func (e *escapeAnalyzer) exprEscapesWaitGroup(expr ast.Expr, wgName string, call
 
 		switch node := n.(type) {
 		case *ast.UnaryExpr:
-			if node.Op == token.AND && common.GetVarName(node.X) == wgName {
+			if node.Op == token.AND && e.names.of(node.X) == wgName {
 				found = true
 				return false
 			}
@@ -216,7 +276,7 @@ func (e *escapeAnalyzer) functionLiteralEscapesWaitGroup(fn *ast.FuncLit, wgName
 			return false
 		}
 
-		if exprNode, ok := n.(ast.Expr); ok && isWaitGroupArgument(exprNode, wgName) {
+		if exprNode, ok := n.(ast.Expr); ok && isWaitGroupArgument(e.names, exprNode, wgName) {
 			found = true
 			return false
 		}
@@ -255,7 +315,7 @@ func (e *escapeAnalyzer) methodValueContainsDoneForWaitGroup(sel *ast.SelectorEx
 		return false
 	}
 
-	receiverExprName := common.GetVarName(sel.X)
+	receiverExprName := e.names.of(sel.X)
 	if receiverExprName == "" || receiverExprName == "?" {
 		return false
 	}
@@ -0,0 +1,308 @@
+package waitgroup
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strconv"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/driver"
+)
+
+// fieldUsage tallies the calls made on one WaitGroup struct field across the
+// whole package. inexact is set once an Add or Done may run a number of times
+// the tally cannot tell, so only the all-or-nothing comparison still holds.
+type fieldUsage struct {
+	owner   string
+	adds    []fieldCall
+	dones   []fieldCall
+	goCalls int
+	escaped bool
+	inexact bool
+}
+
+// fieldCall is one Add or Done on a field: its delta, and the function it is
+// made in, whose callers tell how often it runs.
+type fieldCall struct {
+	pos   token.Pos
+	delta int64
+	fn    *types.Func
+}
+
+// callSites lists the functions calling one function of the package, nil
+// for a call outside any function. inexact is set when a call is repeated or
+// skipped by the flow around it, or when the function is referenced without
+// being called, as a method value may be.
+type callSites struct {
+	callers []*types.Func
+	inexact bool
+}
+
+// CheckFieldBalance correlates Add and Done on WaitGroup struct fields across
+// every function of the package (GCL2019). Per-function checks stay quiet on
+// fields because Add and Done usually sit in different methods; this pass
+// sums them package-wide, keyed by the field itself, and reports a field that
+// is only ever added to or only ever released, or whose constant Adds exceed
+// its Dones. Each call counts once per run of the function it is made in, as
+// told by the calls to that function found in the package. Exported fields
+// and fields whose address escapes may be balanced elsewhere and are
+// skipped.
+func CheckFieldBalance(pkg *driver.Package) {
+	info := pkg.Info
+	usage := make(map[*types.Var]*fieldUsage)
+	var order []*types.Var
+	lookup := func(field *types.Var, owner string) *fieldUsage {
+		u := usage[field]
+		if u == nil {
+			u = &fieldUsage{owner: owner}
+			usage[field] = u
+			order = append(order, field)
+		}
+		return u
+	}
+	calls := make(map[*types.Func]*callSites)
+	sites := func(fn *types.Func) *callSites {
+		cs := calls[fn]
+		if cs == nil {
+			cs = &callSites{}
+			calls[fn] = cs
+		}
+		return cs
+	}
+
+	receivers := make(map[*ast.SelectorExpr]bool)
+	callees := make(map[*ast.Ident]bool)
+	var stack []ast.Node
+	pkg.Inspect(func(n ast.Node, skipped bool) bool {
+		if skipped {
+			return false
+		}
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		stack = append(stack, n)
+		switch node := n.(type) {
+		case *ast.CallExpr:
+			if id, fn := calledFunc(node, info); fn != nil {
+				callees[id] = true
+				cs := sites(fn)
+				cs.callers = append(cs.callers, enclosingFunc(stack, info))
+				cs.inexact = cs.inexact || branched(stack)
+			}
+			method, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			recv, ok := common.UnwrapParenExpr(method.X).(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			field, owner, ok := waitGroupField(recv, info, pkg.SyncPackages)
+			if !ok {
+				return true
+			}
+			receivers[recv] = true
+			lookup(field, owner).record(method.Sel.Name, node, stack, info)
+		case *ast.Ident:
+			if fn, ok := info.Uses[node].(*types.Func); ok && !callees[node] {
+				sites(fn.Origin()).inexact = true
+			}
+		case *ast.SelectorExpr:
+			if receivers[node] {
+				return true
+			}
+			field, owner, ok := waitGroupField(node, info, pkg.SyncPackages)
+			if !ok {
+				return true
+			}
+			// Any use other than a direct method call (address taken,
+			// passed on, method value) may balance the field elsewhere.
+			lookup(field, owner).escaped = true
+		}
+		return true
+	})
+
+	for _, field := range order {
+		u := usage[field]
+		if u.escaped || field.Exported() {
+			continue
+		}
+		name := u.owner + "." + field.Name()
+		switch {
+		case len(u.adds) > 0 && len(u.dones) == 0:
+			pkg.Reporter.AddError(earliest(u.adds), category.FieldAddDoneImbalance,
+				"waitgroup field '"+name+"' has Add but no Done in any function of the package")
+		case len(u.dones) > 0 && len(u.adds) == 0 && u.goCalls == 0:
+			pkg.Reporter.AddError(earliest(u.dones), category.FieldAddDoneImbalance,
+				"waitgroup field '"+name+"' has Done but no Add in any function of the package")
+		case len(u.adds) > 0 && !u.inexact:
+			added, addedOK := total(u.adds, calls)
+			released, releasedOK := total(u.dones, calls)
+			if addedOK && releasedOK && added > released {
+				pkg.Reporter.AddError(earliest(u.adds), category.FieldAddDoneImbalance,
+					"waitgroup field '"+name+"' is added "+strconv.FormatInt(added, 10)+
+						" times but released only "+strconv.FormatInt(released, 10)+" times in the package")
+			}
+		}
+	}
+}
+
+// record counts one method call on the field, made at the end of stack. Add
+// with a negative constant releases like Done.
+func (u *fieldUsage) record(method string, call *ast.CallExpr, stack []ast.Node, info *types.Info) {
+	fc := fieldCall{pos: call.Pos(), delta: 1, fn: enclosingFunc(stack, info)}
+	known := true
+	switch method {
+	case "Add":
+		known = false
+		if len(call.Args) == 1 {
+			if tv, ok := info.Types[call.Args[0]]; ok && tv.Value != nil {
+				fc.delta, known = constant.Int64Val(constant.ToInt(tv.Value))
+			}
+		}
+		if known && fc.delta < 0 {
+			fc.delta = -fc.delta
+			u.dones = append(u.dones, fc)
+		} else {
+			u.adds = append(u.adds, fc)
+		}
+	case "Done":
+		u.dones = append(u.dones, fc)
+	case "Go":
+		u.goCalls++
+		return
+	default:
+		return
+	}
+	u.inexact = u.inexact || !known || branched(stack)
+}
+
+// total sums the deltas of calls, each once per run of the function it is
+// made in. It reports false when a run count is not exact.
+func total(calls []fieldCall, sites map[*types.Func]*callSites) (int64, bool) {
+	var sum int64
+	for _, call := range calls {
+		runs, ok := runCount(call.fn, sites, map[*types.Func]bool{})
+		if !ok {
+			return 0, false
+		}
+		sum += call.delta * runs
+	}
+	return sum, true
+}
+
+// runCount returns how many times fn runs: once when nothing in the package
+// calls it, else once per run of each of its callers. A recursive chain of
+// calls has no count.
+func runCount(fn *types.Func, sites map[*types.Func]*callSites, visiting map[*types.Func]bool) (int64, bool) {
+	if fn == nil {
+		return 1, true
+	}
+	cs := sites[fn]
+	if cs == nil {
+		return 1, true
+	}
+	if cs.inexact || visiting[fn] {
+		return 0, false
+	}
+	if len(cs.callers) == 0 {
+		return 1, true
+	}
+	visiting[fn] = true
+	defer delete(visiting, fn)
+	var runs int64
+	for _, caller := range cs.callers {
+		n, ok := runCount(caller, sites, visiting)
+		if !ok {
+			return 0, false
+		}
+		runs += n
+	}
+	return runs, true
+}
+
+// enclosingFunc returns the declared function the end of stack sits in, or
+// nil outside any.
+func enclosingFunc(stack []ast.Node, info *types.Info) *types.Func {
+	if len(stack) > 1 {
+		if decl, ok := stack[1].(*ast.FuncDecl); ok {
+			fn, _ := info.Defs[decl.Name].(*types.Func)
+			return fn
+		}
+	}
+	return nil
+}
+
+// calledFunc returns the identifier naming the function call invokes and the
+// function itself, when it is one declared with a name.
+func calledFunc(call *ast.CallExpr, info *types.Info) (*ast.Ident, *types.Func) {
+	var id *ast.Ident
+	switch fun := common.UnwrapParenExpr(call.Fun).(type) {
+	case *ast.Ident:
+		id = fun
+	case *ast.SelectorExpr:
+		id = fun.Sel
+	default:
+		return nil, nil
+	}
+	fn, ok := info.Uses[id].(*types.Func)
+	if !ok {
+		return nil, nil
+	}
+	return id, fn.Origin()
+}
+
+// branched reports whether the node at the end of stack sits in a loop or a
+// branch of its function, where it may run any number of times.
+func branched(stack []ast.Node) bool {
+	for _, n := range stack[:len(stack)-1] {
+		switch n.(type) {
+		case *ast.ForStmt, *ast.RangeStmt, *ast.IfStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+			return true
+		}
+	}
+	return false
+}
+
+// waitGroupField reports whether sel selects a struct field of type
+// sync.WaitGroup (not a pointer to one, which may be shared) and returns the
+// field and the name of the struct type that declares it. A WaitGroup of
+// one of the sync forks of syncPackages counts too.
+func waitGroupField(sel *ast.SelectorExpr, info *types.Info, syncPackages []string) (*types.Var, string, bool) {
+	selection, ok := info.Selections[sel]
+	if !ok || selection.Kind() != types.FieldVal {
+		return nil, "", false
+	}
+	field, ok := selection.Obj().(*types.Var)
+	if !ok {
+		return nil, "", false
+	}
+	if _, isPtr := types.Unalias(field.Type()).(*types.Pointer); isPtr || !common.IsWaitGroup(field.Type(), syncPackages...) {
+		return nil, "", false
+	}
+	owner := "?"
+	recv := selection.Recv()
+	if ptr, ok := types.Unalias(recv).(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+	if named, ok := types.Unalias(recv).(*types.Named); ok {
+		owner = named.Obj().Name()
+	}
+	return field, owner, true
+}
+
+// earliest returns the lowest position of calls, so the report lands on the
+// first call in file order regardless of file iteration order.
+func earliest(calls []fieldCall) token.Pos {
+	first := calls[0].pos
+	for _, call := range calls[1:] {
+		if call.pos < first {
+			first = call.pos
+		}
+	}
+	return first
+}
@@ -0,0 +1,75 @@
+package waitgroup
+
+import (
+	"go/ast"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// missingDoneFix builds the suggested fix for an Add(1) without a matching
+// Done when the Add is followed by exactly one `go func() { ... }()` linked
+// to it that never calls Done: `defer wg.Done()` is inserted as the first
+// statement of the goroutine body. Any other Add value gets no fix, since a
+// single Done would not balance it. Neither do goroutines written on one
+// line, or with an empty body, since the inserted line could not match
+// their layout.
+func (c *Checker) missingDoneFix(add addCall, wgName string) []analysis.SuggestedFix {
+	if c.fset == nil || c.function == nil || c.function.Body == nil || !add.known || add.value != 1 {
+		return nil
+	}
+
+	var target *ast.FuncLit
+	candidates := 0
+	ast.Inspect(c.function.Body, func(n ast.Node) bool {
+		goStmt, ok := n.(*ast.GoStmt)
+		if !ok || goStmt.Pos() < add.at || c.worker.findRelatedAddCall(goStmt, wgName) != add.at {
+			return true
+		}
+		fnlit, ok := goStmt.Call.Fun.(*ast.FuncLit)
+		if !ok || callsDone(c.names, fnlit.Body, wgName) {
+			return true
+		}
+		candidates++
+		target = fnlit
+		return true
+	})
+	if candidates != 1 || len(target.Body.List) == 0 {
+		return nil
+	}
+
+	first := target.Body.List[0]
+	firstPos := c.fset.Position(first.Pos())
+	if firstPos.Line == c.fset.Position(target.Body.Lbrace).Line {
+		return nil
+	}
+	// gofmt indents with tabs, so the column of the first statement gives its
+	// indentation depth.
+	indent := strings.Repeat("\t", firstPos.Column-1)
+	return []analysis.SuggestedFix{{
+		Message: "Add defer " + wgName + ".Done() to the goroutine",
+		TextEdits: []analysis.TextEdit{{
+			Pos:     first.Pos(),
+			End:     first.Pos(),
+			NewText: []byte("defer " + wgName + ".Done()\n" + indent),
+		}},
+	}}
+}
+
+// callsDone reports whether body calls wgName.Done() anywhere.
+func callsDone(names varNames, body *ast.BlockStmt, wgName string) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		found = ok && sel.Sel.Name == "Done" && names.of(sel.X) == wgName
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,80 @@
+package waitgroup
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/report"
+)
+
+// checkAddDoneInSameGoroutine flags a goroutine that calls both Add and Done
+// on a WaitGroup from the enclosing function (GCL2024, opt-in). The pair
+// balances to zero inside the goroutine, so nothing waiting on the group
+// ever sees it: the Add belonged in the parent, before the go statement.
+// Calls in nested function literals are not paired, and an Add handed to
+// a nested worker that owns the Done is the usual fan-out shape. When the
+// function itself waits on the group the Add is already reported as racing
+// that Wait (GCL2005).
+func (c *Checker) checkAddDoneInSameGoroutine(goroutines *goroutineInspector) {
+	for _, goStmt := range c.goroutines().goStmts {
+		if c.commentFilter.ShouldSkipStatement(goStmt) {
+			continue
+		}
+		fnLit, ok := goStmt.Call.Fun.(*ast.FuncLit)
+		if !ok || fnLit.Body == nil {
+			continue
+		}
+
+		adds := make(map[string]token.Pos)
+		dones := make(map[string]token.Pos)
+		var order []string
+		ast.Inspect(fnLit.Body, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.GoStmt, *ast.FuncLit:
+				return false
+			case *ast.DeferStmt:
+				for wgName := range c.waitGroupNames {
+					if _, seen := dones[wgName]; !seen && c.worker.deferInvokesDone(node, wgName) {
+						dones[wgName] = node.Pos()
+					}
+				}
+				return false
+			case *ast.CallExpr:
+				if c.commentFilter.ShouldSkipCall(node) || !c.isNodeInGoroutine(node) {
+					return true
+				}
+				sel, ok := node.Fun.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+				wgName := c.names.of(sel.X)
+				if !c.waitGroupNames[wgName] {
+					return true
+				}
+				switch sel.Sel.Name {
+				case "Add":
+					if _, seen := adds[wgName]; !seen && !goroutines.waitGroupIdentDefinedInside(fnLit.Body, sel.X) {
+						adds[wgName] = node.Pos()
+						order = append(order, wgName)
+					}
+				case "Done":
+					if _, seen := dones[wgName]; !seen {
+						dones[wgName] = node.Pos()
+					}
+				}
+			}
+			return true
+		})
+
+		for _, wgName := range order {
+			donePos, ok := dones[wgName]
+			if !ok || goroutines.addHandedOffToWorker(fnLit.Body, wgName) || goroutines.hasMainFlowWait(c.function, wgName) {
+				continue
+			}
+			c.errorCollector.AddError(adds[wgName], category.AddDoneSameGoroutine,
+				"waitgroup '"+wgName+"' Add and Done both inside goroutine (likely misplaced Add)",
+				report.Related(donePos, "Done here"))
+		}
+	}
+}
@@ -47,7 +47,7 @@ func (g *goroutineInspector) hasMainFlowWait(fn *ast.FuncDecl, wgName string) bo
 			return true
 		}
 		sel, ok := call.Fun.(*ast.SelectorExpr)
-		if !ok || sel.Sel.Name != "Wait" || common.GetVarName(sel.X) != wgName {
+		if !ok || sel.Sel.Name != "Wait" || g.names.of(sel.X) != wgName {
 			return true
 		}
 		found = true
@@ -91,7 +91,7 @@ func (g *goroutineInspector) reportNestedWaitsForWorker(fn *ast.FuncDecl, goStmt
 		if !ok || sel.Sel.Name != "Wait" {
 			return true
 		}
-		innerWG := common.GetVarName(sel.X)
+		innerWG := g.names.of(sel.X)
 		if !g.waitGroupNames[innerWG] {
 			return true
 		}
@@ -103,7 +103,7 @@ func (g *goroutineInspector) reportNestedWaitsForWorker(fn *ast.FuncDecl, goStmt
 				continue
 			}
 			if g.outerWaitBeforeInnerRelease(fn, goStmt.Pos(), outerWG, innerWG) {
-				g.reporter.AddError(call.Pos(), category.NestedWaitGroupDeadlock,
+				g.reporter.AddError(common.MethodPos(call), category.NestedWaitGroupDeadlock,
 					"waitgroup '"+innerWG+"' Wait inside worker for waitgroup '"+outerWG+"' can deadlock")
 				return false
 			}
@@ -130,7 +130,7 @@ func (g *goroutineInspector) outerWaitBeforeInnerRelease(fn *ast.FuncDecl, goPos
 		if !ok {
 			return true
 		}
-		wgName := common.GetVarName(sel.X)
+		wgName := g.names.of(sel.X)
 		switch {
 		case wgName == innerWG && sel.Sel.Name == "Add" && call.Pos() < goPos:
 			if delta, ok := g.addDelta(call, innerWG); ok && delta > 0 {
@@ -186,7 +186,7 @@ func (g *goroutineInspector) callReleasesWaitGroup(call *ast.CallExpr, wgName st
 		return false
 	}
 	sel, ok := call.Fun.(*ast.SelectorExpr)
-	if !ok || common.GetVarName(sel.X) != wgName {
+	if !ok || g.names.of(sel.X) != wgName {
 		return false
 	}
 	if sel.Sel.Name == "Done" {
@@ -0,0 +1,131 @@
+package waitgroup
+
+import (
+	"go/ast"
+	"go/token"
+	"maps"
+	"slices"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+)
+
+// checkDoneInUnboundedLoop flags a Done a worker goroutine calls on every
+// iteration of a loop that runs more than once, when the function adds to
+// the WaitGroup exactly once, with a count of 1. The second iteration drives
+// the counter negative and panics. A loop whose body can break or return is
+// assumed to stop after the Done.
+func (g *goroutineInspector) checkDoneInUnboundedLoop(fn *ast.FuncDecl) {
+	if g == nil || fn == nil || fn.Body == nil || g.callInvokesDone == nil {
+		return
+	}
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		goStmt, ok := n.(*ast.GoStmt)
+		if !ok || g.shouldSkipStatement(goStmt) {
+			return true
+		}
+		fnLit, ok := goStmt.Call.Fun.(*ast.FuncLit)
+		if !ok || fnLit.Body == nil {
+			return true
+		}
+		for _, wgName := range slices.Sorted(maps.Keys(g.waitGroupNames)) {
+			if !g.hasSingleAddOfOne(fn.Body, wgName) {
+				continue
+			}
+			for _, done := range g.donesInRepeatingLoops(fnLit.Body, wgName) {
+				g.reporter.AddError(done.Pos(), category.MultipleDoneWorker,
+					"waitgroup '"+wgName+"' Done called repeatedly in loop")
+			}
+		}
+		return true
+	})
+}
+
+// hasSingleAddOfOne reports whether body calls Add on wgName exactly once,
+// outside any loop, with the constant 1.
+func (g *goroutineInspector) hasSingleAddOfOne(body *ast.BlockStmt, wgName string) bool {
+	var adds []*ast.CallExpr
+	var loops []ast.Node
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.ForStmt, *ast.RangeStmt:
+			loops = append(loops, node)
+		case *ast.CallExpr:
+			if _, ok := g.addDelta(node, wgName); ok {
+				adds = append(adds, node)
+			}
+		}
+		return true
+	})
+	if len(adds) != 1 || len(adds[0].Args) != 1 {
+		return false
+	}
+	if value, ok := common.ConstantIntValue(adds[0].Args[0], g.typesInfo); !ok || value != 1 {
+		return false
+	}
+	return !slices.ContainsFunc(loops, func(loop ast.Node) bool {
+		return loop.Pos() <= adds[0].Pos() && adds[0].Pos() < loop.End()
+	})
+}
+
+// donesInRepeatingLoops returns the Done calls that sit directly in the
+// body of a loop in body that repeats: a for without a condition, or a
+// range over anything but a constant of at most 1. Loops inside nested
+// function literals are not considered.
+func (g *goroutineInspector) donesInRepeatingLoops(body *ast.BlockStmt, wgName string) []*ast.CallExpr {
+	var dones []*ast.CallExpr
+	ast.Inspect(body, func(n ast.Node) bool {
+		var loopBody *ast.BlockStmt
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ForStmt:
+			if node.Cond == nil {
+				loopBody = node.Body
+			}
+		case *ast.RangeStmt:
+			if count, ok := common.ConstantIntValue(node.X, g.typesInfo); !ok || count > 1 {
+				loopBody = node.Body
+			}
+		}
+		if loopBody == nil || loopMayStop(loopBody) {
+			return true
+		}
+		for _, stmt := range loopBody.List {
+			if g.shouldSkipStatement(stmt) {
+				continue
+			}
+			exprStmt, ok := stmt.(*ast.ExprStmt)
+			if !ok {
+				continue
+			}
+			if call, ok := exprStmt.X.(*ast.CallExpr); ok && g.callInvokesDone(call, wgName) {
+				dones = append(dones, call)
+			}
+		}
+		return true
+	})
+	return dones
+}
+
+// loopMayStop reports whether a loop body holds a return, break, goto or
+// panic that could end the loop, ignoring nested function literals.
+func loopMayStop(body *ast.BlockStmt) bool {
+	stops := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			stops = true
+		case *ast.BranchStmt:
+			stops = node.Tok == token.BREAK || node.Tok == token.GOTO
+		case *ast.CallExpr:
+			ident, ok := node.Fun.(*ast.Ident)
+			stops = ok && ident.Name == "panic"
+		}
+		return !stops
+	})
+	return stops
+}
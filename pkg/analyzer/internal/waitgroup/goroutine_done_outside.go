@@ -56,7 +56,7 @@ func (g *goroutineInspector) checkDoneOutsideWorkerForWaitGroup(fn *ast.FuncDecl
 			if g.callInvokesDone != nil && g.callInvokesDone(call, wgName) {
 				recentPositiveAdd = false
 				if workerGoroutinesWithoutDone > 0 {
-					g.reporter.AddError(call.Pos(), category.DoneOutsideGoroutine, "waitgroup '"+wgName+"' Done called outside worker goroutine")
+					g.reporter.AddError(common.MethodPos(call), category.DoneOutsideGoroutine, "waitgroup '"+wgName+"' Done called outside worker goroutine")
 					workerGoroutinesWithoutDone--
 				}
 				if pendingAdds > 0 {
@@ -140,7 +140,9 @@ func (g *goroutineInspector) checkDoneOutsideWorkerForWaitGroup(fn *ast.FuncDecl
 	visitStmts(fn.Body.List)
 }
 
-func (g *goroutineInspector) checkAddInsideGoroutine(fn *ast.FuncDecl) {
+// checkAddInsideGoroutine reports an Add on a WaitGroup the function waits
+// on made inside one of goStmts, the goroutines fn launches.
+func (g *goroutineInspector) checkAddInsideGoroutine(fn *ast.FuncDecl, goStmts []*ast.GoStmt) {
 	if g == nil || fn == nil || fn.Body == nil {
 		return
 	}
@@ -155,14 +157,13 @@ func (g *goroutineInspector) checkAddInsideGoroutine(fn *ast.FuncDecl) {
 		return found
 	}
 
-	ast.Inspect(fn.Body, func(n ast.Node) bool {
-		goStmt, ok := n.(*ast.GoStmt)
-		if !ok || g.shouldSkipStatement(goStmt) {
-			return true
+	for _, goStmt := range goStmts {
+		if g.shouldSkipStatement(goStmt) {
+			continue
 		}
 		fnLit, ok := goStmt.Call.Fun.(*ast.FuncLit)
 		if !ok || fnLit.Body == nil {
-			return true
+			continue
 		}
 
 		ast.Inspect(fnLit.Body, func(inner ast.Node) bool {
@@ -174,7 +175,7 @@ func (g *goroutineInspector) checkAddInsideGoroutine(fn *ast.FuncDecl) {
 			if !ok || sel.Sel.Name != "Add" {
 				return true
 			}
-			wgName := common.GetVarName(sel.X)
+			wgName := g.names.of(sel.X)
 			if !g.waitGroupNames[wgName] || g.waitGroupIdentDefinedInside(fnLit.Body, sel.X) {
 				return true
 			}
@@ -185,12 +186,10 @@ func (g *goroutineInspector) checkAddInsideGoroutine(fn *ast.FuncDecl) {
 			if g.addHandedOffToWorker(fnLit.Body, wgName) {
 				return true
 			}
-			g.reporter.AddError(call.Pos(), category.AddInsideGoroutine, "waitgroup '"+wgName+"' Add called inside goroutine, may race with Wait")
+			g.reporter.AddError(common.MethodPos(call), category.AddInsideGoroutine, "waitgroup '"+wgName+"' Add called inside goroutine, may race with Wait")
 			return true
 		})
-
-		return true
-	})
+	}
 }
 
 // addHandedOffToWorker reports whether body spawns a worker goroutine that owns
@@ -253,7 +252,7 @@ func (g *goroutineInspector) workerBodyInvokesDone(body *ast.BlockStmt, wgName s
 
 func (g *goroutineInspector) addDelta(call *ast.CallExpr, wgName string) (int, bool) {
 	sel, ok := call.Fun.(*ast.SelectorExpr)
-	if !ok || sel.Sel.Name != "Add" || common.GetVarName(sel.X) != wgName {
+	if !ok || sel.Sel.Name != "Add" || g.names.of(sel.X) != wgName {
 		return 0, false
 	}
 
@@ -305,7 +304,7 @@ func (g *goroutineInspector) checkMultipleDoneInBranch(stmts []ast.Stmt, wgName
 			if call, ok := s.X.(*ast.CallExpr); ok && g.callInvokesDone != nil && g.callInvokesDone(call, wgName) {
 				current++
 				if current > 1 {
-					g.reporter.AddError(call.Pos(), category.MultipleDoneWorker, "waitgroup '"+wgName+"' Done called multiple times in the same worker branch")
+					g.reporter.AddError(common.MethodPos(call), category.MultipleDoneWorker, "waitgroup '"+wgName+"' Done called multiple times in the same worker branch")
 				}
 			}
 		case *ast.IfStmt:
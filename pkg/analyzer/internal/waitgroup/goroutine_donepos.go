@@ -4,7 +4,6 @@ import (
 	"go/ast"
 	"go/token"
 
-	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
 )
 
@@ -65,7 +64,7 @@ func (g *goroutineInspector) collectWaitDonePositions(block *ast.BlockStmt, wgNa
 			if g.shouldSkipCall(e) {
 				return
 			}
-			recordWaitDoneCall(e, wgName, &positions)
+			recordWaitDoneCall(g.names, e, wgName, &positions)
 			if fnLit, ok := e.Fun.(*ast.FuncLit); ok && fnLit.Body != nil {
 				visitStmts(fnLit.Body.List)
 			}
@@ -182,9 +181,9 @@ func visitMainFlowElse(stmt ast.Stmt, visitStmt func(ast.Stmt), visitStmts func(
 	}
 }
 
-func recordWaitDoneCall(call *ast.CallExpr, wgName string, positions *waitDonePositions) {
+func recordWaitDoneCall(names varNames, call *ast.CallExpr, wgName string, positions *waitDonePositions) {
 	sel, ok := call.Fun.(*ast.SelectorExpr)
-	if !ok || common.GetVarName(sel.X) != wgName {
+	if !ok || names.of(sel.X) != wgName {
 		return
 	}
 
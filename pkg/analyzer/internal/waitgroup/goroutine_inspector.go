@@ -39,6 +39,7 @@ type goroutineInspector struct {
 	typesInfo          *types.Info
 	isInMainFlow       mainFlowChecker
 	isBuiltinPanic     builtinPanicChecker
+	names              varNames
 }
 
 func newGoroutineInspector(
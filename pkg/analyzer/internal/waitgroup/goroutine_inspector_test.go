@@ -8,6 +8,7 @@ import (
 
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+	"golang.org/x/tools/go/analysis"
 )
 
 type fakeWaitGroupReporter struct {
@@ -20,7 +21,7 @@ type fakeWaitGroupReport struct {
 	msg string
 }
 
-func (f *fakeWaitGroupReporter) AddError(pos token.Pos, cat category.Category, message string) {
+func (f *fakeWaitGroupReporter) AddError(pos token.Pos, cat category.Category, message string, _ ...analysis.RelatedInformation) {
 	f.calls = append(f.calls, fakeWaitGroupReport{pos: pos, cat: cat, msg: message})
 }
 
@@ -146,7 +147,7 @@ func f() {
 	wg.Wait()
 }`)
 	rep := &fakeWaitGroupReporter{}
-	newTestGoroutineInspector(rep).checkAddInsideGoroutine(fn)
+	newTestGoroutineInspector(rep).checkAddInsideGoroutine(fn, newGoroutineIndex(fn.Body, nil).goStmts)
 
 	if len(rep.calls) != 1 {
 		t.Fatalf("expected 1 diagnostic, got %d", len(rep.calls))
@@ -169,7 +170,7 @@ func f() {
 	}()
 }`)
 	rep := &fakeWaitGroupReporter{}
-	newTestGoroutineInspector(rep).checkAddInsideGoroutine(fn)
+	newTestGoroutineInspector(rep).checkAddInsideGoroutine(fn, newGoroutineIndex(fn.Body, nil).goStmts)
 
 	if len(rep.calls) != 0 {
 		t.Fatalf("expected no diagnostics without a main-flow Wait, got %d", len(rep.calls))
@@ -3,48 +3,91 @@ package waitgroup
 import (
 	"go/ast"
 	"go/token"
+	"sort"
 )
 
-// isNodeInGoroutine checks if a node is inside a goroutine
-func (c *Checker) isNodeInGoroutine(targetNode ast.Node) bool {
-	inGoroutine := false
+// goroutineIndex records, for the function being analyzed, the go
+// statements it contains and everything inside the literals they launch.
+// The balance and inspector checks ask "is this inside a goroutine?" for
+// every Add, Done and Wait; answering from the index keeps that linear in
+// the body size instead of re-walking the body per query, which grew
+// quadratically on deeply nested goroutines. funcLitBodies holds the bodies
+// of the outermost function literals, goroutine or not, in source order.
+// A statement of launches counts as the go statement it maps to.
+type goroutineIndex struct {
+	goStmts       []*ast.GoStmt
+	calls         map[token.Pos]bool
+	nodes         map[ast.Node]bool
+	funcLitBodies []*ast.BlockStmt
+}
 
-	ast.Inspect(c.function.Body, func(n ast.Node) bool {
-		if goStmt, ok := n.(*ast.GoStmt); ok {
-			if fnLit, ok := goStmt.Call.Fun.(*ast.FuncLit); ok {
-				ast.Inspect(fnLit.Body, func(inner ast.Node) bool {
-					if inner == targetNode {
-						inGoroutine = true
-						return false
-					}
-					return true
-				})
+func newGoroutineIndex(body *ast.BlockStmt, launches map[ast.Stmt]*ast.GoStmt) *goroutineIndex {
+	idx := &goroutineIndex{calls: make(map[token.Pos]bool), nodes: make(map[ast.Node]bool)}
+	if body == nil {
+		return idx
+	}
+	ast.Inspect(body, func(n ast.Node) bool {
+		if fnLit, ok := n.(*ast.FuncLit); ok {
+			if last := len(idx.funcLitBodies) - 1; last < 0 || !nodeContainsPos(idx.funcLitBodies[last], fnLit.Pos()) {
+				idx.funcLitBodies = append(idx.funcLitBodies, fnLit.Body)
 			}
+			return true
+		}
+		goStmt, ok := n.(*ast.GoStmt)
+		if stmt, isStmt := n.(ast.Stmt); isStmt && launches[stmt] != nil {
+			goStmt, ok = launches[stmt], true
+		}
+		if !ok {
+			return true
 		}
-		return !inGoroutine
+		idx.goStmts = append(idx.goStmts, goStmt)
+		fnLit, ok := goStmt.Call.Fun.(*ast.FuncLit)
+		if !ok || idx.nodes[fnLit.Body] {
+			return true
+		}
+		ast.Inspect(fnLit.Body, func(inner ast.Node) bool {
+			if inner == nil {
+				return false
+			}
+			idx.nodes[inner] = true
+			if call, ok := inner.(*ast.CallExpr); ok {
+				idx.calls[call.Pos()] = true
+			}
+			return true
+		})
+		return true
 	})
+	return idx
+}
 
-	return inGoroutine
+// inFunctionLiteral reports whether pos lies in the body of any function
+// literal.
+func (idx *goroutineIndex) inFunctionLiteral(pos token.Pos) bool {
+	i := sort.Search(len(idx.funcLitBodies), func(i int) bool { return idx.funcLitBodies[i].End() >= pos })
+	return i < len(idx.funcLitBodies) && nodeContainsPos(idx.funcLitBodies[i], pos)
+}
+
+// goroutines returns the index for the current function, building it on
+// first use.
+func (c *Checker) goroutines() *goroutineIndex {
+	if c.goroutineIndex == nil || c.goroutineIndexFn != c.function {
+		var body *ast.BlockStmt
+		if c.function != nil {
+			body = c.function.Body
+		}
+		c.goroutineIndex = newGoroutineIndex(body, c.goLaunches)
+		c.goroutineIndexFn = c.function
+		c.goroutineIndexBuilds++
+	}
+	return c.goroutineIndex
+}
+
+// isNodeInGoroutine checks if a node is inside a goroutine
+func (c *Checker) isNodeInGoroutine(targetNode ast.Node) bool {
+	return c.goroutines().nodes[targetNode]
 }
 
 // isInGoroutine checks if a position is within a goroutine
 func (c *Checker) isInGoroutine(pos token.Pos) bool {
-	isInGoroutine := false
-	ast.Inspect(c.function.Body, func(n ast.Node) bool {
-		if goStmt, ok := n.(*ast.GoStmt); ok {
-			if fnLit, ok := goStmt.Call.Fun.(*ast.FuncLit); ok {
-				ast.Inspect(fnLit.Body, func(inner ast.Node) bool {
-					if call, ok := inner.(*ast.CallExpr); ok {
-						if call.Pos() == pos {
-							isInGoroutine = true
-							return false
-						}
-					}
-					return true
-				})
-			}
-		}
-		return !isInGoroutine
-	})
-	return isInGoroutine
+	return c.goroutines().calls[pos]
 }
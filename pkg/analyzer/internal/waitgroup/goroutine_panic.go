@@ -22,7 +22,7 @@ func (g *goroutineInspector) checkWaitGroupGoPanic(fn *ast.FuncDecl) {
 		if !ok || sel.Sel.Name != "Go" {
 			return true
 		}
-		wgName := common.GetVarName(sel.X)
+		wgName := g.names.of(sel.X)
 		if !g.waitGroupNames[wgName] || len(call.Args) == 0 {
 			return true
 		}
@@ -32,7 +32,7 @@ func (g *goroutineInspector) checkWaitGroupGoPanic(fn *ast.FuncDecl) {
 			return true
 		}
 		if g.functionLiteralMayPanic(fnLit) {
-			g.reporter.AddError(call.Pos(), category.GoPanic, "waitgroup '"+wgName+"' Go function may panic")
+			g.reporter.AddError(common.MethodPos(call), category.GoPanic, "waitgroup '"+wgName+"' Go function may panic")
 		}
 		return true
 	})
@@ -0,0 +1,93 @@
+package waitgroup
+
+import (
+	"go/ast"
+	"go/token"
+	"maps"
+	"slices"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+)
+
+// checkWaitOnlyGoroutine flags the Wait of a goroutine that uses a WaitGroup
+// only through Wait while the launching function Waits on it too. With no
+// Done anywhere in the function, the goroutine was most likely meant to call
+// Done and Wait was pasted in its place. A closer goroutine
+// (`wg.Wait(); close(ch)`) is left alone as long as some worker calls Done,
+// and only WaitGroups declared in the function body are considered, since a
+// parameter or field may be released elsewhere.
+func (g *goroutineInspector) checkWaitOnlyGoroutine(fn *ast.FuncDecl) {
+	if g == nil || fn == nil || fn.Body == nil || g.goroutineOnlyWaits == nil {
+		return
+	}
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		goStmt, ok := n.(*ast.GoStmt)
+		if !ok || g.shouldSkipStatement(goStmt) {
+			return true
+		}
+		fnLit, ok := goStmt.Call.Fun.(*ast.FuncLit)
+		if !ok || fnLit.Body == nil {
+			return true
+		}
+		for _, wgName := range slices.Sorted(maps.Keys(g.waitGroupNames)) {
+			if !g.goroutineOnlyWaits(goStmt, wgName) || !g.hasMainFlowWait(fn, wgName) || g.mayBeReleased(fn.Body, wgName) {
+				continue
+			}
+			if wait := g.firstWaitIn(fnLit.Body, wgName); wait != nil {
+				g.reporter.AddError(wait.Pos(), category.WaitInGoroutineWithoutDone,
+					"waitgroup '"+wgName+"' Wait inside goroutine without Done")
+			}
+		}
+		return true
+	})
+}
+
+// mayBeReleased reports whether wgName may see a Done: it is not declared in
+// body, body calls Done or Go on it, or body passes it on.
+func (g *goroutineInspector) mayBeReleased(body *ast.BlockStmt, wgName string) bool {
+	released := false
+	declared := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if released {
+			return false
+		}
+		switch node := n.(type) {
+		case *ast.Ident:
+			if node.Name == wgName && g.waitGroupIdentDefinedInside(body, node) {
+				declared = true
+			}
+		case *ast.CallExpr:
+			if sel, ok := node.Fun.(*ast.SelectorExpr); ok && g.names.of(sel.X) == wgName {
+				released = sel.Sel.Name == "Done" || sel.Sel.Name == "Go"
+			}
+			for _, arg := range node.Args {
+				if g.names.of(arg) == wgName {
+					released = true
+				}
+			}
+		case *ast.UnaryExpr:
+			released = node.Op == token.AND && g.names.of(node.X) == wgName
+		}
+		return !released
+	})
+	return released || !declared
+}
+
+func (g *goroutineInspector) firstWaitIn(body *ast.BlockStmt, wgName string) *ast.CallExpr {
+	var wait *ast.CallExpr
+	ast.Inspect(body, func(n ast.Node) bool {
+		if wait != nil {
+			return false
+		}
+		call, ok := n.(*ast.CallExpr)
+		if !ok || g.shouldSkipCall(call) {
+			return true
+		}
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Wait" && g.names.of(sel.X) == wgName {
+			wait = call
+		}
+		return wait == nil
+	})
+	return wait
+}
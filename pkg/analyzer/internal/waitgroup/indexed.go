@@ -0,0 +1,136 @@
+package waitgroup
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/report"
+)
+
+// indexedWaitGroup gathers the calls made on the elements of a local slice
+// or array of WaitGroups. The index is dynamic, so every element is folded
+// into one name, "wgs[]", and only the blunt case of Adds with no Done on
+// any element is reported.
+type indexedWaitGroup struct {
+	name    string
+	adds    []token.Pos
+	done    bool
+	escaped bool
+}
+
+// reportIndexedWaitGroups flags local WaitGroup slices and arrays whose
+// elements are added to but never marked done. Any use of the collection
+// other than calling a method on an element, len/cap or an index-only range
+// may hand the elements to code this function cannot see, so it is skipped.
+func (c *Checker) reportIndexedWaitGroups() {
+	if c.typesInfo == nil || c.function == nil || c.function.Body == nil {
+		return
+	}
+	body := c.function.Body
+	groups := make(map[types.Object]*indexedWaitGroup)
+	allowed := make(map[*ast.Ident]bool)
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.CallExpr:
+			if ident, ok := common.UnwrapParenExpr(node.Fun).(*ast.Ident); ok && (ident.Name == "len" || ident.Name == "cap") && len(node.Args) == 1 {
+				if arg, ok := common.UnwrapParenExpr(node.Args[0]).(*ast.Ident); ok {
+					allowed[arg] = true
+				}
+				return true
+			}
+			sel, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			index, ok := common.UnwrapParenExpr(sel.X).(*ast.IndexExpr)
+			if !ok {
+				return true
+			}
+			ident, ok := common.UnwrapParenExpr(index.X).(*ast.Ident)
+			if !ok {
+				return true
+			}
+			group := c.indexedWaitGroupFor(ident, groups)
+			if group == nil {
+				return true
+			}
+			allowed[ident] = true
+			switch sel.Sel.Name {
+			case "Add":
+				if !c.commentFilter.ShouldSkipCall(node) {
+					group.adds = append(group.adds, node.Pos())
+				}
+			case "Done", "Go":
+				group.done = true
+			}
+		case *ast.RangeStmt:
+			if ident, ok := common.UnwrapParenExpr(node.X).(*ast.Ident); ok && node.Value == nil {
+				allowed[ident] = true
+			}
+		}
+		return true
+	})
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		ident, ok := n.(*ast.Ident)
+		if !ok || allowed[ident] {
+			return true
+		}
+		if group := groups[c.typesInfo.Uses[ident]]; group != nil {
+			group.escaped = true
+		}
+		return true
+	})
+
+	var pending []*indexedWaitGroup
+	for _, group := range groups {
+		if len(group.adds) > 0 && !group.done && !group.escaped {
+			pending = append(pending, group)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].adds[0] < pending[j].adds[0] })
+	for _, group := range pending {
+		c.errorCollector.AddError(group.adds[0], category.AddWithoutDone,
+			report.FormatMessage("waitgroup.add_without_done", group.name))
+	}
+}
+
+// indexedWaitGroupFor returns the group for ident when it names a slice or
+// array of WaitGroups declared inside the function being analyzed.
+func (c *Checker) indexedWaitGroupFor(ident *ast.Ident, groups map[types.Object]*indexedWaitGroup) *indexedWaitGroup {
+	name := ident.Name + "[]"
+	if !c.waitGroupSlices[name] {
+		return nil
+	}
+	obj, ok := c.typesInfo.Uses[ident].(*types.Var)
+	if !ok {
+		return nil
+	}
+	if group := groups[obj]; group != nil {
+		return group
+	}
+	body := c.function.Body
+	if obj.Pos() < body.Pos() || obj.Pos() >= body.End() {
+		return nil
+	}
+	var elem types.Type
+	switch typ := obj.Type().Underlying().(type) {
+	case *types.Slice:
+		elem = typ.Elem()
+	case *types.Array:
+		elem = typ.Elem()
+	default:
+		return nil
+	}
+	if !common.IsWaitGroup(elem, c.syncPackages...) {
+		return nil
+	}
+	group := &indexedWaitGroup{name: name}
+	groups[obj] = group
+	return group
+}
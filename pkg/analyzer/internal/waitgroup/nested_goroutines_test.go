@@ -0,0 +1,100 @@
+package waitgroup
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/commentfilter"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/primitives"
+	"golang.org/x/tools/go/analysis"
+)
+
+// nestedGoroutineSource returns a function with waitGroups WaitGroups, each
+// added to and released across goroutines nested depth levels deep, with
+// fanout sibling goroutines per level. It is the shape that made the
+// per-query ast.Inspect walks of the goroutine helpers grow quadratically;
+// BenchmarkWaitGroupAnalyzer in the analyzer package times it end to end.
+func nestedGoroutineSource(waitGroups, depth, fanout int) string {
+	var b strings.Builder
+	b.WriteString("package p\n\nfunc f() {\n")
+	for i := range waitGroups {
+		fmt.Fprintf(&b, "\tvar wg%d waitGroup\n", i)
+	}
+	var level func(d int, indent string)
+	level = func(d int, indent string) {
+		for range fanout {
+			for i := range waitGroups {
+				fmt.Fprintf(&b, "%swg%d.Add(1)\n", indent, i)
+			}
+			fmt.Fprintf(&b, "%sgo func() {\n", indent)
+			for i := range waitGroups {
+				fmt.Fprintf(&b, "%s\tdefer wg%d.Done()\n", indent, i)
+			}
+			if d > 1 {
+				level(d-1, indent+"\t")
+			}
+			fmt.Fprintf(&b, "%s\twork()\n%s}()\n", indent, indent)
+		}
+	}
+	level(depth, "\t")
+	for i := range waitGroups {
+		fmt.Fprintf(&b, "\twg%d.Wait()\n", i)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// newNestedChecker type-checks src, whose first declaration is f, against a
+// local WaitGroup stand-in and returns a checker for it.
+func newNestedChecker(tb testing.TB, src string, waitGroups int) (*Checker, *ast.FuncDecl) {
+	tb.Helper()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "nested_goroutines_test.go", src+`
+type waitGroup struct{}
+
+func (*waitGroup) Add(int) {}
+func (*waitGroup) Done()   {}
+func (*waitGroup) Wait()   {}
+
+func work() {}
+`, parser.ParseComments)
+	if err != nil {
+		tb.Fatalf("parse: %v", err)
+	}
+	typesInfo := &types.Info{
+		Types:      map[ast.Expr]types.TypeAndValue{},
+		Defs:       map[*ast.Ident]types.Object{},
+		Uses:       map[*ast.Ident]types.Object{},
+		Selections: map[*ast.SelectorExpr]*types.Selection{},
+	}
+	if _, err := new(types.Config).Check("p", fset, []*ast.File{file}, typesInfo); err != nil {
+		tb.Fatalf("type-check: %v", err)
+	}
+
+	names := make(map[string]bool, waitGroups)
+	for i := range waitGroups {
+		names[fmt.Sprintf("wg%d", i)] = true
+	}
+	c := NewChecker(&primitives.FunctionResult{WaitGroups: names, LocalWaitGroups: names}, &fakeWaitGroupReporter{},
+		commentfilter.NewCommentFilter(fset, file),
+		&analysis.Pass{Fset: fset, Files: []*ast.File{file}, TypesInfo: typesInfo})
+	return c, file.Decls[0].(*ast.FuncDecl)
+}
+
+// TestWaitGroupAnalyzerNestedGoroutinesIndexedOnce guards against the
+// goroutine helpers regressing to re-walking the body on every query: every
+// check must answer from the one index built for the function.
+func TestWaitGroupAnalyzerNestedGoroutinesIndexedOnce(t *testing.T) {
+	const waitGroups = 4
+	c, fn := newNestedChecker(t, nestedGoroutineSource(waitGroups, 4, 2), waitGroups)
+	c.AnalyzeFunction(fn)
+	if c.goroutineIndexBuilds != 1 {
+		t.Fatalf("AnalyzeFunction built the goroutine index %d times, want 1", c.goroutineIndexBuilds)
+	}
+}
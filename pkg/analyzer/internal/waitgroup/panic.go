@@ -49,7 +49,7 @@ func (w *workerDoneAnalyzer) checkDoneNotDeferredInBlock(stmts []ast.Stmt, wgNam
 		case *ast.ExprStmt:
 			if call, ok := s.X.(*ast.CallExpr); ok && w.callInvokesDone(call, wgName) {
 				if risky {
-					w.errorCollector.AddError(call.Pos(), category.DoneNotDeferred, "waitgroup '"+wgName+"' Done should be deferred so it runs on panic or runtime.Goexit")
+					w.errorCollector.AddError(common.MethodPos(call), category.DoneNotDeferred, "waitgroup '"+wgName+"' Done should be deferred so it runs on panic or runtime.Goexit")
 				}
 				continue
 			}
@@ -283,7 +283,7 @@ func (w *workerDoneAnalyzer) isBuiltinPanic(ident *ast.Ident) bool {
 
 func (w *workerDoneAnalyzer) isWaitGroupHousekeepingCall(call *ast.CallExpr, wgName string) bool {
 	sel, ok := call.Fun.(*ast.SelectorExpr)
-	if !ok || common.GetVarName(sel.X) != wgName {
+	if !ok || w.names.of(sel.X) != wgName {
 		return false
 	}
 	switch sel.Sel.Name {
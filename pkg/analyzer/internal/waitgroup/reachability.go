@@ -7,6 +7,15 @@ import (
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
 )
 
+// NoBlockingHeuristic turns off the guess that a worker goroutine which
+// first receives from a local channel nothing sends on may never reach its
+// Done. With the guess such a Done does not count as guaranteed; without it,
+// the receive is assumed to return. A Done placed after an early return,
+// panic or Goexit is unreachable for certain and is unaffected. It is bound
+// to the umbrella analyzer's -no-blocking-heuristic flag for code whose
+// channels are fed from other packages, where the guess is wrong.
+var NoBlockingHeuristic bool
+
 // isTerminatingStatement checks if a statement terminates execution flow
 func (w *workerDoneAnalyzer) isTerminatingStatement(stmt ast.Stmt) bool {
 	switch s := stmt.(type) {
@@ -59,6 +68,23 @@ func (w *workerDoneAnalyzer) hasUnreachableDone(body *ast.BlockStmt, wgName stri
 	return false
 }
 
+// blocksForever reports whether stmt is a bare receive from a channel made
+// in the function that nothing sends on or closes, so the goroutine running
+// it never gets past it. It is a guess, since the channel may be fed through
+// an alias the scan does not follow.
+func (w *workerDoneAnalyzer) blocksForever(stmt ast.Stmt) bool {
+	expr, ok := stmt.(*ast.ExprStmt)
+	if !ok {
+		return false
+	}
+	unary, ok := expr.X.(*ast.UnaryExpr)
+	if !ok || unary.Op != token.ARROW {
+		return false
+	}
+	chanName := common.GetVarName(unary.X)
+	return chanName != "" && chanName != "?" && w.isLocallyCreatedChannel(chanName) && !w.hasChannelSends(chanName)
+}
+
 // containsDoneCall checks if a statement contains a Done call for the given WaitGroup
 func (w *workerDoneAnalyzer) containsDoneCall(stmt ast.Stmt, wgName string) bool {
 	found := false
@@ -207,10 +233,10 @@ func (w *workerDoneAnalyzer) findRelatedAddCall(goStmt *ast.GoStmt, wgName strin
 	ast.Inspect(w.function.Body, func(n ast.Node) bool {
 		if call, ok := n.(*ast.CallExpr); ok {
 			if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
-				if sel.Sel.Name == "Add" && common.GetVarName(sel.X) == wgName {
-					allAdds = append(allAdds, call.Pos())
+				if sel.Sel.Name == "Add" && w.names.of(sel.X) == wgName {
+					allAdds = append(allAdds, common.MethodPos(call))
 					if call.Pos() < goStmt.Pos() {
-						lastAddBeforeGo = call.Pos()
+						lastAddBeforeGo = common.MethodPos(call)
 					}
 				}
 			}
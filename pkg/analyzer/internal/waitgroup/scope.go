@@ -0,0 +1,91 @@
+package waitgroup
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common"
+)
+
+// varNames names the WaitGroup an expression refers to, as
+// common.GetVarName does, except that an expression rooted at a hidden
+// variable names none. The checker hides the WaitGroups a nested block
+// declares under a name an enclosing scope already binds, so calls on them
+// are never matched against the WaitGroup of the same name outside the
+// block. The zero value hides nothing.
+type varNames struct {
+	info   *types.Info
+	hidden map[types.Object]bool
+}
+
+// of returns the name expr refers to, or "?" when it is rooted at a hidden
+// variable.
+func (n varNames) of(expr ast.Expr) string {
+	if len(n.hidden) > 0 && n.info != nil {
+		if root := rootIdent(expr); root != nil && n.hidden[n.info.ObjectOf(root)] {
+			return "?"
+		}
+	}
+	return common.GetVarName(expr)
+}
+
+// rootIdent returns the variable a selector chain starts from.
+func rootIdent(expr ast.Expr) *ast.Ident {
+	for {
+		switch e := common.UnwrapParenExpr(expr).(type) {
+		case *ast.Ident:
+			return e
+		case *ast.SelectorExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.StarExpr:
+			expr = e.X
+		default:
+			return nil
+		}
+	}
+}
+
+// shadowScope is a block analyzed as if it were a function body of its
+// own: only the WaitGroups it declares are tracked, and the ones declared by
+// the other shadowing blocks are hidden.
+type shadowScope struct {
+	fn     *ast.FuncDecl
+	names  map[string]bool
+	hidden map[types.Object]bool
+}
+
+// shadowScopes returns the scopes c analyzes fn in: fn itself, with every
+// shadowing WaitGroup hidden, followed by one scope per shadowing block in
+// source order.
+func (c *Checker) shadowScopes(fn *ast.FuncDecl) []shadowScope {
+	all := make(map[types.Object]bool, len(c.shadowing))
+	byBlock := map[*ast.BlockStmt][]types.Object{}
+	for obj, block := range c.shadowing {
+		all[obj] = true
+		byBlock[block] = append(byBlock[block], obj)
+	}
+	scopes := []shadowScope{{fn: fn, names: c.allWaitGroupNames, hidden: all}}
+	blocks := make([]*ast.BlockStmt, 0, len(byBlock))
+	for block := range byBlock {
+		blocks = append(blocks, block)
+	}
+	slices.SortFunc(blocks, func(a, b *ast.BlockStmt) int { return int(a.Lbrace - b.Lbrace) })
+	for _, block := range blocks {
+		scope := *fn
+		scope.Body = block
+		names := map[string]bool{}
+		hidden := make(map[types.Object]bool, len(all))
+		for obj := range all {
+			if c.shadowing[obj] == block {
+				names[obj.Name()] = true
+			} else {
+				hidden[obj] = true
+			}
+		}
+		scopes = append(scopes, shadowScope{fn: &scope, names: names, hidden: hidden})
+	}
+	return scopes
+}
@@ -1,6 +1,7 @@
 package waitgroup
 
 import (
+	"fmt"
 	"reflect"
 
 	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/commentfilter"
@@ -17,17 +18,28 @@ import (
 // umbrella analyzer re-emits them via pass.Report.
 var SubAnalyzer = &analysis.Analyzer{
 	Name:       "goconcurrencylint_waitgroup",
-	Doc:        "Detects misuse of sync.WaitGroup (Add/Done/Wait imbalance, Add after Wait, etc.).",
+	Doc:        "Detects misuse of sync.WaitGroup (Add/Done/Wait imbalance, Add after Wait, etc.), including Add/Done on struct fields correlated across the package.",
 	Run:        run,
 	Requires:   []*analysis.Analyzer{inspect.Analyzer, primitives.Analyzer, filesetup.Analyzer},
 	ResultType: reflect.TypeFor[[]analysis.Diagnostic](),
 }
 
 func run(pass *analysis.Pass) (any, error) {
-	return driver.Run(pass, driver.Config[*Checker]{
+	if AddAttribution != AttributeFirst && AddAttribution != AttributeLast {
+		return nil, fmt.Errorf("-add-attribution: unknown strategy %q, want %q or %q", AddAttribution, AttributeFirst, AttributeLast)
+	}
+	result, err := driver.Run(pass, driver.Config[*Checker]{
 		Guard: primitives.HasWaitGroups,
 		NewChecker: func(fr *primitives.FunctionResult, ec report.Reporter, cf *commentfilter.CommentFilter, pass *analysis.Pass) *Checker {
 			return NewChecker(fr, ec, cf, pass)
 		},
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Struct-field Add/Done pairs span functions, so they are tallied in one
+	// package-wide pass after the per-function checks.
+	diags := result.([]analysis.Diagnostic)
+	return append(diags, driver.RunPackage(pass, CheckFieldBalance)...), nil
 }
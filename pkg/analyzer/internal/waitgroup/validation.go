@@ -11,18 +11,24 @@ func (c *Checker) validateUsage(stats map[string]*Stats) {
 		function:                     c.function,
 		waitGroupNames:               c.waitGroupNames,
 		localWaitGroupNames:          c.localWaitGroupNames,
+		names:                        c.names,
 		commentFilter:                c.commentFilter,
 		reporter:                     c.errorCollector,
 		typesInfo:                    c.typesInfo,
+		syncPackages:                 c.syncPackages,
 		escape:                       c.escape,
 		isInGoroutine:                c.isInGoroutine,
 		isNodeInGoroutine:            c.isNodeInGoroutine,
+		goroutines:                   c.goroutines,
 		callInvokesDone:              c.worker.callInvokesDone,
 		goroutineDoneInfo:            c.goroutineDoneInfo,
 		isSimpleDeferDone:            c.worker.isSimpleDeferDone,
 		findRelatedAddCall:           c.worker.findRelatedAddCall,
+		missingDoneFix:               c.missingDoneFix,
 		hasUnreachableDone:           c.worker.hasUnreachableDone,
 		waitInEarlyExitBranch:        c.worker.waitInEarlyExitBranch,
+		waitUnreachable:              c.worker.waitUnreachable,
+		callAborts:                   c.worker.callAbortsWorker,
 		estimateForIterations:        iteration.estimateForIterations,
 		estimateForIterationsKnown:   iteration.estimateForIterationsKnown,
 		estimateRangeIterations:      iteration.estimateRangeIterations,
@@ -42,13 +48,29 @@ func (c *Checker) validateUsage(stats map[string]*Stats) {
 		balance.isInMainFunctionFlow,
 		c.worker.isBuiltinPanic,
 	)
-	goroutines.checkAddInsideGoroutine(c.function)
+	goroutines.names = c.names
+	goroutines.checkAddInsideGoroutine(c.function, c.goroutines().goStmts)
+	c.checkAddDoneInSameGoroutine(goroutines)
 	c.worker.checkDoneNotDeferredInWorker()
 	balance.checkLiteralAddLoopGoroutineMismatch(stats)
 	balance.checkWaitWithoutAdd(stats)
+	balance.checkAddWithoutWait(stats)
+	balance.checkNoOpMainFlowPair(stats)
+	balance.checkWaitNotGuaranteed(stats)
 	goroutines.checkMultipleDoneSameWorkerBranch(c.function)
+	goroutines.checkDoneInUnboundedLoop(c.function)
 	goroutines.checkNestedWaitGroupDeadlock(c.function)
+	goroutines.checkWaitOnlyGoroutine(c.function)
 	balance.checkAddAfterWait(stats)
+	balance.checkAddAfterGoroutineStart(stats)
+	c.checkWaitInLoopWithoutAdd(stats)
+	c.checkWaitBeforeAdd(stats, balance)
+	c.checkAddWaitSameIteration(stats, balance)
+	c.checkDoneAfterConditionalAdd(stats, balance)
+	c.checkWaitUnreachable(stats, balance)
+	c.checkDoneAfterWait(stats, balance)
+	c.checkDoneSkippedOnCancel(stats)
+	c.checkDeferAdd()
 	balance.checkWaitBeforeDoneSameGoroutine(stats)
 	goroutines.checkWaitAndDoneInSameGoroutine(c.function)
 	goroutines.checkDoneOutsideWorkerGoroutine(c.function)
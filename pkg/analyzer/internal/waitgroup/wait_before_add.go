@@ -0,0 +1,84 @@
+package waitgroup
+
+import (
+	"go/token"
+	"strings"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+)
+
+// checkWaitBeforeAdd flags a Wait on a local WaitGroup that runs before
+// anything has touched the group: no Add, Go or Done precedes it and no
+// goroutine of the function uses it. The counter is still zero, so the Wait
+// returns at once; the usual mistake is expecting it to block until work
+// added afterwards completes, so a later Add or Go must exist. A group with
+// none at all is left to the Wait-without-Add report. The check is opt-in
+// (GCL2025) and separate from the Add-after-Wait report, which describes the
+// later Add instead. Waits inside loops are skipped, since a later iteration
+// may follow an Add, as are groups handed to other code before the Wait.
+func (c *Checker) checkWaitBeforeAdd(stats map[string]*Stats, balance *balanceValidator) {
+	for wgName, st := range stats {
+		if !c.localWaitGroupNames[wgName] || strings.Contains(wgName, ".") ||
+			balance.waitGroupInitializedFromAnother(wgName) || c.goroutineUsesWaitGroup(wgName) {
+			continue
+		}
+		for _, waitPos := range st.waitCalls {
+			if !balance.isInMainFunctionFlow(waitPos) || c.enclosingLoopBody(waitPos) != nil ||
+				touchedBefore(st, waitPos) || !addedAfter(st, waitPos) {
+				continue
+			}
+			if targetObj := balance.waitGroupReceiverObjectAt(wgName, "Wait", waitPos); targetObj != nil &&
+				(balance.isWaitGroupPassedToOtherFunctionsForWait(targetObj, waitPos) ||
+					balance.hasAddInLocalClosure(targetObj, waitPos)) {
+				continue
+			}
+			c.errorCollector.AddError(waitPos, category.WaitBeforeAdd,
+				"waitgroup '"+wgName+"' Wait before any Add (returns immediately)")
+		}
+	}
+}
+
+// touchedBefore reports whether any Add, Go or Done on the group comes
+// before pos in the source.
+func touchedBefore(st *Stats, pos token.Pos) bool {
+	for _, add := range st.addCalls {
+		if add.pos < pos {
+			return true
+		}
+	}
+	for _, group := range [][]token.Pos{st.goCalls, st.doneCalls, st.deferDoneCalls} {
+		for _, p := range group {
+			if p < pos {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// addedAfter reports whether an Add or Go on the group comes after pos in
+// the source.
+func addedAfter(st *Stats, pos token.Pos) bool {
+	for _, add := range st.addCalls {
+		if add.pos > pos {
+			return true
+		}
+	}
+	for _, p := range st.goCalls {
+		if p > pos {
+			return true
+		}
+	}
+	return false
+}
+
+// goroutineUsesWaitGroup reports whether any goroutine launched by the
+// function calls a method on wgName.
+func (c *Checker) goroutineUsesWaitGroup(wgName string) bool {
+	for _, goStmt := range c.goroutines().goStmts {
+		if goroutineRelatedToWaitGroup(c.names, goStmt, wgName) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package waitgroup
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+)
+
+// checkWaitInLoopWithoutAdd flags a Wait on a local WaitGroup repeated by a
+// loop whose body never calls Add or Go on it. After the first iteration the
+// counter is already zero, so every later Wait returns immediately and the
+// loop makes no progress waiting. Loops whose body always exits, and loops
+// that use the WaitGroup for anything other than Wait (so it may be refilled
+// elsewhere), are left alone.
+func (c *Checker) checkWaitInLoopWithoutAdd(stats map[string]*Stats) {
+	for wgName, st := range stats {
+		if !c.localWaitGroupNames[wgName] || strings.Contains(wgName, ".") {
+			continue
+		}
+		for _, waitPos := range st.waitCalls {
+			body := c.enclosingLoopBody(waitPos)
+			if body == nil || c.worker.blockAlwaysTerminates(body) {
+				continue
+			}
+			if callsInside(st.addCalls, body) || positionsInside(st.goCalls, body) ||
+				loopUsesWaitGroupBeyondWait(c.names, body, wgName) {
+				continue
+			}
+			c.errorCollector.AddError(waitPos, category.WaitInLoopWithoutAdd,
+				"waitgroup '"+wgName+"' Wait in loop without Add (no progress)")
+		}
+	}
+}
+
+// enclosingLoopBody returns the body of the innermost for or range loop whose
+// body holds pos, or nil when pos is not in a loop body of the function
+// itself (a closure boundary stops the search).
+func (c *Checker) enclosingLoopBody(pos token.Pos) *ast.BlockStmt {
+	if c.function == nil || c.function.Body == nil {
+		return nil
+	}
+	var body *ast.BlockStmt
+	ast.Inspect(c.function.Body, func(n ast.Node) bool {
+		if n == nil || pos < n.Pos() || pos >= n.End() {
+			return false
+		}
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			body = nil
+			return false
+		case *ast.ForStmt:
+			body = loopBodyHolding(node.Body, pos)
+		case *ast.RangeStmt:
+			body = loopBodyHolding(node.Body, pos)
+		}
+		return true
+	})
+	return body
+}
+
+// loopBodyHolding returns body when it holds pos, or nil when pos sits in the
+// loop header instead.
+func loopBodyHolding(body *ast.BlockStmt, pos token.Pos) *ast.BlockStmt {
+	if body != nil && body.Pos() <= pos && pos < body.End() {
+		return body
+	}
+	return nil
+}
+
+// callsInside reports whether any of the Add calls sits in body.
+func callsInside(calls []addCall, body *ast.BlockStmt) bool {
+	for _, call := range calls {
+		if positionsInside([]token.Pos{call.pos}, body) {
+			return true
+		}
+	}
+	return false
+}
+
+// positionsInside reports whether any of positions sits in body.
+func positionsInside(positions []token.Pos, body *ast.BlockStmt) bool {
+	for _, pos := range positions {
+		if body.Pos() <= pos && pos < body.End() {
+			return true
+		}
+	}
+	return false
+}
+
+// loopUsesWaitGroupBeyondWait reports whether body refers to wgName other
+// than as the receiver of a Wait call: a Done, a reassignment, or passing it
+// on means the loop may be driving the counter after all.
+func loopUsesWaitGroupBeyondWait(names varNames, body *ast.BlockStmt, wgName string) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		if sel, ok := n.(*ast.SelectorExpr); ok && sel.Sel.Name == "Wait" && names.of(sel.X) == wgName {
+			return false
+		}
+		if ident, ok := n.(*ast.Ident); ok && names.of(ident) == wgName {
+			found = true
+		}
+		return true
+	})
+	return found
+}
@@ -0,0 +1,307 @@
+package waitgroup
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/report"
+)
+
+// waitState is what a path through the function knows about the workers
+// started on the WaitGroup. States are ordered so that joining two paths
+// keeps the larger.
+type waitState int
+
+const (
+	// stateDead is an unreachable point: the path returned, panicked or
+	// branched away.
+	stateDead waitState = iota
+	// stateIdle has no worker started since the last Wait.
+	stateIdle
+	// statePending may have workers still running.
+	statePending
+)
+
+func joinStates(a, b waitState) waitState {
+	return max(a, b)
+}
+
+// checkWaitNotGuaranteed flags a local WaitGroup whose workers are started
+// and waited on, but where some return after the first Add or Go skips every
+// Wait, typically because the Wait sits inside an if. The function can then
+// return while its workers are still running. Functions whose Wait is
+// missing altogether are left to checkAddWithoutWait.
+func (b *balanceValidator) checkWaitNotGuaranteed(stats map[string]*Stats) {
+	for wgName, st := range stats {
+		if !b.localWaitGroupNames[wgName] || strings.Contains(wgName, ".") || len(st.waitCalls) == 0 {
+			continue
+		}
+		if len(st.goCalls) == 0 && !b.hasGoroutineDone(wgName) {
+			continue
+		}
+		if !b.waitsOnlyInMainFlow(wgName) {
+			continue
+		}
+		if b.escape != nil && b.escape.isWaitGroupPassedToOtherFunctions(wgName) {
+			continue
+		}
+		// A Wait that is never reached is reported as unreachable instead.
+		if b.waitUnreachable(st.waitCalls[0]) {
+			continue
+		}
+
+		starts := b.mainFlowStarts(st)
+		if !waitFollowsStart(st.waitCalls, starts) {
+			continue
+		}
+		walker := &waitPathWalker{wgName: wgName, names: b.names, starts: starts, aborts: b.callAborts}
+		if walker.list(b.function.Body.List, stateIdle) == statePending && !walker.exit.IsValid() {
+			walker.exit = b.function.Body.Rbrace
+		}
+		if walker.unknown || !walker.exit.IsValid() {
+			continue
+		}
+		b.reporter.AddError(st.waitCalls[0], category.WaitNotGuaranteed,
+			report.FormatMessage("waitgroup.wait_not_guaranteed", wgName),
+			report.Related(walker.exit, "function returns here without waiting on '"+wgName+"'"))
+	}
+}
+
+// mainFlowStarts returns the positions of the Add and Go calls on the
+// WaitGroup in the function's own flow.
+func (b *balanceValidator) mainFlowStarts(st *Stats) map[token.Pos]bool {
+	starts := make(map[token.Pos]bool)
+	for _, add := range st.addCalls {
+		if b.isInMainFunctionFlow(add.pos) {
+			starts[add.pos] = true
+		}
+	}
+	for _, goPos := range st.goCalls {
+		if b.isInMainFunctionFlow(goPos) {
+			starts[goPos] = true
+		}
+	}
+	return starts
+}
+
+// waitFollowsStart reports whether some Wait comes after a start in source
+// order. Waits that all precede the workers are Add-after-Wait cases, which
+// have their own check.
+func waitFollowsStart(waits []token.Pos, starts map[token.Pos]bool) bool {
+	for start := range starts {
+		for _, wait := range waits {
+			if wait > start {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// waitsOnlyInMainFlow reports whether every reference to wgName.Wait is a
+// plain or deferred call in the function's own flow. A Wait in a closure or
+// passed as a method value may run on paths the walk cannot see.
+func (b *balanceValidator) waitsOnlyInMainFlow(wgName string) bool {
+	calls := make(map[*ast.SelectorExpr]bool)
+	for _, stmt := range b.collectStmts() {
+		if sel := waitStmtSelector(b.names, stmt, wgName); sel != nil {
+			calls[sel] = true
+		}
+	}
+	ok := true
+	ast.Inspect(b.function.Body, func(n ast.Node) bool {
+		if !ok {
+			return false
+		}
+		sel, isSel := n.(*ast.SelectorExpr)
+		if isSel && sel.Sel.Name == "Wait" && b.names.of(sel.X) == wgName && !calls[sel] {
+			ok = false
+		}
+		return true
+	})
+	return ok
+}
+
+// collectStmts returns the function's statements outside function literals.
+func (b *balanceValidator) collectStmts() []ast.Stmt {
+	var stmts []ast.Stmt
+	ast.Inspect(b.function.Body, func(n ast.Node) bool {
+		switch s := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case ast.Stmt:
+			stmts = append(stmts, s)
+		}
+		return true
+	})
+	return stmts
+}
+
+// waitStmtSelector returns the wgName.Wait selector of stmt when stmt is
+// wgName.Wait() or defer wgName.Wait().
+func waitStmtSelector(names varNames, stmt ast.Stmt, wgName string) *ast.SelectorExpr {
+	var call *ast.CallExpr
+	switch s := stmt.(type) {
+	case *ast.ExprStmt:
+		call, _ = s.X.(*ast.CallExpr)
+	case *ast.DeferStmt:
+		call = s.Call
+	}
+	if call == nil {
+		return nil
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Wait" || names.of(sel.X) != wgName {
+		return nil
+	}
+	return sel
+}
+
+// waitPathWalker follows the statements of a function, tracking whether
+// workers started by an Add or Go may still be running, and records the
+// first return reached in that state. A Done in the function's own flow
+// hands back what the path added, as in an Add undone before an early
+// return. Loops are assumed to run at least once: a Wait inside a loop body
+// covers the code after it, leaving zero-iteration paths to the loop checks.
+type waitPathWalker struct {
+	wgName string
+	names  varNames
+	starts map[token.Pos]bool
+	aborts func(*ast.CallExpr) bool
+
+	// exit is the first return reached with workers pending.
+	exit token.Pos
+	// unknown is set on goto, labeled branches and fallthrough, which the
+	// walk does not follow.
+	unknown bool
+	// deferred is set once a deferred Wait covers every later return.
+	deferred bool
+	// breaks and continues collect the states reaching the innermost
+	// enclosing break and continue targets.
+	breaks, continues []waitState
+}
+
+func (w *waitPathWalker) list(stmts []ast.Stmt, in waitState) waitState {
+	state := in
+	for _, stmt := range stmts {
+		if state == stateDead || w.unknown {
+			return stateDead
+		}
+		state = w.stmt(stmt, state)
+	}
+	return state
+}
+
+func (w *waitPathWalker) stmt(stmt ast.Stmt, in waitState) waitState {
+	switch s := stmt.(type) {
+	case *ast.ExprStmt:
+		call, ok := s.X.(*ast.CallExpr)
+		if !ok {
+			return in
+		}
+		switch {
+		case waitStmtSelector(w.names, s, w.wgName) != nil:
+			return stateIdle
+		case w.aborts != nil && w.aborts(call):
+			return stateDead
+		case w.starts[call.Pos()] && !w.deferred:
+			return statePending
+		case w.isDone(call):
+			return stateIdle
+		}
+	case *ast.DeferStmt:
+		if waitStmtSelector(w.names, s, w.wgName) != nil {
+			w.deferred = true
+			return stateIdle
+		}
+	case *ast.ReturnStmt:
+		if in == statePending && !w.exit.IsValid() {
+			w.exit = s.Pos()
+		}
+		return stateDead
+	case *ast.BranchStmt:
+		switch {
+		case s.Label != nil || s.Tok == token.GOTO || s.Tok == token.FALLTHROUGH:
+			w.unknown = true
+		case s.Tok == token.BREAK && len(w.breaks) > 0:
+			w.breaks[len(w.breaks)-1] = joinStates(w.breaks[len(w.breaks)-1], in)
+		case s.Tok == token.CONTINUE && len(w.continues) > 0:
+			w.continues[len(w.continues)-1] = joinStates(w.continues[len(w.continues)-1], in)
+		}
+		return stateDead
+	case *ast.BlockStmt:
+		return w.list(s.List, in)
+	case *ast.LabeledStmt:
+		return w.stmt(s.Stmt, in)
+	case *ast.IfStmt:
+		els := in
+		if s.Else != nil {
+			els = w.stmt(s.Else, in)
+		}
+		return joinStates(w.list(s.Body.List, in), els)
+	case *ast.SwitchStmt:
+		return w.clauses(s.Body, in, false)
+	case *ast.TypeSwitchStmt:
+		return w.clauses(s.Body, in, false)
+	case *ast.SelectStmt:
+		return w.clauses(s.Body, in, true)
+	case *ast.ForStmt:
+		return w.loop(s.Body, in, s.Cond == nil)
+	case *ast.RangeStmt:
+		return w.loop(s.Body, in, false)
+	}
+	return in
+}
+
+// clauses walks the cases of a switch or select from the state in. When no
+// case must run (a switch without default) control may also skip them all.
+func (w *waitPathWalker) clauses(body *ast.BlockStmt, in waitState, exhaustive bool) waitState {
+	w.breaks = append(w.breaks, stateDead)
+	out := stateDead
+	for _, clause := range body.List {
+		switch c := clause.(type) {
+		case *ast.CaseClause:
+			exhaustive = exhaustive || c.List == nil
+			out = joinStates(out, w.list(c.Body, in))
+		case *ast.CommClause:
+			out = joinStates(out, w.list(c.Body, in))
+		}
+	}
+	if !exhaustive {
+		out = joinStates(out, in)
+	}
+	out = joinStates(out, w.breaks[len(w.breaks)-1])
+	w.breaks = w.breaks[:len(w.breaks)-1]
+	return out
+}
+
+// loop walks body until the state at its head settles. An infinite loop is
+// left only through break.
+func (w *waitPathWalker) loop(body *ast.BlockStmt, in waitState, infinite bool) waitState {
+	w.breaks = append(w.breaks, stateDead)
+	w.continues = append(w.continues, stateDead)
+	head, end := in, stateDead
+	for {
+		end = w.list(body.List, head)
+		next := joinStates(in, joinStates(end, w.continues[len(w.continues)-1]))
+		if next == head {
+			break
+		}
+		head = next
+	}
+	out := w.breaks[len(w.breaks)-1]
+	if !infinite {
+		out = joinStates(out, joinStates(end, w.continues[len(w.continues)-1]))
+	}
+	w.breaks = w.breaks[:len(w.breaks)-1]
+	w.continues = w.continues[:len(w.continues)-1]
+	return out
+}
+
+// isDone reports whether call is wgName.Done().
+func (w *waitPathWalker) isDone(call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == "Done" && w.names.of(sel.X) == w.wgName
+}
@@ -0,0 +1,79 @@
+package waitgroup
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/report"
+)
+
+// checkWaitUnreachable flags a Wait in the function's own flow that follows a
+// statement which always leaves it — a panic, runtime.Goexit, return, break
+// or goto earlier in the same list or in an enclosing one. The Wait never
+// runs, so the goroutines it was meant to join are never waited for. A
+// labeled statement between the two may be a goto target, so it ends the
+// search.
+func (c *Checker) checkWaitUnreachable(stats map[string]*Stats, balance *balanceValidator) {
+	for wgName, st := range stats {
+		for _, waitPos := range st.waitCalls {
+			if !balance.isInMainFunctionFlow(waitPos) {
+				continue
+			}
+			term := c.worker.terminatorBefore(waitPos)
+			if term == nil {
+				continue
+			}
+			c.errorCollector.AddError(waitPos, category.WaitUnreachable,
+				"waitgroup '"+wgName+"' Wait unreachable",
+				report.Related(term.Pos(), "execution always leaves here"))
+		}
+	}
+}
+
+// terminatorBefore returns the statement that makes pos unreachable: one for
+// which isTerminatingStatement holds, sitting before the statement holding
+// pos in any statement list of the function's own flow that encloses it. It
+// returns nil when pos is reachable.
+func (w *workerDoneAnalyzer) terminatorBefore(pos token.Pos) ast.Stmt {
+	var term ast.Stmt
+	ast.Inspect(w.function.Body, func(n ast.Node) bool {
+		if term != nil || n == nil || !nodeContainsPos(n, pos) {
+			return false
+		}
+		if _, ok := n.(*ast.FuncLit); ok {
+			return false
+		}
+		stmts := stmtListOf(n)
+		for i, stmt := range stmts {
+			if !nodeContainsPos(stmt, pos) {
+				continue
+			}
+			term = w.terminatorInList(stmts[:i+1])
+			break // Let Inspect descend to the directly-enclosing list.
+		}
+		return true
+	})
+	return term
+}
+
+// waitUnreachable reports whether the Wait at waitPos is never reached.
+func (w *workerDoneAnalyzer) waitUnreachable(waitPos token.Pos) bool {
+	return w.terminatorBefore(waitPos) != nil
+}
+
+// terminatorInList returns the first terminating statement in stmts, excluding
+// the last one, that no later labeled statement follows, or nil. A goto cannot
+// jump into a block, so only labels in stmts itself matter.
+func (w *workerDoneAnalyzer) terminatorInList(stmts []ast.Stmt) ast.Stmt {
+	var term ast.Stmt
+	for i, stmt := range stmts {
+		if _, ok := stmt.(*ast.LabeledStmt); ok {
+			term = nil
+		}
+		if i < len(stmts)-1 && term == nil && w.isTerminatingStatement(stmt) {
+			term = stmt
+		}
+	}
+	return term
+}
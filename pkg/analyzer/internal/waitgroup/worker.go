@@ -19,6 +19,7 @@ type workerDoneAnalyzer struct {
 	commentFilter  *commentfilter.CommentFilter
 	typesInfo      *types.Info
 	errorCollector report.Reporter
+	names          varNames
 
 	// workerCanRecover is set per worker goroutine before its body is scanned
 	// for a non-deferred Done. It records whether that goroutine installs a
@@ -0,0 +1,26 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/common/category"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// optInChecks lists every opt-in code exercised by the optin fixtures.
+const optInChecks = "GCL1014,GCL1015,GCL1019,GCL1021,GCL1022,GCL1024,GCL2019,GCL2021,GCL2022,GCL2024,GCL2025,GCL2026,GCL5002"
+
+// TestEnabledOptInRejectsUnknownAndDefaultChecks pins the -enable parsing:
+// codes and slugs are accepted, anything else fails the run.
+func TestEnabledOptInRejectsUnknownAndDefaultChecks(t *testing.T) {
+	enabled, err := enabledOptIn(" GCL5002 , pool-get-without-put,")
+	require.NoError(t, err)
+	assert.Equal(t, map[category.Category]bool{category.PoolGetWithoutPut: true}, enabled)
+
+	_, err = enabledOptIn("GCL9999")
+	assert.ErrorContains(t, err, "unknown check")
+
+	_, err = enabledOptIn("GCL1001")
+	assert.ErrorContains(t, err, "not opt-in")
+}
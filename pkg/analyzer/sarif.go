@@ -0,0 +1,133 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"slices"
+
+	"github.com/sanbricio/goconcurrencylint/pkg/analyzer/internal/config"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	repoURL      = "https://github.com/sanbricio/goconcurrencylint"
+)
+
+// WriteSARIF writes findings to w as a SARIF 2.1.0 log with a single run,
+// the format GitHub code scanning uploads. Each check a finding carries
+// becomes a rule whose id is its code (GCL1001); a finding whose category is
+// not in the catalogue still gets a rule, with the category as its id.
+// Artifact URIs are the findings' file names, relative to their module root
+// when ReportRelativePaths is set, with forward slashes.
+func WriteSARIF(findings []Finding, w io.Writer) error {
+	var codes []string
+	for _, f := range findings {
+		if !slices.Contains(codes, f.Category) {
+			codes = append(codes, f.Category)
+		}
+	}
+	slices.Sort(codes)
+
+	rules := make([]sarifRule, len(codes))
+	for i, code := range codes {
+		rules[i] = sarifRule{ID: code}
+		if c, ok := Lookup(code); ok {
+			rules[i].Name = c.Slug
+			rules[i].ShortDescription = &sarifText{Text: c.Summary}
+			rules[i].HelpURI = repoURL + "/blob/main/docs/checks/" + c.Code + ".md"
+		}
+	}
+
+	results := make([]sarifResult, len(findings))
+	for i, f := range findings {
+		level := "error"
+		if f.Severity == config.SeverityWarning {
+			level = "warning"
+		}
+		results[i] = sarifResult{
+			RuleID:    f.Category,
+			RuleIndex: slices.Index(codes, f.Category),
+			Level:     level,
+			Message:   sarifText{Text: f.Message},
+			Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(reportPath(f.Position.Filename))},
+				Region:           sarifRegion{StartLine: f.Position.Line, StartColumn: f.Position.Column},
+			}}},
+		}
+	}
+
+	log := sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: sarifDriver{Name: "goconcurrencylint", InformationURI: repoURL, Rules: rules}},
+			Results: results,
+		}},
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
+
+// The sarif* types mirror the subset of the SARIF 2.1.0 object model that
+// WriteSARIF emits.
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string     `json:"id"`
+	Name             string     `json:"name,omitempty"`
+	ShortDescription *sarifText `json:"shortDescription,omitempty"`
+	HelpURI          string     `json:"helpUri,omitempty"`
+}
+
+type sarifText struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifText       `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
@@ -0,0 +1,96 @@
+package analyzer
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWriteSARIF checks the log carries one run whose results point at their
+// check's rule by id and index.
+func TestWriteSARIF(t *testing.T) {
+	findings := RunOnSource(t, `package p
+
+import "sync"
+
+func leak() {
+	var mu sync.Mutex
+	mu.Lock()
+}
+
+func wait() {
+	var wg sync.WaitGroup
+	wg.Wait()
+}
+`)
+	require.Len(t, findings, 2)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteSARIF(findings, &buf))
+
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name  string `json:"name"`
+					Rules []struct {
+						ID      string `json:"id"`
+						Name    string `json:"name"`
+						HelpURI string `json:"helpUri"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				RuleIndex int    `json:"ruleIndex"`
+				Level     string `json:"level"`
+				Message   struct {
+					Text string `json:"text"`
+				} `json:"message"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+						Region struct {
+							StartLine int `json:"startLine"`
+						} `json:"region"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+
+	assert.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+	assert.Equal(t, "goconcurrencylint", run.Tool.Driver.Name)
+	require.Len(t, run.Results, 2)
+	for i, res := range run.Results {
+		assert.Equal(t, findings[i].Category, res.RuleID)
+		require.Less(t, res.RuleIndex, len(run.Tool.Driver.Rules))
+		assert.Equal(t, res.RuleID, run.Tool.Driver.Rules[res.RuleIndex].ID)
+		assert.Equal(t, "error", res.Level)
+		assert.Equal(t, findings[i].Message, res.Message.Text)
+		require.Len(t, res.Locations, 1)
+		assert.Equal(t, "source.go", res.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+		assert.Equal(t, findings[i].Position.Line, res.Locations[0].PhysicalLocation.Region.StartLine)
+	}
+	assert.Equal(t, "GCL1001", run.Results[0].RuleID)
+	assert.Equal(t, "lock-without-unlock", run.Tool.Driver.Rules[run.Results[0].RuleIndex].Name)
+	assert.Contains(t, run.Tool.Driver.Rules[run.Results[0].RuleIndex].HelpURI, "docs/checks/GCL1001.md")
+}
+
+// TestWriteSARIFNoFindings checks an empty report is still a valid log with
+// empty results rather than null.
+func TestWriteSARIFNoFindings(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteSARIF(nil, &buf))
+	assert.Contains(t, buf.String(), `"results": []`)
+	assert.Contains(t, buf.String(), `"rules": []`)
+}
@@ -0,0 +1,193 @@
+package analyzer
+
+import (
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"slices"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// RunOnSource type-checks src as a single-file package and returns the
+// findings the Analyzer reports on it, sorted by position. It is meant for
+// regression tests that are quicker to state inline than as a testdata
+// fixture:
+//
+//	findings := analyzer.RunOnSource(t, `package p
+//
+//	import "sync"
+//
+//	func f(mu *sync.Mutex) { mu.Lock() }`)
+//
+// Only "sync" may be imported, and it resolves to a stub declaring the
+// exported API of the real package. Flags already set on Analyzer (e.g.
+// -enable) apply as usual. Parse, type and analysis errors fail t.
+func RunOnSource(t testing.TB, src string) []Finding {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "source.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("RunOnSource: %v", err)
+	}
+	info := &types.Info{
+		Types:        make(map[ast.Expr]types.TypeAndValue),
+		Instances:    make(map[*ast.Ident]types.Instance),
+		Defs:         make(map[*ast.Ident]types.Object),
+		Uses:         make(map[*ast.Ident]types.Object),
+		Implicits:    make(map[ast.Node]types.Object),
+		Selections:   make(map[*ast.SelectorExpr]*types.Selection),
+		Scopes:       make(map[ast.Node]*types.Scope),
+		FileVersions: make(map[*ast.File]string),
+	}
+	conf := types.Config{Importer: &syncStubImporter{fset: fset}}
+	pkg, err := conf.Check(file.Name.Name, fset, []*ast.File{file}, info)
+	if err != nil {
+		t.Fatalf("RunOnSource: %v", err)
+	}
+
+	base := analysis.Pass{
+		Fset:              fset,
+		Files:             []*ast.File{file},
+		Pkg:               pkg,
+		TypesInfo:         info,
+		TypesSizes:        types.SizesFor("gc", "amd64"),
+		Report:            func(analysis.Diagnostic) {},
+		ImportObjectFact:  func(types.Object, analysis.Fact) bool { return false },
+		ImportPackageFact: func(*types.Package, analysis.Fact) bool { return false },
+		ExportObjectFact:  func(types.Object, analysis.Fact) {},
+		ExportPackageFact: func(analysis.Fact) {},
+		AllObjectFacts:    func() []analysis.ObjectFact { return nil },
+		AllPackageFacts:   func() []analysis.PackageFact { return nil },
+	}
+	res, err := runWithRequires(Analyzer, base, make(map[*analysis.Analyzer]any))
+	if err != nil {
+		t.Fatalf("RunOnSource: %v", err)
+	}
+	findings := slices.Clone(res.(Findings))
+	slices.SortStableFunc(findings, func(a, b Finding) int {
+		return cmp.Compare(a.Pos, b.Pos)
+	})
+	return findings
+}
+
+// runWithRequires runs a after every analyzer it requires, caching each
+// result in done so a shared dependency runs once.
+func runWithRequires(a *analysis.Analyzer, base analysis.Pass, done map[*analysis.Analyzer]any) (any, error) {
+	if res, ok := done[a]; ok {
+		return res, nil
+	}
+	resultOf := make(map[*analysis.Analyzer]any, len(a.Requires))
+	for _, req := range a.Requires {
+		res, err := runWithRequires(req, base, done)
+		if err != nil {
+			return nil, err
+		}
+		resultOf[req] = res
+	}
+	pass := base
+	pass.Analyzer = a
+	pass.ResultOf = resultOf
+	res, err := a.Run(&pass)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", a.Name, err)
+	}
+	done[a] = res
+	return res, nil
+}
+
+// syncStubImporter resolves "sync" to syncStubSource and rejects every other
+// import, keeping RunOnSource independent of the local Go installation.
+type syncStubImporter struct {
+	fset *token.FileSet
+	sync *types.Package
+}
+
+func (imp *syncStubImporter) Import(path string) (*types.Package, error) {
+	if path != "sync" {
+		return nil, fmt.Errorf("RunOnSource can only import \"sync\", not %q", path)
+	}
+	if imp.sync != nil {
+		return imp.sync, nil
+	}
+	file, err := parser.ParseFile(imp.fset, "sync.go", syncStubSource, 0)
+	if err != nil {
+		return nil, err
+	}
+	var conf types.Config
+	pkg, err := conf.Check("sync", imp.fset, []*ast.File{file}, nil)
+	if err != nil {
+		return nil, err
+	}
+	imp.sync = pkg
+	return pkg, nil
+}
+
+// syncStubSource declares the exported API of package sync with empty
+// bodies; only the types and method sets matter to the analysis.
+const syncStubSource = `package sync
+
+type Locker interface {
+	Lock()
+	Unlock()
+}
+
+type Mutex struct{ state int32 }
+
+func (m *Mutex) Lock()         {}
+func (m *Mutex) Unlock()       {}
+func (m *Mutex) TryLock() bool { return false }
+
+type RWMutex struct{ w Mutex }
+
+func (rw *RWMutex) Lock()           {}
+func (rw *RWMutex) Unlock()         {}
+func (rw *RWMutex) TryLock() bool   { return false }
+func (rw *RWMutex) RLock()          {}
+func (rw *RWMutex) RUnlock()        {}
+func (rw *RWMutex) TryRLock() bool  { return false }
+func (rw *RWMutex) RLocker() Locker { return nil }
+
+type WaitGroup struct{ state uint64 }
+
+func (wg *WaitGroup) Add(delta int) {}
+func (wg *WaitGroup) Done()         {}
+func (wg *WaitGroup) Wait()         {}
+func (wg *WaitGroup) Go(f func())   {}
+
+type Once struct{ done uint32 }
+
+func (o *Once) Do(f func()) {}
+
+func OnceFunc(f func()) func() { return f }
+
+type Cond struct{ L Locker }
+
+func NewCond(l Locker) *Cond { return &Cond{L: l} }
+func (c *Cond) Wait()        {}
+func (c *Cond) Signal()      {}
+func (c *Cond) Broadcast()   {}
+
+type Pool struct{ New func() any }
+
+func (p *Pool) Get() any  { return nil }
+func (p *Pool) Put(x any) {}
+
+type Map struct{ m map[any]any }
+
+func (m *Map) Load(key any) (value any, ok bool)                    { return nil, false }
+func (m *Map) Store(key, value any)                                 {}
+func (m *Map) LoadOrStore(key, value any) (actual any, loaded bool) { return nil, false }
+func (m *Map) LoadAndDelete(key any) (value any, loaded bool)       { return nil, false }
+func (m *Map) Delete(key any)                                       {}
+func (m *Map) Swap(key, value any) (previous any, loaded bool)      { return nil, false }
+func (m *Map) CompareAndSwap(key, old, new any) (swapped bool)      { return false }
+func (m *Map) CompareAndDelete(key, old any) (deleted bool)         { return false }
+func (m *Map) Range(f func(key, value any) bool)                    {}
+func (m *Map) Clear()                                               {}
+`
@@ -0,0 +1,57 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRunOnSourceReportsLeak checks that only the unbalanced function of the
+// source is reported.
+func TestRunOnSourceReportsLeak(t *testing.T) {
+	findings := RunOnSource(t, `package p
+
+import "sync"
+
+func leak() {
+	var mu sync.Mutex
+	mu.Lock()
+}
+
+func balanced() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+	}()
+	wg.Wait()
+}
+`)
+
+	require.Len(t, findings, 1)
+	assert.Equal(t, "GCL1001", findings[0].Category)
+	assert.Equal(t, "mutex 'mu' is locked but not unlocked", findings[0].Message)
+}
+
+// TestRunOnSourceSortsByPosition checks that findings from different
+// sub-analyzers come back in source order.
+func TestRunOnSourceSortsByPosition(t *testing.T) {
+	findings := RunOnSource(t, `package p
+
+import "sync"
+
+func f() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	wg.Wait()
+	var mu sync.RWMutex
+	mu.RLock()
+}
+`)
+
+	require.Len(t, findings, 2)
+	assert.Equal(t, "GCL2001", findings[0].Category)
+	assert.Equal(t, "rwmutex 'mu' is rlocked but not runlocked", findings[1].Message)
+	assert.Less(t, findings[0].Pos, findings[1].Pos)
+}
@@ -0,0 +1,13 @@
+package analyzer
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// TestSuggestedFixes applies every suggested fix in the waitgroupfix fixtures
+// and compares the result with the .golden files next to them.
+func TestSuggestedFixes(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), Analyzer, "waitgroupfix")
+}
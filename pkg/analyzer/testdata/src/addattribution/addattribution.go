@@ -0,0 +1,51 @@
+package addattribution
+
+import "sync"
+
+// Run with -add-attribution=last: the shortfall is pinned on the latest Adds.
+
+// One unit is missing; the default strategy blames Add(2), "last" the Add(1).
+func BadLastAttributionReportsLatestAdd() {
+	var wg sync.WaitGroup
+	wg.Add(2)
+	wg.Add(1) // want "waitgroup 'wg' has Add without corresponding Done \\(3 added, 2 done; reported at the last Adds the Dones cannot cover\\)"
+	wg.Done()
+	wg.Done()
+	wg.Wait()
+}
+
+// The Done matches the first Add, so the trailing Add is reported.
+func BadLastAttributionReportsTrailingAdd() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	wg.Add(1) // want "waitgroup 'wg' has Add without corresponding Done \\(2 added, 1 done; reported at the last Adds the Dones cannot cover\\)"
+	wg.Done()
+	wg.Wait()
+}
+
+// A shortfall wider than the last Add spreads back over earlier Adds.
+func BadLastAttributionSpansSeveralAdds() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	wg.Add(1) // want "waitgroup 'wg' has Add without corresponding Done \\(3 added, 1 done"
+	wg.Add(1) // want "waitgroup 'wg' has Add without corresponding Done \\(3 added, 1 done"
+	wg.Done()
+	wg.Wait()
+}
+
+// A lone Add keeps the plain message.
+func BadLastAttributionSingleAdd() {
+	var wg sync.WaitGroup
+	wg.Add(1) // want "waitgroup 'wg' has Add without corresponding Done$"
+	wg.Wait()
+}
+
+func GoodLastAttributionBalanced() {
+	var wg sync.WaitGroup
+	wg.Add(2)
+	wg.Add(1)
+	wg.Done()
+	wg.Done()
+	wg.Done()
+	wg.Wait()
+}
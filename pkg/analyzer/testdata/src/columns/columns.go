@@ -0,0 +1,30 @@
+package columns
+
+import "sync"
+
+type inner struct {
+	mu sync.Mutex
+	wg sync.WaitGroup
+}
+
+type service struct {
+	inner inner
+}
+
+func ChainedLockNotUnlocked(s *service) {
+	s.inner.mu.Lock() // want "mutex 's.inner.mu' is locked but not unlocked"
+}
+
+func ChainedAddWithoutDone(s *service) {
+	var wg sync.WaitGroup
+	wg.Add(1) // want "waitgroup 'wg' has Add without corresponding Done"
+	wg.Wait()
+	s.inner.mu.Lock()
+	s.inner.mu.Unlock()
+}
+
+func ChainedLocalAddWithoutDone() {
+	var s service
+	s.inner.wg.Add(1) // want "waitgroup 's.inner.wg' has Add without corresponding Done"
+	s.inner.wg.Wait()
+}
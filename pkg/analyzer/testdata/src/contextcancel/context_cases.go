@@ -0,0 +1,110 @@
+package contextcancel
+
+import (
+	"context"
+	"time"
+)
+
+// ========== cancel-not-called (GCL7001) ==========
+//
+// context.WithCancel, WithTimeout and WithDeadline return a cancel function
+// that releases the derived context. If it is never called the context, and
+// the timer behind a deadline, live until the parent is cancelled.
+
+// --- Bad: the cancel function is never called ---
+
+func BadCancelSilenced(parent context.Context) error {
+	ctx, cancel := context.WithCancel(parent) // want "context cancel function 'cancel' never called \\(leak\\)"
+	_ = cancel
+	return ctx.Err()
+}
+
+func BadTimeoutCancelSilenced(parent context.Context) {
+	ctx, stop := context.WithTimeout(parent, time.Second) // want "context cancel function 'stop' never called \\(leak\\)"
+	_ = stop
+	<-ctx.Done()
+}
+
+func BadDeadlineVarDecl(parent context.Context) {
+	var ctx, cancel = context.WithDeadline(parent, time.Now().Add(time.Minute)) // want "context cancel function 'cancel' never called \\(leak\\)"
+	_ = cancel
+	<-ctx.Done()
+}
+
+func BadCancelCauseSilenced(parent context.Context) error {
+	ctx, cancel := context.WithCancelCause(parent) // want "context cancel function 'cancel' never called \\(leak\\)"
+	_ = cancel
+	return ctx.Err()
+}
+
+// Reassigning the cancel does not release the first context.
+func BadCancelReassignedNeverCalled(parent context.Context) {
+	ctx, cancel := context.WithCancel(parent) // want "context cancel function 'cancel' never called \\(leak\\)"
+	ctx, cancel = context.WithTimeout(ctx, time.Second)
+	_ = cancel
+	<-ctx.Done()
+}
+
+// --- Good: the cancel function is called, deferred or handed off ---
+
+func GoodDeferCancel(parent context.Context) error {
+	ctx, cancel := context.WithTimeout(parent, time.Second)
+	defer cancel()
+	return ctx.Err()
+}
+
+func GoodCancelCalledOnOnePath(parent context.Context, fail bool) error {
+	ctx, cancel := context.WithCancel(parent)
+	if fail {
+		cancel()
+		return nil
+	}
+	return ctx.Err()
+}
+
+func GoodCancelInGoroutine(parent context.Context) context.Context {
+	ctx, cancel := context.WithCancel(parent)
+	go func() {
+		time.Sleep(time.Second)
+		cancel()
+	}()
+	return ctx
+}
+
+func GoodCancelReturned(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	return ctx, cancel
+}
+
+type holder struct {
+	stop context.CancelFunc
+}
+
+func GoodCancelStored(parent context.Context, h *holder) context.Context {
+	ctx, cancel := context.WithCancel(parent)
+	h.stop = cancel
+	return ctx
+}
+
+func GoodCancelPassedOn(parent context.Context) {
+	ctx, cancel := context.WithCancel(parent)
+	context.AfterFunc(ctx, cancel)
+}
+
+func GoodCancelCauseCalled(parent context.Context, err error) error {
+	ctx, cancel := context.WithCancelCause(parent)
+	cancel(err)
+	return ctx.Err()
+}
+
+// Discarding the cancel with _ is left to go vet's lostcancel check.
+func GoodCancelBlank(parent context.Context) context.Context {
+	ctx, _ := context.WithCancel(parent)
+	return ctx
+}
+
+func GoodCancelIgnored(parent context.Context) error {
+	ctx, cancel := context.WithCancel(parent) // goconcurrencylint:ignore GCL7001
+	_ = cancel
+	return ctx.Err()
+}
@@ -0,0 +1,44 @@
+package customlocker
+
+// Semaphore is a team-built locker with Acquire/Release instead of
+// Lock/Unlock. The .goconcurrencylint.yaml next to this file names it under
+// locker-types, so its balance is checked like a sync.Mutex.
+type Semaphore struct {
+	slots chan struct{}
+}
+
+func (s *Semaphore) Acquire() { s.slots <- struct{}{} }
+func (s *Semaphore) Release() { <-s.slots }
+
+type Store struct {
+	sem  Semaphore
+	data map[string]int
+}
+
+func GoodAcquireRelease(sem *Semaphore) {
+	sem.Acquire()
+	sem.Release()
+}
+
+func GoodDeferRelease(s *Store, key string) int {
+	s.sem.Acquire()
+	defer s.sem.Release()
+	return s.data[key]
+}
+
+func BadAcquireWithoutRelease(s *Store, key string) int {
+	s.sem.Acquire() // want "mutex 's.sem' is locked but not unlocked"
+	return s.data[key]
+}
+
+func BadReleaseWithoutAcquire(sem *Semaphore) {
+	sem.Release() // want "mutex 'sem' is unlocked but not locked"
+}
+
+func BadDoubleAcquire() {
+	var sem Semaphore
+	sem.Acquire()
+	sem.Acquire() // want "mutex 'sem' is re-locked before unlock"
+	sem.Release()
+	sem.Release()
+}
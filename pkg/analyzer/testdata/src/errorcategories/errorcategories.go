@@ -0,0 +1,13 @@
+package errorcategories
+
+import "sync"
+
+func Leak() {
+	var mu sync.Mutex
+	mu.Lock() // want "GCL1001 \\[warning\\]: mutex 'mu' is locked but not unlocked"
+}
+
+func UnlockOnly() {
+	var mu sync.Mutex
+	mu.Unlock() // want "^GCL1002: mutex 'mu' is unlocked but not locked"
+}
@@ -0,0 +1,11 @@
+package exclude
+
+import "sync"
+
+// Run by exclude_test.go with -exclude "vendored_*.go": this file is still
+// analyzed, so its diagnostics are reported.
+
+func BadLockInIncludedFile() {
+	var mu sync.Mutex
+	mu.Lock() // want "mutex 'mu' is locked but not unlocked"
+}
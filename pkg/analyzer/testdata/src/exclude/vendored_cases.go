@@ -0,0 +1,23 @@
+package exclude
+
+import "sync"
+
+// Matched by -exclude "vendored_*.go", so none of these bugs are reported and
+// the file deliberately carries no want markers.
+
+func BadLockInExcludedFile() {
+	var mu sync.Mutex
+	mu.Lock()
+}
+
+func BadWaitGroupInExcludedFile() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	wg.Wait()
+}
+
+type copied struct{ mu sync.Mutex }
+
+func BadCopyInExcludedFile(c copied) {
+	_ = c
+}
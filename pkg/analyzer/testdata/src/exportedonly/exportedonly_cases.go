@@ -0,0 +1,60 @@
+package exportedonly
+
+import "sync"
+
+// Run with -exported-only: only the API surface is analyzed, so the unexported
+// functions and methods below are intentionally buggy but carry no want.
+
+// ---------- Exported Functions ----------
+
+func BadExportedLock() {
+	var mu sync.Mutex
+	mu.Lock() // want "mutex 'mu' is locked but not unlocked"
+}
+
+func BadExportedWaitGroup() {
+	var wg sync.WaitGroup
+	wg.Add(1) // want "waitgroup 'wg' has Add without corresponding Done"
+	wg.Wait()
+}
+
+func badUnexportedLock() {
+	var mu sync.Mutex
+	mu.Lock()
+}
+
+func badUnexportedWaitGroup() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	wg.Wait()
+}
+
+// ---------- Methods ----------
+
+type Store struct {
+	mu sync.Mutex
+}
+
+func (s *Store) BadExportedMethod() {
+	s.mu.Lock() // want "mutex 's.mu' is locked but not unlocked"
+}
+
+func (s *Store) badUnexportedMethod() {
+	s.mu.Lock()
+}
+
+type store struct {
+	mu sync.Mutex
+}
+
+func (s *store) BadExportedMethodOnUnexportedType() {
+	s.mu.Lock()
+}
+
+type Cache[K comparable] struct {
+	mu sync.Mutex
+}
+
+func (c *Cache[K]) BadExportedGenericMethod() {
+	c.mu.Lock() // want "mutex 'c.mu' is locked but not unlocked"
+}
@@ -0,0 +1,14 @@
+package extrasync
+
+// The .goconcurrencylint.yaml next to this file lists syncfork/sync under
+// extra-sync-packages, so its Mutex is checked like a sync.Mutex.
+import "syncfork/sync"
+
+func GoodForkMutex(mu *sync.Mutex) {
+	mu.Lock()
+	defer mu.Unlock()
+}
+
+func BadForkMutexLeak(mu *sync.Mutex) {
+	mu.Lock() // want "mutex 'mu' is locked but not unlocked"
+}
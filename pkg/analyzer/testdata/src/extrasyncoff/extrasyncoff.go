@@ -0,0 +1,9 @@
+package extrasyncoff
+
+// No configuration applies here, so the syncfork/sync Mutex is an ordinary
+// type even when the extrasync package is analyzed in the same run.
+import "syncfork/sync"
+
+func ForkMutexLeak(mu *sync.Mutex) {
+	mu.Lock()
+}
@@ -0,0 +1,21 @@
+package firstonly
+
+import "sync"
+
+// ThreeFindings leaks a lock, releases a read lock it never took and waits
+// on a group nobody adds to. Only the earliest finding carries a want marker,
+// so with -first-only any other diagnostic fails the run.
+func ThreeFindings() {
+	var mu sync.Mutex
+	var rw sync.RWMutex
+	var wg sync.WaitGroup
+	mu.Lock() // want "mutex 'mu' is locked but not unlocked"
+	rw.RUnlock()
+	wg.Wait()
+}
+
+// OneFinding is reported on its own: the limit applies per function.
+func OneFinding() {
+	var mu sync.Mutex
+	mu.Unlock() // want "mutex 'mu' is unlocked but not locked"
+}
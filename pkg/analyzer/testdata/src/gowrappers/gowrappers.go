@@ -0,0 +1,75 @@
+package gowrappers
+
+import "sync"
+
+// pool runs each task on its own goroutine, like errgroup or a supervised
+// worker pool. Its Go method is configured as a wrapper by the test.
+type pool struct{ wg sync.WaitGroup }
+
+func (p *pool) Go(task func()) {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		task()
+	}()
+}
+
+func work() error { return nil }
+
+func MissingDoneInWrapper(p *pool) {
+	var wg sync.WaitGroup
+	wg.Add(1) // want "waitgroup 'wg' has Add without corresponding Done"
+	p.Go(func() {
+		_ = work()
+	})
+	wg.Wait()
+}
+
+func AddInsideWrapper(p *pool) {
+	var wg sync.WaitGroup
+	p.Go(func() {
+		wg.Add(1) // want "waitgroup 'wg' Add called inside goroutine, may race with Wait"
+		defer wg.Done()
+		_ = work()
+	})
+	wg.Wait()
+}
+
+func GoodDeferredDoneInWrapper(p *pool, items []int) {
+	var wg sync.WaitGroup
+	for range items {
+		wg.Add(1)
+		p.Go(func() {
+			defer wg.Done()
+			_ = work()
+		})
+	}
+	wg.Wait()
+}
+
+func GoodWaitGroupGoIsNotAWrapper(mu *sync.Mutex) {
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		mu.Lock()
+		defer mu.Unlock()
+		_ = work()
+	})
+	wg.Wait()
+}
+
+// The inner block shadows wg and is analyzed on its own, so the Done its
+// wrapper literal makes is not credited to the outer wg.
+func BadShadowedOuterWaitGroupWithWrapper(p *pool) {
+	var wg sync.WaitGroup
+	wg.Add(1) // want "waitgroup 'wg' has Add without corresponding Done"
+	{
+		var wg sync.WaitGroup
+		wg.Add(1)
+		p.Go(func() {
+			defer wg.Done()
+			_ = work()
+		})
+		wg.Wait()
+	}
+	wg.Wait()
+}
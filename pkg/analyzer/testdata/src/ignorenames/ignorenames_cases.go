@@ -0,0 +1,36 @@
+package ignorenames
+
+import "sync"
+
+// Run by ignore_names_test.go with -ignore-names "legacyMu,*.cacheMu": the
+// primitives matching a glob are never tracked, so only the others are
+// reported.
+
+var legacyMu sync.Mutex
+
+type store struct {
+	cacheMu sync.Mutex
+	mu      sync.Mutex
+}
+
+func GoodIgnoredPackageMutexLeak() {
+	legacyMu.Lock()
+}
+
+func GoodIgnoredLocalMutexLeak() {
+	var legacyMu sync.Mutex
+	legacyMu.Lock()
+}
+
+func (s *store) GoodIgnoredFieldLeak() {
+	s.cacheMu.Lock()
+}
+
+func BadTrackedMutexLeak() {
+	var mu sync.Mutex
+	mu.Lock() // want "mutex 'mu' is locked but not unlocked"
+}
+
+func (s *store) BadTrackedFieldLeak() {
+	s.mu.Lock() // want "mutex 's.mu' is locked but not unlocked"
+}
@@ -0,0 +1,42 @@
+package lockers
+
+import "sync"
+
+var shared sync.Mutex
+
+func getLock() sync.Locker { return &shared }
+
+func BadLockerLockedWithoutUnlock() {
+	l := getLock()
+	l.Lock() // want "mutex 'l' is locked but not unlocked"
+}
+
+func BadLockerParamLockedWithoutUnlock(l sync.Locker) {
+	l.Lock() // want "mutex 'l' is locked but not unlocked"
+}
+
+func BadLockerUnlockedWithoutLock() {
+	l := getLock()
+	l.Unlock() // want "mutex 'l' is unlocked but not locked"
+}
+
+func GoodLockerDeferredUnlock() {
+	l := getLock()
+	l.Lock()
+	defer l.Unlock()
+}
+
+func GoodLockerBalanced(l sync.Locker) {
+	l.Lock()
+	l.Unlock()
+}
+
+type guarded struct {
+	l sync.Locker
+	n int
+}
+
+func (g *guarded) BadFieldLockerLeak() {
+	g.l.Lock() // want "mutex 'g.l' is locked but not unlocked"
+	g.n++
+}
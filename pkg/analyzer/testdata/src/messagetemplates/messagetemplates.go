@@ -0,0 +1,13 @@
+package messagetemplates
+
+import "sync"
+
+func LockLeak() {
+	var mu sync.Mutex
+	mu.Lock() // want "mu \\(mutex\\) se queda bloqueado"
+}
+
+func WaitWithoutAdd() {
+	var wg sync.WaitGroup
+	wg.Wait() // want "waitgroup 'wg' Wait called without any Add"
+}
@@ -0,0 +1,72 @@
+package methodpairs
+
+import "sync"
+
+// Table wraps its mutex in Lock/Unlock methods, which are recognized as a
+// pair by name with or without -method-pairs.
+type Table struct {
+	mu     sync.Mutex
+	locked bool
+}
+
+func (t *Table) Lock() {
+	t.mu.Lock()
+	t.locked = true
+}
+
+func (t *Table) Unlock() {
+	t.locked = false
+	t.mu.Unlock()
+}
+
+// Store exposes its lock through Begin/End rather than Lock/Unlock, so the
+// wrapper-name heuristic does not recognize the pair. With -method-pairs
+// neither half is reported.
+type Store struct {
+	mu    sync.Mutex
+	items map[string]int
+}
+
+func (s *Store) Begin() {
+	s.mu.Lock()
+	s.items = make(map[string]int)
+}
+
+func (s *Store) End() {
+	clear(s.items)
+	s.mu.Unlock()
+}
+
+// Cache pairs a read lock across methods the same way.
+type Cache struct {
+	mu   sync.RWMutex
+	hits int
+}
+
+func (c *Cache) Open() int {
+	c.mu.RLock()
+	return c.hits
+}
+
+func (c *Cache) Close() {
+	c.hits++
+	c.mu.RUnlock()
+}
+
+// Leaky has no method that only releases mu: Get balances itself, so the
+// lock left held by Reset is still reported.
+type Leaky struct {
+	mu sync.Mutex
+	n  int
+}
+
+func (l *Leaky) Get() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.n
+}
+
+func (l *Leaky) Reset() {
+	l.mu.Lock() // want "mutex 'l.mu' is locked but not unlocked"
+	l.n = 0
+}
@@ -38,6 +38,31 @@ func BadLockWithoutUnlock() {
 	mu.Lock() // want "mutex 'mu' is locked but not unlocked"
 }
 
+// Copy-paste slip: the second mutex is unlocked instead of the one held. Both
+// halves are reported, plus a hint pairing them.
+func BadSwappedUnlock() {
+	var mu1, mu2 sync.Mutex
+	mu1.Lock()   // want "mutex 'mu1' is locked but not unlocked"
+	mu2.Unlock() // want "mutex 'mu2' is unlocked but not locked" "possible swapped Unlock: 'mu2' unlocked where 'mu1' is held"
+}
+
+func BadSwappedRUnlock(data map[string]int) int {
+	var a, b sync.RWMutex
+	a.RLock() // want "rwmutex 'a' is rlocked but not runlocked"
+	v := data["k"]
+	b.RUnlock() // want "rwmutex 'b' is runlocked but not rlocked" "possible swapped RUnlock: 'b' unlocked where 'a' is held"
+	return v
+}
+
+// The stray unlock sits in a different block, so no swap hint is added.
+func BadLockAndUnlockDifferentBlocks(ok bool) {
+	var mu1, mu2 sync.Mutex
+	mu1.Lock() // want "mutex 'mu1' is locked but not unlocked"
+	if ok {
+		mu2.Unlock() // want "mutex 'mu2' is unlocked but not locked"
+	}
+}
+
 // Double lock (lock called twice) without unlock
 func BadDoubleLock() {
 	mu := sync.Mutex{}
@@ -53,6 +78,31 @@ func BadDoubleLockDirectAssign() {
 	mu.Lock()         // want "mutex 'mu' is locked but not unlocked" "mutex 'mu' is re-locked before unlock"
 }
 
+// Bad: the locked mutex is overwritten, so the Unlock hits a fresh mutex
+func BadReassignBetweenLockAndUnlock() {
+	var mu sync.Mutex
+	mu.Lock()
+	mu = sync.Mutex{} // want "mutex 'mu' reassigned while locked; original lock leaked"
+	mu.Unlock()       // want "mutex 'mu' is unlocked but not locked"
+}
+
+// Bad: a read-locked rwmutex pointer is swapped for another one
+func BadReassignRWMutexPointerWhileRLocked(other *sync.RWMutex) {
+	rw := new(sync.RWMutex)
+	rw.RLock()
+	rw = other // want "rwmutex 'rw' reassigned while locked; original lock leaked"
+}
+
+// Good: the mutex is reset only after it has been unlocked
+func GoodReassignAfterUnlock() {
+	var mu sync.Mutex
+	mu.Lock()
+	mu.Unlock()
+	mu = sync.Mutex{}
+	mu.Lock()
+	defer mu.Unlock()
+}
+
 // Imbalanced lock/unlock (more locks than unlocks)
 func BadImbalancedLockUnlock() {
 	var mu sync.Mutex
@@ -87,6 +137,89 @@ func BadDoubleUnlock() {
 	mu.Unlock() // want "mutex 'mu' is unlocked but not locked"
 }
 
+// The double Unlock must survive the branch copy and the merge after it.
+func BadDoubleUnlockInIfBranch(ok bool) {
+	var mu sync.Mutex
+	if ok {
+		mu.Lock()
+		mu.Unlock()
+		mu.Unlock() // want "mutex 'mu' is unlocked but not locked"
+	}
+	mu.Lock()
+	mu.Unlock()
+}
+
+func BadDoubleUnlockInBranchOfHeldLock(ok bool) {
+	var mu sync.Mutex
+	mu.Lock()
+	if ok {
+		mu.Unlock()
+		mu.Unlock() // want "mutex 'mu' is unlocked but not locked"
+		return
+	}
+	mu.Unlock()
+}
+
+func BadDoubleUnlockInElseBranch(ok bool) {
+	var mu sync.Mutex
+	if ok {
+		mu.Lock()
+		mu.Unlock()
+	} else {
+		mu.Lock()
+		mu.Unlock()
+		mu.Unlock() // want "mutex 'mu' is unlocked but not locked"
+	}
+}
+
+func BadDoubleUnlockInNestedBranch(n int) {
+	var mu sync.Mutex
+	for i := 0; i < n; i++ {
+		if i > 2 {
+			mu.Lock()
+			mu.Unlock()
+			mu.Unlock() // want "mutex 'mu' is unlocked but not locked"
+		}
+	}
+	switch n {
+	case 1:
+		mu.Lock()
+		mu.Unlock()
+		mu.Unlock() // want "mutex 'mu' is unlocked but not locked"
+	}
+}
+
+// ---------- Anonymous Struct Fields ----------
+
+// Mutex fields of anonymous structs are keyed by their selector, like named
+// struct fields.
+func GoodAnonymousStructMutex() {
+	s := struct {
+		mu sync.Mutex
+		n  int
+	}{}
+	s.mu.Lock()
+	s.n++
+	s.mu.Unlock()
+}
+
+func GoodNestedAnonymousStructMutex() {
+	var s struct {
+		inner struct{ rw sync.RWMutex }
+	}
+	s.inner.rw.RLock()
+	defer s.inner.rw.RUnlock()
+}
+
+func BadAnonymousStructMutex() {
+	s := &struct{ mu sync.Mutex }{}
+	s.mu.Lock() // want "mutex 's.mu' is locked but not unlocked"
+}
+
+func BadAnonymousStructParamMutex(s *struct{ mu sync.Mutex }) {
+	s.mu.Lock() // want "mutex 's.mu' is locked but not unlocked"
+}
+
 // ---------- Defer Patterns ----------
 
 // Deferred anonymous function that performs its own Lock + defer Unlock.
@@ -112,6 +245,60 @@ func GoodDeferUnlock() {
 	defer mu.Unlock()
 }
 
+// The deferred closure unlocks on both arms of the if/else, so the lock is
+// released whatever the condition.
+func GoodDeferClosureUnlocksOnBothArms(verbose bool) {
+	var mu sync.Mutex
+	mu.Lock()
+	defer func() {
+		if verbose {
+			println("releasing")
+			mu.Unlock()
+		} else {
+			mu.Unlock()
+		}
+	}()
+}
+
+// A loop with break before the unlock does not make it conditional: break
+// stays inside the closure.
+func GoodDeferClosureUnlockAfterLoop(items []int) {
+	var mu sync.RWMutex
+	mu.RLock()
+	defer func() {
+		for _, item := range items {
+			if item < 0 {
+				break
+			}
+		}
+		mu.RUnlock()
+	}()
+}
+
+// The unlock inside the deferred closure only runs when ok is true; on the
+// other path the lock is still held at return.
+func BadDeferClosureConditionalUnlock(ok bool) {
+	var mu sync.Mutex
+	mu.Lock() // want "mutex 'mu' is locked but not unlocked"
+	defer func() {
+		if ok {
+			mu.Unlock()
+		}
+	}()
+}
+
+// An early return inside the deferred closure skips the unlock.
+func BadDeferClosureReturnsBeforeRUnlock(err error) {
+	var mu sync.RWMutex
+	mu.RLock() // want "rwmutex 'mu' is rlocked but not runlocked"
+	defer func() {
+		if err != nil {
+			return
+		}
+		mu.RUnlock()
+	}()
+}
+
 type conditionalBufferedConn struct {
 	mu           sync.Mutex
 	buffered     bool
@@ -289,6 +476,67 @@ func GoodDeferRelockAfterTemporaryUnlock(mu *sync.Mutex) {
 	_ = mu
 }
 
+// A deferred closure that only re-acquires the lock is a defer Lock too.
+func BadDeferClosureOnlyLocks() {
+	var mu sync.Mutex
+	defer func() { // want "mutex 'mu' defer calls Lock instead of Unlock, will deadlock on return"
+		mu.Lock()
+	}()
+}
+
+func BadDeferClosureOnlyRLocks() {
+	var mu sync.RWMutex
+	defer func() { // want "rwmutex 'mu' defer calls RLock instead of RUnlock, will deadlock on return"
+		mu.RLock()
+	}()
+}
+
+// A deferred closure that takes and releases its own lock is a scope of its
+// own: a balanced pair is fine, a lock left held inside it is reported.
+func GoodDeferClosureBalancedLockPair() {
+	var mu sync.Mutex
+	defer func() {
+		mu.Lock()
+		mu.Unlock()
+	}()
+}
+
+func GoodDeferClosureBalancedRLockPair() {
+	var mu sync.RWMutex
+	defer func() {
+		mu.RLock()
+		defer mu.RUnlock()
+	}()
+}
+
+func BadDeferClosureConditionalUnlockOfOwnLock(flush bool) {
+	var mu sync.Mutex
+	defer func() {
+		mu.Lock() // want "mutex 'mu' is locked but not unlocked in deferred closure"
+		if flush {
+			mu.Unlock()
+		}
+	}()
+}
+
+func BadDeferClosureUnbalancedRLockPair() {
+	var mu sync.RWMutex
+	defer func() {
+		mu.RLock()
+		mu.RLock() // want "rwmutex 'mu' is rlocked but not runlocked in deferred closure"
+		mu.RUnlock()
+	}()
+}
+
+// The closure form of the temporary-unlock idiom: the caller's lock is
+// handed back on return.
+func GoodDeferClosureRelockAfterTemporaryUnlock(mu *sync.Mutex) {
+	mu.Unlock()
+	defer func() {
+		mu.Lock()
+	}()
+}
+
 func GoodDeferRWMutexRelockAfterTemporaryUnlock(mu *sync.RWMutex) {
 	mu.Unlock()
 	defer mu.Lock()
@@ -530,3 +778,124 @@ func EdgeCaseElseIf() {
 		mu.Lock() // want "mutex 'mu' is locked but not unlocked in if"
 	}
 }
+
+// An error guard inside the critical section returns while the lock is held.
+func BadErrorGuardReturnsWithLockHeld(fail func() error) error {
+	var mu sync.Mutex
+	mu.Lock() // want "mutex 'mu' is locked but not unlocked"
+	if err := fail(); err != nil {
+		return err
+	}
+	mu.Unlock()
+	return nil
+}
+
+func BadErrorGuardReturnsWithRLockHeld(fail func() error) error {
+	var mu sync.RWMutex
+	mu.RLock() // want "rwmutex 'mu' is rlocked but not runlocked"
+	err := fail()
+	if err != nil {
+		return err
+	}
+	mu.RUnlock()
+	return nil
+}
+
+func GoodErrorGuardUnlocksBeforeReturn(fail func() error) error {
+	var mu sync.Mutex
+	mu.Lock()
+	if err := fail(); err != nil {
+		mu.Unlock()
+		return err
+	}
+	mu.Unlock()
+	return nil
+}
+
+func GoodErrorGuardWithDeferredUnlock(fail func() error) error {
+	var mu sync.Mutex
+	mu.Lock()
+	defer mu.Unlock()
+	if err := fail(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// The Unlock after the panic never runs; a caller that recovers finds the
+// mutex locked.
+func BadPanicSkipsLaterUnlock() {
+	var mu sync.Mutex
+	mu.Lock()
+	panic("fail") // want "mutex 'mu' may remain locked: panic makes the later Unlock unreachable"
+	mu.Unlock()
+}
+
+func BadPanicInBranchSkipsUnlock(fail bool) {
+	var mu sync.RWMutex
+	mu.RLock()
+	if fail {
+		panic("fail") // want "rwmutex 'mu' may remain rlocked: panic makes the later RUnlock unreachable"
+		mu.RUnlock()
+	}
+	mu.RUnlock()
+}
+
+func GoodPanicWithRecoveringUnlock() {
+	var mu sync.Mutex
+	mu.Lock()
+	defer func() {
+		if r := recover(); r != nil {
+			mu.Unlock()
+		}
+	}()
+	panic("fail")
+	mu.Unlock()
+}
+
+func GoodPanicWithDeferredUnlock() {
+	var mu sync.Mutex
+	mu.Lock()
+	defer mu.Unlock()
+	panic("fail")
+	mu.Unlock()
+}
+
+// ========== CONDITIONAL LOCK WITH UNCONDITIONAL DEFER ==========
+
+func BadConditionalLockDeferredUnlock(cond bool) {
+	var mu sync.Mutex
+	if cond {
+		mu.Lock()
+	}
+	defer mu.Unlock() // want "mutex 'mu' defer Unlock but Lock is conditional"
+	println("work")
+}
+
+func BadConditionalRLockDeferredRUnlock(cond bool, mu *sync.RWMutex) {
+	if cond {
+		mu.RLock()
+	}
+	defer mu.RUnlock() // want "rwmutex 'mu' defer RUnlock but RLock is conditional"
+	println("work")
+}
+
+func GoodLockInBothBranchesDeferredUnlock(cond bool) {
+	var mu sync.Mutex
+	if cond {
+		mu.Lock()
+	} else {
+		mu.Lock()
+	}
+	defer mu.Unlock()
+	println("work")
+}
+
+func GoodConditionalLockDeferredInBranch(cond bool) {
+	var mu sync.Mutex
+	if cond {
+		mu.Lock()
+		defer mu.Unlock()
+	}
+	println("work")
+}
@@ -547,6 +547,21 @@ func BadMutexDeclParen(rw *sync.RWMutex) {
 	(rw.RLock()) // want "rwmutex 'rw' is rlocked but not runlocked"
 }
 
+// Bad: named mutex result is locked and never unlocked
+func BadMutexNamedResult() (mu *sync.Mutex) {
+	mu = new(sync.Mutex)
+	mu.Lock() // want "mutex 'mu' is locked but not unlocked"
+	return
+}
+
+// Good: named rwmutex result is locked and unlocked before returning
+func GoodRWMutexNamedResult() (rw *sync.RWMutex, err error) {
+	rw = new(sync.RWMutex)
+	rw.Lock()
+	defer rw.Unlock()
+	return rw, nil
+}
+
 // ========== COPY-BY-VALUE TESTS ==========
 
 func takesMutexByValue(mu sync.Mutex) { // want "mutex 'mu' is copied by value"
@@ -576,6 +591,25 @@ func BadMutexPassedByValue() {
 	takesMutexByValue(mu) // want "mutex 'mu' is copied by value"
 }
 
+// The goroutine locks its own copy, so the caller's mutex is never held.
+func BadMutexPassedToGoroutineByValue() {
+	var mu sync.Mutex
+	go takesMutexByValue(mu) // want "mutex 'mu' copied into goroutine by value"
+}
+
+func BadRWMutexPassedToGoroutineLiteralByValue() {
+	var rw sync.RWMutex
+	go func(r sync.RWMutex) { // want "rwmutex 'r' is copied by value"
+		r.RLock()
+		r.RUnlock()
+	}(rw) // want "rwmutex 'rw' copied into goroutine by value"
+}
+
+func GoodMutexPassedToGoroutineByPointer() {
+	var mu sync.Mutex
+	go takesMutexByPointer(&mu)
+}
+
 func BadMutexAssignedByValue() {
 	var mu sync.Mutex
 	mu.Lock()
@@ -833,7 +867,7 @@ type ValueReceiverCounterWithMutex struct {
 }
 
 func (c ValueReceiverCounterWithMutex) BadValueReceiverWithMutex() { // want "struct 'c' containing mutex is copied by value"
-	c.mu.Lock()
+	c.mu.Lock() // want "mutex 'c.mu' locked on value receiver copy"
 	c.n++
 	c.mu.Unlock()
 }
@@ -844,6 +878,42 @@ func (c *ValueReceiverCounterWithMutex) GoodPointerReceiverWithMutex() {
 	c.mu.Unlock()
 }
 
+type ValueReceiverEmbeddedRWMutex struct {
+	sync.RWMutex
+	inner struct {
+		mu sync.Mutex
+	}
+	n int
+}
+
+func (c ValueReceiverEmbeddedRWMutex) BadValueReceiverEmbeddedRLock() int { // want "struct 'c' containing rwmutex is copied by value"
+	c.RLock() // want "rwmutex 'c' locked on value receiver copy"
+	defer c.RUnlock()
+	return c.n
+}
+
+func (c ValueReceiverEmbeddedRWMutex) BadValueReceiverNestedField() { // want "struct 'c' containing rwmutex is copied by value"
+	c.inner.mu.Lock() // want "mutex 'c.inner.mu' locked on value receiver copy"
+	c.n++
+	c.inner.mu.Unlock()
+}
+
+type ValueReceiverSharedMutex struct {
+	mu *sync.Mutex
+	n  *int
+}
+
+func NewValueReceiverSharedMutex() ValueReceiverSharedMutex {
+	return ValueReceiverSharedMutex{mu: &sync.Mutex{}, n: new(int)}
+}
+
+// The mutex sits behind a pointer, so every copy of the receiver shares it.
+func (c ValueReceiverSharedMutex) GoodValueReceiverPointerMutex() {
+	c.mu.Lock()
+	*c.n++
+	c.mu.Unlock()
+}
+
 // Bad: struct field mutex locked but not unlocked
 func BadStructFieldMutex() {
 	var sm SafeMap
@@ -1812,6 +1882,43 @@ func GoodLockSelectEveryArmUnlocks(ch chan int, done chan struct{}) {
 	}
 }
 
+// Good: a default arm counts like any other arm; every arm unlocks.
+func GoodLockSelectDefaultAndCasesUnlock(ch chan int) {
+	var mu sync.Mutex
+	mu.Lock()
+	select {
+	case <-ch:
+		mu.Unlock()
+	default:
+		mu.Unlock()
+	}
+}
+
+// Bad: the default unlocks but the receive arm keeps the lock, so the unlock
+// is not guaranteed.
+func BadLockSelectOnlyDefaultUnlocks(ch chan int) {
+	var mu sync.Mutex
+	mu.Lock() // want "mutex 'mu' is locked but not unlocked"
+	select {
+	case <-ch:
+	default:
+		mu.Unlock()
+	}
+}
+
+// Bad: the receive arm returns while still holding the lock.
+func BadLockSelectArmReturnsHoldingLock(ch chan int) int {
+	var mu sync.Mutex
+	mu.Lock() // want "mutex 'mu' is locked but not unlocked"
+	select {
+	case v := <-ch:
+		return v
+	default:
+		mu.Unlock()
+	}
+	return 0
+}
+
 // Bad: without a default the switch can match no case and leak the lock.
 func BadLockSwitchNoDefaultLeaks(x int) {
 	var mu sync.Mutex
@@ -1836,6 +1943,114 @@ func BadLockSwitchArmKeepsLock(x int) {
 	}
 }
 
+// Bad: only one case runs, so the Lock in case 1 leaks; the Unlock in case 2
+// is the other half of that split pair and is not reported again as orphaned.
+func BadSwitchLockAndUnlockInDifferentCases(x int) {
+	var mu sync.Mutex
+	switch x {
+	case 1:
+		mu.Lock() // want "mutex 'mu' is locked but not unlocked in case"
+	case 2:
+		mu.Unlock()
+	}
+}
+
+// Bad: same split pair across the arms of a select.
+func BadSelectRLockAndRUnlockInDifferentArms(ch chan int, done chan struct{}) {
+	var rw sync.RWMutex
+	select {
+	case <-ch:
+		rw.RLock() // want "rwmutex 'rw' is rlocked but not runlocked in select"
+	case <-done:
+		rw.RUnlock()
+	}
+}
+
+// Bad: no case leaves the mutex locked, so the Unlock in case 2 is orphaned.
+func BadSwitchUnlockWithoutLockInAnyCase(x int) {
+	var mu sync.Mutex
+	switch x {
+	case 1:
+		mu.Lock()
+		mu.Unlock()
+	case 2:
+		mu.Unlock() // want "mutex 'mu' is unlocked but not locked"
+	}
+}
+
+// Method-value unlocks: `unlock := mu.Unlock` binds the method, so a later
+// `defer unlock()` or `unlock()` releases the lock like the direct call.
+func GoodDeferredMethodValueUnlock() {
+	var mu sync.Mutex
+	unlock := mu.Unlock
+	mu.Lock()
+	defer unlock()
+}
+
+func GoodCalledMethodValueUnlock() {
+	var mu sync.Mutex
+	mu.Lock()
+	unlock := mu.Unlock
+	unlock()
+}
+
+func GoodDeferredMethodValueRUnlock() {
+	var rw sync.RWMutex
+	var runlock = rw.RUnlock
+	rw.RLock()
+	defer runlock()
+}
+
+func GoodMethodValueLockAndUnlock() {
+	var mu sync.Mutex
+	lock, unlock := mu.Lock, mu.Unlock
+	lock()
+	defer unlock()
+}
+
+// Bad: the bound unlock is deferred but the mutex is never locked.
+func BadDeferredMethodValueUnlockWithoutLock() {
+	var mu sync.Mutex
+	unlock := mu.Unlock
+	defer unlock() // want "mutex 'mu' has defer unlock but no corresponding lock"
+}
+
+// Bad: rebinding the variable drops the method value, so the lock leaks.
+func BadMethodValueRebound() {
+	var mu sync.Mutex
+	unlock := mu.Unlock
+	unlock = func() {}
+	mu.Lock() // want "mutex 'mu' is locked but not unlocked"
+	unlock()
+}
+
+// Method-expression calls: `(*sync.Mutex).Lock(&mu)` is `mu.Lock()` with the
+// receiver passed as the first argument.
+func BadMethodExpressionLockWithoutUnlock() {
+	var mu sync.Mutex
+	(*sync.Mutex).Lock(&mu) // want "mutex 'mu' is locked but not unlocked"
+}
+
+func GoodMethodExpressionLockAndDeferredUnlock() {
+	var mu sync.Mutex
+	(*sync.Mutex).Lock(&mu)
+	defer (*sync.Mutex).Unlock(&mu)
+}
+
+func GoodMixedMethodExpressionAndDirectCall() {
+	var rw sync.RWMutex
+	(*sync.RWMutex).RLock(&rw)
+	rw.RUnlock()
+}
+
+type methodExprGuarded struct {
+	mu sync.Mutex
+}
+
+func (g *methodExprGuarded) BadMethodExpressionUnlockWithoutLock() {
+	(*sync.Mutex).Unlock(&g.mu) // want "mutex 'g.mu' is unlocked but not locked"
+}
+
 // Per-iteration mutex used by workers launched with WaitGroup.Go and joined by
 // wg.Wait(); a fresh mutex per iteration is correct, so it must NOT be flagged
 // (thanos pkg/compact/compact.go).
@@ -1861,3 +2076,118 @@ func GoodMutexInLoopJoinedByWaitGroupGo(jobs chan int) {
 		}
 	}
 }
+
+// ---------- Annotated Lock-Returning Functions ----------
+
+type annotatedStore struct {
+	mu    sync.Mutex
+	rw    sync.RWMutex
+	other sync.Mutex
+	n     int
+}
+
+// acquire hands s.mu to the caller, which releases it.
+//
+//goconcurrencylint:locks s.mu
+func (s *annotatedStore) acquire() *annotatedStore {
+	s.mu.Lock()
+	return s
+}
+
+//goconcurrencylint:locks s.rw
+func (s *annotatedStore) snapshot() int {
+	s.rw.RLock()
+	return s.n
+}
+
+// Only the annotated mutex is exempt.
+//
+//goconcurrencylint:locks s.mu
+func (s *annotatedStore) acquireBoth() {
+	s.mu.Lock()
+	s.other.Lock() // want "mutex 's.other' is locked but not unlocked"
+}
+
+func (s *annotatedStore) GoodUseAnnotatedAcquire() {
+	s.acquire()
+	s.n++
+	s.mu.Unlock()
+}
+
+func (s *annotatedStore) BadUnannotatedAcquire() {
+	s.mu.Lock() // want "mutex 's.mu' is locked but not unlocked"
+}
+
+// runtime.Goexit runs deferred calls but skips the rest of the goroutine, so
+// the Unlock below it never runs and every other goroutine blocks on mu.
+func BadGoexitSkipsLaterUnlock(mu *sync.Mutex) {
+	mu.Lock()
+	runtime.Goexit() // want "mutex 'mu' may remain locked: runtime.Goexit makes the later Unlock unreachable"
+	mu.Unlock()
+}
+
+// ---------- Nested Closures in a Deferred Unlock ----------
+
+var deferredReleaseHooks []func()
+
+// The inner closure is stored, not called, so the deferred function never
+// unlocks mu.
+func BadDeferredClosureStoresUnlock() {
+	var mu sync.Mutex
+	mu.Lock() // want "mutex 'mu' is locked but not unlocked"
+	defer func() {
+		deferredReleaseHooks = append(deferredReleaseHooks, func() { mu.Unlock() })
+	}()
+}
+
+func GoodDeferredClosureInvokesInnerUnlock() {
+	var mu sync.Mutex
+	mu.Lock()
+	defer func() {
+		func() { mu.Unlock() }()
+	}()
+}
+
+func GoodDeferredClosureCallsNamedUnlock() {
+	var mu sync.Mutex
+	mu.Lock()
+	defer func() {
+		release := func() { mu.Unlock() }
+		release()
+	}()
+}
+
+// ---------- goto Past Unlock ----------
+
+// The goto jumps over the Unlock, so mu is still held after the label.
+func BadGotoSkipsUnlock(n *int) {
+	var mu sync.Mutex
+	mu.Lock() // want "mutex 'mu' is locked but not unlocked"
+	goto done
+	mu.Unlock()
+done:
+	*n++
+}
+
+func BadConditionalGotoSkipsUnlock(n *int) {
+	var mu sync.Mutex
+	mu.Lock() // want "mutex 'mu' is locked but not unlocked"
+	if *n > 0 {
+		goto done
+	}
+	mu.Unlock()
+done:
+	*n++
+}
+
+// Both paths reach the Unlock after the label.
+func GoodGotoToUnlock(n *int) {
+	var mu sync.Mutex
+	mu.Lock()
+	if *n > 0 {
+		goto done
+	}
+	*n++
+done:
+	mu.Unlock()
+}
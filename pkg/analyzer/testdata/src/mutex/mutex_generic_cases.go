@@ -0,0 +1,67 @@
+package mutex
+
+import "sync"
+
+// ========== GENERIC FUNCTION TESTS ==========
+
+// lockedMutex constrains a type parameter to exactly *sync.Mutex while
+// exposing its Lock/Unlock methods.
+type lockedMutex interface {
+	*sync.Mutex
+	sync.Locker
+}
+
+// lockedRWMutex constrains a type parameter to exactly *sync.RWMutex.
+type lockedRWMutex interface {
+	*sync.RWMutex
+	RLock()
+	RUnlock()
+}
+
+// Good: generic function receives a mutex parameter and unlocks it
+func GoodGenericMutexParameter[T any](mu *sync.Mutex, v T) T {
+	mu.Lock()
+	defer mu.Unlock()
+	return v
+}
+
+// Bad: generic function receives a mutex parameter and forgets to unlock
+func BadGenericMutexParameter[T any](mu *sync.Mutex, v T) T {
+	mu.Lock() // want "mutex 'mu' is locked but not unlocked"
+	return v
+}
+
+// Good: mutex typed by a type parameter whose core type is *sync.Mutex
+func GoodGenericTypeParamMutex[L lockedMutex](mu L) {
+	mu.Lock()
+	mu.Unlock()
+}
+
+// Bad: mutex typed by a type parameter whose core type is *sync.Mutex
+func BadGenericTypeParamMutex[L lockedMutex](mu L) {
+	mu.Lock() // want "mutex 'mu' is locked but not unlocked"
+}
+
+// Bad: rwmutex typed by a type parameter whose core type is *sync.RWMutex
+func BadGenericTypeParamRWMutex[L lockedRWMutex](rw L) {
+	rw.RLock() // want "rwmutex 'rw' is rlocked but not runlocked"
+}
+
+// Edge case: a plain sync.Locker constraint admits many types, so the
+// parameter is not classified as a mutex
+func EdgeCaseGenericLockerConstraint[L sync.Locker](mu L) {
+	mu.Lock()
+}
+
+// Good: generic struct method with a mutex field
+type genericCache[K comparable, V any] struct {
+	mu    sync.Mutex
+	items map[K]V
+}
+
+func (c *genericCache[K, V]) Get(k K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.items[k]
+	return v, ok
+}
@@ -1,6 +1,9 @@
 package mutex
 
-import "sync"
+import (
+	"sync"
+	"time"
+)
 
 // ---------- Goroutine Patterns ----------
 
@@ -55,7 +58,7 @@ func BadGoroutineWaitGroupWaitsBeforeParentUnlocks() {
 		mu.Lock()
 		mu.Unlock()
 	}()
-	wg.Wait()
+	wg.Wait() // want "waitgroup 'wg' Wait called while mutex 'mu' held"
 	mu.Unlock()
 }
 
@@ -305,3 +308,29 @@ func BadGoroutineDeferUnlockWithoutLock() {
 		<-ch
 	}()
 }
+
+// ---------- time.AfterFunc Callbacks ----------
+
+// The callback runs on its own goroutine once the timer fires.
+func BadAfterFuncLockWithoutUnlock() {
+	var mu sync.Mutex
+	time.AfterFunc(time.Second, func() {
+		mu.Lock() // want "mutex 'mu' is locked but not unlocked in goroutine"
+	})
+}
+
+func BadAfterFuncAssignedLockWithoutUnlock() *time.Timer {
+	var mu sync.Mutex
+	t := time.AfterFunc(time.Second, func() {
+		mu.Lock() // want "mutex 'mu' is locked but not unlocked in goroutine"
+	})
+	return t
+}
+
+func GoodAfterFuncLockUnlock() *time.Timer {
+	var mu sync.Mutex
+	return time.AfterFunc(time.Second, func() {
+		mu.Lock()
+		defer mu.Unlock()
+	})
+}
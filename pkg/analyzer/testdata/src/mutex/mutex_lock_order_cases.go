@@ -0,0 +1,80 @@
+package mutex
+
+import "sync"
+
+// ========== LOCK ORDER ACROSS FUNCTIONS ==========
+//
+// Each function on its own is fine; together they take the same pair of
+// locks in opposite orders and can deadlock when they run concurrently.
+
+var (
+	ordersMu   sync.Mutex
+	accountsMu sync.Mutex
+	auditMu    sync.RWMutex
+)
+
+func LockOrdersThenAccounts() {
+	ordersMu.Lock()
+	accountsMu.Lock()
+	accountsMu.Unlock()
+	ordersMu.Unlock()
+}
+
+func BadLockAccountsThenOrders() {
+	accountsMu.Lock()
+	defer accountsMu.Unlock()
+	ordersMu.Lock() // want "inconsistent lock ordering between 'accountsMu' and 'ordersMu' may deadlock"
+	ordersMu.Unlock()
+}
+
+// Consistent order in every function is fine.
+func GoodLockOrdersThenAudit() {
+	ordersMu.Lock()
+	auditMu.RLock()
+	auditMu.RUnlock()
+	ordersMu.Unlock()
+}
+
+func GoodLockOrdersThenAuditAgain() {
+	ordersMu.Lock()
+	defer ordersMu.Unlock()
+	auditMu.Lock()
+	defer auditMu.Unlock()
+}
+
+// Struct fields are matched by field, whatever the receiver is called.
+type ledger struct {
+	head sync.Mutex
+	tail sync.Mutex
+}
+
+func (l *ledger) appendEntry() {
+	l.head.Lock()
+	l.tail.Lock()
+	l.tail.Unlock()
+	l.head.Unlock()
+}
+
+func (lg *ledger) BadCompactReversed() {
+	lg.tail.Lock()
+	lg.head.Lock() // want "inconsistent lock ordering between 'lg.head' and 'lg.tail' may deadlock"
+	lg.head.Unlock()
+	lg.tail.Unlock()
+}
+
+// Locals never meet another function's locks.
+func GoodLocalPairOne() {
+	var a, b sync.Mutex
+	a.Lock()
+	b.Lock()
+	b.Unlock()
+	a.Unlock()
+}
+
+func GoodLocalPairTwo() {
+	var a, b sync.Mutex
+	b.Lock()
+	a.Lock()
+	a.Unlock()
+	b.Unlock()
+}
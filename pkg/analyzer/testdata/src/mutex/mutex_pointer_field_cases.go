@@ -0,0 +1,311 @@
+package mutex
+
+import "sync"
+
+// ========== POINTER MUTEX FIELDS ==========
+//
+// A *sync.Mutex / *sync.RWMutex field is classified like a value field: its
+// Lock/Unlock pairing is checked through the pointer. An unexported pointer
+// field that nothing in the package assigns stays nil, so locking it panics.
+
+// --- Good: the constructor assigns the pointer field ---
+
+type ptrFieldCounter struct {
+	mu *sync.Mutex
+	n  int
+}
+
+func newPtrFieldCounter() *ptrFieldCounter {
+	return &ptrFieldCounter{mu: &sync.Mutex{}}
+}
+
+func (c *ptrFieldCounter) GoodPointerFieldIncrement() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.n++
+}
+
+func (c *ptrFieldCounter) BadPointerFieldIncrementLeaks() {
+	c.mu.Lock() // want "mutex 'c.mu' is locked but not unlocked"
+	c.n++
+}
+
+// --- Good: a shared RWMutex is injected by assignment ---
+
+type ptrFieldCache struct {
+	mu   *sync.RWMutex
+	data map[string]int
+}
+
+func (c *ptrFieldCache) share(mu *sync.RWMutex) { c.mu = mu }
+
+func (c *ptrFieldCache) GoodPointerFieldRead(key string) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.data[key]
+}
+
+// --- Good: a nil check shows the author expects a nil field ---
+
+type optionalLockCounter struct {
+	mu *sync.Mutex
+	n  int
+}
+
+func (c *optionalLockCounter) GoodNilCheckedPointerField() {
+	if c.mu != nil {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	c.n++
+}
+
+// --- Good: an unkeyed literal sets every field ---
+
+type unkeyedPtrField struct {
+	mu *sync.Mutex
+}
+
+var sharedUnkeyed = unkeyedPtrField{new(sync.Mutex)}
+
+func GoodUnkeyedLiteralPointerField() {
+	sharedUnkeyed.mu.Lock()
+	defer sharedUnkeyed.mu.Unlock()
+}
+
+// --- Good: exported fields may be set by another package ---
+
+type ExportedPtrField struct {
+	Mu *sync.Mutex
+}
+
+func (e *ExportedPtrField) GoodExportedPointerField() {
+	e.Mu.Lock()
+	defer e.Mu.Unlock()
+}
+
+// --- Bad: the pointer field is never assigned, so every Lock panics ---
+
+type nilPtrFieldCounter struct {
+	mu *sync.Mutex
+	n  int
+}
+
+func (c *nilPtrFieldCounter) BadNilPointerFieldIncrement() {
+	c.mu.Lock() // want "mutex field 'nilPtrFieldCounter.mu' is a pointer never assigned in the package, so locking it dereferences nil"
+	defer c.mu.Unlock()
+	c.n++
+}
+
+type nilPtrFieldCache struct {
+	mu   *sync.RWMutex
+	data map[string]int
+}
+
+func (c *nilPtrFieldCache) BadNilPointerFieldRead(key string) int {
+	c.mu.RLock() // want "rwmutex field 'nilPtrFieldCache.mu' is a pointer never assigned in the package, so locking it dereferences nil"
+	defer c.mu.RUnlock()
+	return c.data[key]
+}
+
+// --- Lock-named methods: the wrapper heuristic and nil pointer fields ---
+//
+// A method named after Lock whose sibling releases the same field is taken
+// for one half of a Lock/Unlock wrapper, so its lone Lock is not a leak. The
+// heuristic only excuses the pairing: a pointer field that is never assigned
+// is still reported, wrapper or not, and a Lock-named method without an
+// Unlock-named sibling that releases the field still leaks.
+
+type ptrFieldGate struct {
+	mu *sync.Mutex
+}
+
+func newPtrFieldGate() *ptrFieldGate {
+	return &ptrFieldGate{mu: new(sync.Mutex)}
+}
+
+func (g *ptrFieldGate) Lock() {
+	g.mu.Lock()
+}
+
+func (g *ptrFieldGate) Unlock() {
+	g.mu.Unlock()
+}
+
+type nilPtrFieldGate struct {
+	mu *sync.Mutex
+}
+
+func (g *nilPtrFieldGate) Lock() {
+	g.mu.Lock() // want "mutex field 'nilPtrFieldGate.mu' is a pointer never assigned in the package, so locking it dereferences nil"
+}
+
+func (g *nilPtrFieldGate) Unlock() {
+	g.mu.Unlock()
+}
+
+func (c *nilPtrFieldCounter) BadNilPointerFieldLockAndCount() {
+	c.mu.Lock() // want "mutex field 'nilPtrFieldCounter.mu' is a pointer never assigned in the package, so locking it dereferences nil" "mutex 'c.mu' is locked but not unlocked"
+	c.n++
+}
+
+// ========== LOCAL ALIASES OF A MUTEX FIELD ==========
+//
+// `mu := &s.lock` gives a *sync.Mutex local that is tracked under its own
+// name, so its Lock/Unlock pairing is checked like any other mutex.
+
+type aliasedLockStore struct {
+	lock sync.Mutex
+	rw   sync.RWMutex
+	n    int
+}
+
+func (s *aliasedLockStore) GoodFieldAliasDeferUnlock() {
+	mu := &s.lock
+	mu.Lock()
+	defer mu.Unlock()
+	s.n++
+}
+
+func (s *aliasedLockStore) BadFieldAliasLeaks() {
+	mu := &s.lock
+	mu.Lock() // want "mutex 'mu' is locked but not unlocked"
+	s.n++
+}
+
+func (s *aliasedLockStore) BadRWFieldAliasLeaks() int {
+	var rw = &s.rw
+	rw.RLock() // want "rwmutex 'rw' is rlocked but not runlocked"
+	return s.n
+}
+
+// ========== NIL POINTER MUTEX LOCALS ==========
+//
+// A *sync.Mutex local declared without a value is nil until something sets
+// it; locking it before then dereferences nil.
+
+func BadNilPointerMutexLock() {
+	var mu *sync.Mutex
+	mu.Lock() // want "mutex 'mu' is a nil pointer when Lock is called"
+	defer mu.Unlock()
+}
+
+func BadNilPointerRWMutexRLock() int {
+	var rw *sync.RWMutex = nil
+	rw.RLock() // want "rwmutex 'rw' is a nil pointer when RLock is called"
+	defer rw.RUnlock()
+	return 0
+}
+
+func GoodPointerMutexAssignedBeforeLock() {
+	var mu *sync.Mutex
+	mu = &sync.Mutex{}
+	mu.Lock()
+	defer mu.Unlock()
+}
+
+func GoodPointerMutexFromNew() {
+	var mu = new(sync.Mutex)
+	mu.Lock()
+	defer mu.Unlock()
+}
+
+func GoodPointerMutexSetByHelper(lookup func(**sync.Mutex)) {
+	var mu *sync.Mutex
+	lookup(&mu)
+	mu.Lock()
+	defer mu.Unlock()
+}
+
+// ========== LOCAL ALIASES OF A MUTEX VARIABLE ==========
+//
+// `p := &mu` makes p another name for mu, so locking through one and
+// unlocking through the other pairs up, and findings name mu.
+
+func GoodAliasUnlock() {
+	var mu sync.Mutex
+	p := &mu
+	mu.Lock()
+	p.Unlock()
+}
+
+func GoodAliasLock() {
+	var mu sync.Mutex
+	var p = &mu
+	p.Lock()
+	defer mu.Unlock()
+}
+
+func GoodRWAliasRUnlock() {
+	var rw sync.RWMutex
+	p := &rw
+	rw.RLock()
+	defer p.RUnlock()
+}
+
+func BadAliasLeaks() {
+	var mu sync.Mutex
+	p := &mu
+	p.Lock() // want "mutex 'mu' is locked but not unlocked"
+}
+
+func BadAliasDoubleUnlock() {
+	var mu sync.Mutex
+	p := &mu
+	mu.Lock()
+	p.Unlock()
+	mu.Unlock() // want "mutex 'mu' is unlocked but not locked"
+}
+
+func GoodReassignedPointerTrackedAlone(other *sync.Mutex) {
+	var mu sync.Mutex
+	p := &mu
+	p = other
+	p.Lock()
+	p.Unlock()
+	mu.Lock()
+	mu.Unlock()
+}
+
+func GoodAliasDeferredClosureUnlock() {
+	var mu sync.Mutex
+	p := &mu
+	p.Lock()
+	defer func() {
+		p.Unlock()
+	}()
+}
+
+func GoodAliasGoroutineUnlock() {
+	var mu sync.Mutex
+	p := &mu
+	p.Lock()
+	go func() {
+		p.Unlock()
+	}()
+}
+
+func GoodAliasMethodValueUnlock() {
+	var mu sync.Mutex
+	p := &mu
+	unlock := p.Unlock
+	p.Lock()
+	defer unlock()
+}
+
+func GoodAliasTryLock() {
+	var mu sync.Mutex
+	p := &mu
+	if p.TryLock() {
+		defer p.Unlock()
+	}
+}
+
+func GoodAliasDeferredRUnlockClosure() {
+	var rw sync.RWMutex
+	p := &rw
+	rw.RLock()
+	defer func() {
+		p.RUnlock()
+	}()
+}
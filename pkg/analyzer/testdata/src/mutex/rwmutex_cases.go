@@ -241,6 +241,19 @@ func BadRWConditionalOneBranchMissingRLock() {
 	}
 }
 
+func BadRWDoubleUnlockInBranches(cond bool) {
+	var mu sync.RWMutex
+	if cond {
+		mu.RLock()
+		mu.RUnlock()
+		mu.RUnlock() // want "rwmutex 'mu' is runlocked but not rlocked"
+	} else {
+		mu.Lock()
+		mu.Unlock()
+		mu.Unlock() // want "rwmutex 'mu' is unlocked but not locked"
+	}
+}
+
 // ---------- RWMutex Goroutine Patterns ----------
 
 // Goroutine: Lock/Unlock and RLock/RUnlock
@@ -332,6 +345,13 @@ func BadRWImbalancedMixed() {
 	mu.Unlock()
 }
 
+// Bad: both the write lock and the read lock leak; each gets its own report
+func BadRWMixedLeakBoth() {
+	var mu sync.RWMutex
+	mu.Lock()  // want "rwmutex 'mu' is locked but not unlocked"
+	mu.RLock() // want "rwmutex 'mu' attempts read RLock while write lock is held" "rwmutex 'mu' is rlocked but not runlocked"
+}
+
 func BadUnlockWhenReadLocked() {
 	var mu sync.RWMutex
 	mu.RLock()
@@ -344,6 +364,31 @@ func BadRUnlockWhenWriteLocked() {
 	mu.RUnlock() // want "rwmutex 'mu' RUnlock called but only write lock is held, did you mean Unlock\\?"
 }
 
+// The deferred forms are the same mistake: the wrong release method is
+// reported, and the lock it was meant to release is not reported again.
+func BadDeferRUnlockWhenWriteLocked() {
+	var mu sync.RWMutex
+	mu.Lock()
+	defer mu.RUnlock() // want "rwmutex 'mu' deferred RUnlock but only write lock is held, did you mean Unlock\\?"
+}
+
+func BadDeferUnlockWhenReadLocked() {
+	var mu sync.RWMutex
+	mu.RLock()
+	defer mu.Unlock() // want "rwmutex 'mu' deferred Unlock but only read lock is held, did you mean RUnlock\\?"
+}
+
+type rwCounter struct {
+	mu sync.RWMutex
+	n  int
+}
+
+func (c *rwCounter) BadFieldRUnlockAfterWrite() {
+	c.mu.Lock()
+	c.n++
+	c.mu.RUnlock() // want "rwmutex 'c.mu' RUnlock called but only write lock is held, did you mean Unlock\\?"
+}
+
 func GoodRWMutexCorrectReadAPI() {
 	var mu sync.RWMutex
 	mu.RLock()
@@ -583,3 +628,198 @@ func (r *rwLazyReader) GoodRWReadLockUpgrade() {
 	}()
 	r.loaded = true
 }
+
+// ---------- RLock In Loops ----------
+
+// Bad: each iteration takes another read lock and none is released
+func BadRLockInLoopWithoutRUnlock(done func() bool) {
+	var mu sync.RWMutex
+	for {
+		mu.RLock() // want "rwmutex 'mu' RLock in loop without RUnlock"
+		if done() {
+			break
+		}
+	}
+}
+
+// Bad: an RUnlock after the loop releases only the last iteration's lock
+func BadRLockInRangeReleasedAfterLoop(items []int) int {
+	var mu sync.RWMutex
+	total := 0
+	for _, item := range items {
+		mu.RLock() // want "rwmutex 'mu' RLock in loop without RUnlock"
+		total += item
+	}
+	mu.RUnlock()
+	return total
+}
+
+// Good: every iteration releases the read lock it takes
+func GoodRLockRUnlockInLoop(items []int) int {
+	var mu sync.RWMutex
+	total := 0
+	for _, item := range items {
+		mu.RLock()
+		total += item
+		mu.RUnlock()
+	}
+	return total
+}
+
+// Good: a closure run on each iteration releases its own read lock
+func GoodRLockInClosurePerIteration(items []int) int {
+	var mu sync.RWMutex
+	total := 0
+	for _, item := range items {
+		func() {
+			mu.RLock()
+			defer mu.RUnlock()
+			total += item
+		}()
+	}
+	return total
+}
+
+// loopReader holds a read lock across a loop that takes it at most once.
+type loopReader struct {
+	rw    sync.RWMutex
+	items map[string]string
+	last  string
+}
+
+// Good: the read lock is taken once, on the iteration that breaks out of
+// the loop, and released after it
+func (c *loopReader) GoodRLockThenBreakInLoop(keys []string) string {
+	for _, k := range keys {
+		if k == "" {
+			continue
+		}
+		c.rw.RLock()
+		break
+	}
+	last := c.last
+	c.rw.RUnlock()
+	return last
+}
+
+// Good: the read lock is taken once, on the iteration that returns, and
+// handed to a helper that releases it
+func (c *loopReader) GoodRLockThenReturnInLoop(k string) string {
+	for {
+		c.rw.RLock()
+		return c.lookupLocked(k)
+	}
+}
+
+func (c *loopReader) lookupLocked(k string) string {
+	defer c.rw.RUnlock() // goconcurrencylint:ignore GCL1003 the caller holds the read lock
+	return c.items[k]
+}
+
+// ---------- Writes Under a Read Lock ----------
+
+type readCache struct {
+	mu    sync.RWMutex
+	wmu   sync.Mutex
+	items map[string]string
+	hits  int
+	last  string
+}
+
+var (
+	readCacheMu    sync.RWMutex
+	readCacheTotal int
+)
+
+func (c *readCache) BadPutUnderRLock(k, v string) {
+	c.mu.RLock()
+	c.items[k] = v // want "write under RLock of rwmutex 'c.mu'; use Lock"
+	c.mu.RUnlock()
+}
+
+func (c *readCache) BadIncrementUnderDeferredRUnlock() {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.hits++ // want "write under RLock of rwmutex 'c.mu'; use Lock"
+}
+
+func BadPackageWriteUnderRLock() {
+	readCacheMu.RLock()
+	readCacheTotal = 3 // want "write under RLock of rwmutex 'readCacheMu'; use Lock"
+	readCacheMu.RUnlock()
+}
+
+func (c *readCache) BadWriteInBranchUnderRLock(k string, ok bool) {
+	c.mu.RLock()
+	if ok {
+		c.last = k // want "write under RLock of rwmutex 'c.mu'; use Lock"
+	}
+	c.mu.RUnlock()
+}
+
+func (c *readCache) GoodReadUnderRLock(k string) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	v, ok := c.items[k]
+	return v, ok
+}
+
+type readCacheResult struct{ last string }
+
+// Locals filled in under the read lock are copies, not shared state.
+func (c *readCache) GoodCopyOutUnderRLock() readCacheResult {
+	var r readCacheResult
+	var last string
+	c.mu.RLock()
+	r.last = c.last
+	last = c.last
+	c.mu.RUnlock()
+	_ = last
+	return r
+}
+
+// The write is guarded by a second, write-held mutex.
+func (c *readCache) GoodWriteUnderInnerLock(k string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.wmu.Lock()
+	c.last = k
+	c.wmu.Unlock()
+}
+
+func (c *readCache) GoodWriteAfterRUnlock(k, v string) {
+	c.mu.Lock()
+	c.items[k] = v
+	c.mu.Unlock()
+	c.mu.RLock()
+	_ = c.items[k]
+	c.mu.RUnlock()
+}
+
+// ---------- Promoted Through Nested Embedding ----------
+
+type embeddedRWLevel1 struct {
+	sync.RWMutex
+}
+
+type embeddedRWLevel2 struct {
+	embeddedRWLevel1
+	hits int
+}
+
+func (s *embeddedRWLevel2) BadPromotedRLockLeak() int {
+	s.RLock() // want "rwmutex 's' is rlocked but not runlocked"
+	return s.hits
+}
+
+func (s *embeddedRWLevel2) GoodPromotedRLock() int {
+	s.RLock()
+	defer s.RUnlock()
+	return s.hits
+}
+
+func BadPromotedLockOnLocal() {
+	var s embeddedRWLevel2
+	s.Lock() // want "rwmutex 's' is locked but not unlocked"
+	s.hits++
+}
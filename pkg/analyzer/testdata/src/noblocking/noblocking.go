@@ -0,0 +1,57 @@
+package noblocking
+
+import "sync"
+
+// The Done after the return is never reached. That is certain rather than
+// guessed, so it is reported either way.
+func UnreachableDoneAfterReturn(fail bool) {
+	var wg sync.WaitGroup
+	wg.Add(1) // want "waitgroup 'wg' has Add without corresponding Done"
+	go func() {
+		if fail {
+			return
+			wg.Done()
+		}
+		wg.Done()
+	}()
+	wg.Wait()
+}
+
+// A plain imbalance is deterministic and reported either way.
+func MissingDone() {
+	var wg sync.WaitGroup
+	wg.Add(2) // want "waitgroup 'wg' Add\\(2\\) exceeds spawned goroutines"
+	go func() {
+		defer wg.Done()
+	}()
+	wg.Wait()
+}
+
+// The worker waits on a receive from a local channel nothing sends on. A
+// present Done is never treated as orphaned, so this is silent either way.
+func BlockedOnLocalChannel() {
+	var wg sync.WaitGroup
+	ready := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		<-ready
+		wg.Done()
+	}()
+	wg.Wait()
+}
+
+// By default the Done is guessed to be skipped, since the worker first waits
+// on a channel nothing sends on, so it cannot outrun the conditional Add.
+// -no-blocking-heuristic drops the guess and the Done counts as reached.
+func ConditionalAddBeforeBlockedWorker(ok bool) {
+	var wg sync.WaitGroup
+	ready := make(chan struct{})
+	if ok {
+		wg.Add(1)
+	}
+	go func() {
+		<-ready
+		wg.Done() // want "waitgroup 'wg' Done may exceed Add \\(negative counter\\) when Add is conditional"
+	}()
+	wg.Wait()
+}
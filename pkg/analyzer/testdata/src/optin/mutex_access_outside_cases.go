@@ -0,0 +1,82 @@
+package optin
+
+import "sync"
+
+// ========== access-outside-critical-section (GCL1015, opt-in) ==========
+//
+// Shared data written under a mutex and touched again after the mutex was
+// released races with other holders. Only reported with -enable GCL1015.
+
+var (
+	sharedMu    sync.Mutex
+	sharedTotal int
+	sharedNames []string
+)
+
+func process(v int) int { return v * 2 }
+
+// --- Bad: shared data read after Unlock ---
+
+func BadReadAfterUnlock() int {
+	sharedMu.Lock()
+	sharedTotal++
+	sharedMu.Unlock()
+	return sharedTotal // want "variable 'sharedTotal' accessed outside mutex 'sharedMu' critical section"
+}
+
+func BadAppendAfterUnlock(name string) {
+	sharedMu.Lock()
+	sharedNames = append(sharedNames, name)
+	sharedMu.Unlock()
+	if len(sharedNames) > 10 { // want "variable 'sharedNames' accessed outside mutex 'sharedMu' critical section"
+		sharedNames = nil
+	}
+}
+
+type counterStore struct {
+	mu    sync.Mutex
+	count int
+}
+
+func (s *counterStore) BadFieldAfterUnlock() int {
+	s.mu.Lock()
+	s.count++
+	s.mu.Unlock()
+	return process(s.count) // want "variable 's.count' accessed outside mutex 's.mu' critical section"
+}
+
+// --- Good: the value is copied while the lock is held ---
+
+func GoodCopyUnderLock() int {
+	sharedMu.Lock()
+	sharedTotal++
+	v := sharedTotal
+	sharedMu.Unlock()
+	return process(v)
+}
+
+func (s *counterStore) GoodDeferredUnlock() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.count++
+	return s.count
+}
+
+// Re-locking before the next access keeps it inside a critical section.
+func (s *counterStore) GoodRelockBeforeAccess() int {
+	s.mu.Lock()
+	s.count++
+	s.mu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.count
+}
+
+// Locals written under the lock are private to this call.
+func GoodLocalAfterUnlock() int {
+	var local int
+	sharedMu.Lock()
+	local = sharedTotal
+	sharedMu.Unlock()
+	return local
+}
@@ -0,0 +1,61 @@
+package optin
+
+import "sync"
+
+// ========== empty-critical-section (GCL1014, opt-in) ==========
+//
+// A Lock immediately followed by its Unlock protects nothing. Only reported
+// with -enable GCL1014.
+
+// --- Bad: nothing between the lock and the unlock ---
+
+func BadEmptyCriticalSection() {
+	var mu sync.Mutex
+	counter := 0
+	mu.Lock() // want "mutex 'mu' has empty critical section"
+	mu.Unlock()
+	counter++
+	_ = counter
+}
+
+func BadEmptyReadCriticalSection(rw *sync.RWMutex) {
+	rw.RLock() // want "rwmutex 'rw' has empty critical section"
+	rw.RUnlock()
+}
+
+type guarded struct {
+	mu sync.Mutex
+	n  int
+}
+
+func (g *guarded) BadEmptyFieldCriticalSection() {
+	g.mu.Lock() // want "mutex 'g.mu' has empty critical section"
+	g.mu.Unlock()
+	g.n++
+}
+
+// --- Good: the critical section protects something ---
+
+func GoodNonEmptyCriticalSection() {
+	var mu sync.Mutex
+	counter := 0
+	mu.Lock()
+	counter++
+	mu.Unlock()
+	_ = counter
+}
+
+func GoodDeferredUnlock(g *guarded) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.n++
+}
+
+// Adjacent calls on different mutexes never form an empty section.
+func GoodAdjacentCallsOnDifferentMutexes(a, b *guarded) {
+	a.mu.Lock()
+	b.mu.Lock()
+	a.n += b.n
+	b.mu.Unlock()
+	a.mu.Unlock()
+}
@@ -0,0 +1,50 @@
+package optin
+
+import "sync"
+
+func BadUnlockHandedOffOverChannel() {
+	var mu sync.Mutex
+	ch := make(chan struct{})
+	mu.Lock() // want "mutex 'mu' lock/unlock split across goroutines"
+	go func() {
+		<-ch
+		mu.Unlock()
+	}()
+	ch <- struct{}{}
+}
+
+func BadDeferredUnlockInGoroutine() {
+	var mu sync.Mutex
+	mu.Lock() // want "mutex 'mu' lock/unlock split across goroutines"
+	go func() {
+		defer mu.Unlock()
+	}()
+}
+
+func BadRUnlockInGoroutine() {
+	var rw sync.RWMutex
+	rw.RLock() // want "rwmutex 'rw' rlock/runlock split across goroutines"
+	go func() {
+		rw.RUnlock()
+	}()
+}
+
+func GoodUnlockBeforeGoroutine(counter *int) {
+	var mu sync.Mutex
+	mu.Lock()
+	*counter++
+	mu.Unlock()
+	go func() {
+		mu.Lock()
+		defer mu.Unlock()
+	}()
+}
+
+func GoodGoroutineOwnsItsLock(counter *int) {
+	var mu sync.Mutex
+	go func() {
+		mu.Lock()
+		*counter++
+		mu.Unlock()
+	}()
+}
@@ -0,0 +1,86 @@
+package optin
+
+import "sync"
+
+// ========== lock-where-rlock-suffices (GCL1022, opt-in) ==========
+//
+// A write Lock on an RWMutex whose critical section never writes could be an
+// RLock. Only reported with -enable GCL1022.
+
+type readCache struct {
+	mu    sync.RWMutex
+	items map[string]string
+	hits  int
+}
+
+// --- Bad: the write-locked section only reads ---
+
+func (c *readCache) BadGetUnderWriteLock(k string) string {
+	c.mu.Lock() // want "rwmutex 'c.mu' Lock with read-only critical section; consider RLock"
+	defer c.mu.Unlock()
+	return c.items[k]
+}
+
+func (c *readCache) BadLenUnderWriteLock() int {
+	c.mu.Lock() // want "rwmutex 'c.mu' Lock with read-only critical section; consider RLock"
+	n := len(c.items)
+	c.mu.Unlock()
+	return n
+}
+
+func (c *readCache) BadLookupWithEarlyUnlock(k string) (string, bool) {
+	var v string
+	c.mu.Lock() // want "rwmutex 'c.mu' Lock with read-only critical section; consider RLock"
+	if c.items == nil {
+		c.mu.Unlock()
+		return "", false
+	}
+	v = c.items[k]
+	c.mu.Unlock()
+	return v, true
+}
+
+// --- Good: the section writes, or may write through a call ---
+
+func (c *readCache) GoodPutUnderWriteLock(k, v string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items[k] = v
+}
+
+func (c *readCache) GoodCountedGet(k string) string {
+	c.mu.Lock()
+	c.hits++
+	v := c.items[k]
+	c.mu.Unlock()
+	return v
+}
+
+func (c *readCache) GoodDeleteUnderWriteLock(k string) {
+	c.mu.Lock()
+	delete(c.items, k)
+	c.mu.Unlock()
+}
+
+func (c *readCache) GoodCallUnderWriteLock(k string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.refresh(k)
+}
+
+func (c *readCache) refresh(k string) { c.items[k] = "" }
+
+// Already read-locked.
+func (c *readCache) GoodGetUnderReadLock(k string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.items[k]
+}
+
+// A plain Mutex has no read lock to switch to.
+func GoodPlainMutexReadOnly(mu *sync.Mutex, m map[string]int) int {
+	mu.Lock()
+	n := len(m)
+	mu.Unlock()
+	return n
+}
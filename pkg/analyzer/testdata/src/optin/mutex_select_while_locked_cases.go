@@ -0,0 +1,65 @@
+package optin
+
+import (
+	"context"
+	"sync"
+)
+
+// Run by opt_in_test.go with -enable GCL1021: a select without a default case
+// blocks, so entering one with a mutex held risks a deadlock with whichever
+// goroutine needs the mutex to make a channel ready.
+
+func BadSelectAfterLock(ctx context.Context, results <-chan int, total *int) {
+	var mu sync.Mutex
+	mu.Lock()
+	select { // want "mutex 'mu' held while blocking in select without default"
+	case v := <-results:
+		*total += v
+	case <-ctx.Done():
+	}
+	mu.Unlock()
+}
+
+func BadSelectUnderDeferredUnlock(ctx context.Context, results <-chan int, total *int) {
+	var mu sync.Mutex
+	mu.Lock()
+	defer mu.Unlock()
+	select { // want "mutex 'mu' held while blocking in select without default"
+	case v := <-results:
+		*total += v
+	case <-ctx.Done():
+	}
+}
+
+func BadSelectUnderRLock(results chan<- int, value int) {
+	var rw sync.RWMutex
+	rw.RLock()
+	select { // want "rwmutex 'rw' rlocked while blocking in select without default"
+	case results <- value:
+	}
+	rw.RUnlock()
+}
+
+func GoodSelectWithDefaultUnderLock(results <-chan int, total *int) {
+	var mu sync.Mutex
+	mu.Lock()
+	select {
+	case v := <-results:
+		*total += v
+	default:
+	}
+	mu.Unlock()
+}
+
+func GoodSelectBeforeLock(ctx context.Context, results <-chan int, total *int) {
+	var mu sync.Mutex
+	var v int
+	select {
+	case v = <-results:
+	case <-ctx.Done():
+		return
+	}
+	mu.Lock()
+	*total += v
+	mu.Unlock()
+}
@@ -0,0 +1,50 @@
+package optin
+
+import (
+	"sync"
+	"time"
+)
+
+// Run by opt_in_test.go with -enable GCL1024: sleeping with a mutex held
+// makes every goroutine that needs the mutex wait out the sleep as well.
+
+func BadSleepBetweenLockAndUnlock(n *int) {
+	var mu sync.Mutex
+	mu.Lock()
+	time.Sleep(time.Millisecond) // want "mutex 'mu' held across time.Sleep"
+	*n++
+	mu.Unlock()
+}
+
+func BadSleepUnderDeferredUnlock(n *int) {
+	var mu sync.Mutex
+	mu.Lock()
+	defer mu.Unlock()
+	*n++
+	time.Sleep(time.Millisecond) // want "mutex 'mu' held across time.Sleep"
+}
+
+func BadSleepUnderRLock(n *int) int {
+	var rw sync.RWMutex
+	rw.RLock()
+	time.Sleep(time.Millisecond) // want "rwmutex 'rw' rlocked across time.Sleep"
+	v := *n
+	rw.RUnlock()
+	return v
+}
+
+func GoodSleepBeforeLock(n *int) {
+	var mu sync.Mutex
+	time.Sleep(time.Millisecond)
+	mu.Lock()
+	*n++
+	mu.Unlock()
+}
+
+func GoodSleepAfterUnlock(n *int) {
+	var mu sync.Mutex
+	mu.Lock()
+	*n++
+	mu.Unlock()
+	time.Sleep(time.Millisecond)
+}
@@ -0,0 +1,74 @@
+package optin
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+)
+
+// ========== pool-get-without-put (GCL5002, opt-in) ==========
+//
+// A value taken from a sync.Pool with Get should be handed back with Put, or
+// the pool never reuses it. Only reported with -enable GCL5002.
+
+var bufPool = sync.Pool{New: func() any { return new(bytes.Buffer) }}
+
+// --- Bad: the pooled value is never Put back ---
+
+func BadPoolGetWithoutPut() string {
+	buf := bufPool.Get().(*bytes.Buffer) // want "sync.Pool 'bufPool' Get without corresponding Put"
+	buf.Reset()
+	fmt.Fprintf(buf, "%d", 42)
+	return buf.String()
+}
+
+func BadPoolGetPutOnOtherValue() {
+	var p sync.Pool
+	a := p.Get() // want "sync.Pool 'p' Get without corresponding Put"
+	b := p.Get()
+	_ = a
+	p.Put(b)
+}
+
+// --- Good: Put, deferred or direct, or ownership handed off ---
+
+func GoodPoolGetDeferPut() string {
+	buf := bufPool.Get().(*bytes.Buffer)
+	defer bufPool.Put(buf)
+	buf.Reset()
+	buf.WriteString("ok")
+	return buf.String()
+}
+
+func GoodPoolGetPutInDeferredClosure() {
+	buf := bufPool.Get().(*bytes.Buffer)
+	defer func() {
+		buf.Reset()
+		bufPool.Put(buf)
+	}()
+	buf.WriteString("ok")
+}
+
+func GoodPoolGetPut() {
+	var p sync.Pool
+	v := p.Get()
+	p.Put(v)
+}
+
+// The caller owns the returned value and is responsible for putting it back.
+func GoodPoolGetReturned() *bytes.Buffer {
+	buf := bufPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+// A same-package helper may release the value.
+func GoodPoolGetReleasedByHelper() {
+	buf := bufPool.Get().(*bytes.Buffer)
+	release(buf)
+}
+
+func release(buf *bytes.Buffer) {
+	buf.Reset()
+	bufPool.Put(buf)
+}
@@ -0,0 +1,63 @@
+package optin
+
+import "sync"
+
+func BadAddDoneInGoroutineWaitedElsewhere(done chan struct{}) {
+	var wg sync.WaitGroup
+	go func() {
+		wg.Add(1) // want "waitgroup 'wg' Add and Done both inside goroutine \\(likely misplaced Add\\)"
+		defer wg.Done()
+	}()
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+}
+
+func BadAddDoneInGoroutineReturned() *sync.WaitGroup {
+	wg := new(sync.WaitGroup)
+	go func() {
+		wg.Add(1) // want "waitgroup 'wg' Add and Done both inside goroutine \\(likely misplaced Add\\)"
+		wg.Done()
+	}()
+	return wg
+}
+
+func GoodAddInParent(done chan struct{}) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+	}()
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+}
+
+// The goroutine adds for the nested workers it starts; they own the Done.
+func GoodAddHandedToNestedWorker(done chan struct{}, jobs []int) {
+	var wg sync.WaitGroup
+	go func() {
+		for range jobs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+			}()
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+}
+
+// A WaitGroup local to the goroutine is not shared with anyone else.
+func GoodAddDoneOnGoroutineLocal() {
+	go func() {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		wg.Done()
+		wg.Wait()
+	}()
+}
@@ -0,0 +1,69 @@
+package optin
+
+import "sync"
+
+func work(wg *sync.WaitGroup) {
+	defer wg.Done()
+}
+
+func BadAddWaitSameIteration(jobs []int) {
+	var wg sync.WaitGroup
+	for range jobs {
+		wg.Add(1)
+		go work(&wg)
+		wg.Wait() // want "waitgroup 'wg' Add and Wait in same loop iteration"
+	}
+}
+
+func BadAddWaitRetryLoop(attempts int) {
+	var wg sync.WaitGroup
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+		}()
+		wg.Wait() // want "waitgroup 'wg' Add and Wait in same loop iteration"
+		if i > 2 {
+			break
+		}
+	}
+}
+
+func GoodWaitGroupPerIteration(jobs []int) {
+	for range jobs {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go work(&wg)
+		wg.Wait()
+	}
+}
+
+func GoodAddInLoopWaitAfter(jobs []int) {
+	var wg sync.WaitGroup
+	for range jobs {
+		wg.Add(1)
+		go work(&wg)
+	}
+	wg.Wait()
+}
+
+func BadAddInNestedLoop(batches [][]int) {
+	var wg sync.WaitGroup
+	for _, batch := range batches {
+		for range batch {
+			wg.Add(1)
+			go work(&wg)
+		}
+		wg.Wait() // want "waitgroup 'wg' Add and Wait in same loop iteration"
+	}
+}
+
+func GoodLoopAlwaysReturns(jobs []int) {
+	var wg sync.WaitGroup
+	for range jobs {
+		wg.Add(1)
+		go work(&wg)
+		wg.Wait()
+		return
+	}
+}
@@ -0,0 +1,117 @@
+package optin
+
+import "sync"
+
+// ========== field-add-done-imbalance (GCL2019, opt-in) ==========
+//
+// A WaitGroup stored in a struct field is usually added to in one method and
+// released in another. Add and Done are tallied across every function of the
+// package, each once per call of the method it sits in; a field that is
+// only ever added to, only ever released, or added more times than it is
+// released is reported. Only reported with -enable GCL2019.
+
+// --- Good: Add in Start, Done in the worker, Wait in Stop ---
+
+type balancedServer struct {
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+func (s *balancedServer) Start() {
+	s.wg.Add(1)
+	go s.loop()
+}
+
+func (s *balancedServer) loop() {
+	defer s.wg.Done()
+	<-s.quit
+}
+
+func (s *balancedServer) Stop() {
+	close(s.quit)
+	s.wg.Wait()
+}
+
+// --- Good: Add(2) in Start is released by the two workers it launches ---
+
+type pairServer struct{ wg sync.WaitGroup }
+
+func (s *pairServer) Start() {
+	s.wg.Add(2)
+	go s.run()
+	go s.run()
+}
+
+func (s *pairServer) run() { defer s.wg.Done() }
+
+func (s *pairServer) Stop() { s.wg.Wait() }
+
+// --- Good: Go counts the goroutine itself, so a Done elsewhere is not flagged ---
+
+type goServer struct{ wg sync.WaitGroup }
+
+func (s *goServer) Start(work func()) { s.wg.Go(work) }
+
+func (s *goServer) Stop() { s.wg.Wait() }
+
+// --- Good: the field's address escapes, so Done may happen elsewhere ---
+
+type escapingServer struct{ wg sync.WaitGroup }
+
+func (s *escapingServer) Start() {
+	s.wg.Add(1)
+	go runAndRelease(&s.wg)
+}
+
+func runAndRelease(wg *sync.WaitGroup) { defer wg.Done() }
+
+// --- Good: exported fields may be balanced by another package ---
+
+type ExportedServer struct{ WG sync.WaitGroup }
+
+func (s *ExportedServer) Start() { s.WG.Add(1) }
+
+// --- Bad: Add in Start, but no function ever calls Done ---
+
+type leakyServer struct{ wg sync.WaitGroup }
+
+func (s *leakyServer) Start() {
+	s.wg.Add(1) // want "waitgroup field 'leakyServer.wg' has Add but no Done in any function of the package"
+	go s.loop()
+}
+
+func (s *leakyServer) loop() {}
+
+func (s *leakyServer) Stop() { s.wg.Wait() }
+
+// --- Bad: Done in the worker, but no function ever calls Add ---
+
+type unaddedServer struct{ wg sync.WaitGroup }
+
+func (s *unaddedServer) Start() { go s.loop() }
+
+func (s *unaddedServer) loop() {
+	defer s.wg.Done() // want "waitgroup field 'unaddedServer.wg' has Done but no Add in any function of the package"
+}
+
+func (s *unaddedServer) Stop() { s.wg.Wait() }
+
+// --- Bad: two methods add to the field, but only one worker releases it ---
+
+type partialServer struct{ wg sync.WaitGroup }
+
+func (s *partialServer) StartA() {
+	s.wg.Add(1) // want "waitgroup field 'partialServer.wg' is added 2 times but released only 1 times in the package"
+	go s.a()
+}
+
+func (s *partialServer) StartB() {
+	s.wg.Add(1)
+	go s.b()
+}
+
+func (s *partialServer) a() { defer s.wg.Done() }
+
+func (s *partialServer) b() {}
+
+func (s *partialServer) Stop() { s.wg.Wait() }
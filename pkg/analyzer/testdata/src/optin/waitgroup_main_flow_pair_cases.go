@@ -0,0 +1,75 @@
+package optin
+
+import "sync"
+
+// ========== main-flow-add-done (GCL2022) ==========
+//
+// Add and Done on the same goroutine cancel out before anyone can wait, so
+// the WaitGroup synchronizes nothing.
+
+func BadMainFlowAddDone() {
+	var wg sync.WaitGroup
+	wg.Add(1) // want "waitgroup 'wg' Add/Done in main flow with no goroutine \\(no-op synchronization\\)"
+	doWork()
+	wg.Done()
+	wg.Wait()
+}
+
+func BadMainFlowDeferredDone() {
+	var wg sync.WaitGroup
+	wg.Add(1) // want "waitgroup 'wg' Add/Done in main flow with no goroutine \\(no-op synchronization\\)"
+	defer wg.Done()
+	doWork()
+}
+
+func BadMainFlowAddDoneInLoop(n int) {
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1) // want "waitgroup 'wg' Add/Done in main flow with no goroutine \\(no-op synchronization\\)"
+		doWork()
+		wg.Done()
+	}
+	wg.Wait()
+}
+
+// Waiting before the Done deadlocks; that check owns the report.
+func GoodMainFlowWaitBeforeDoneLeftToDeadlockCheck() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	wg.Wait() // want "waitgroup 'wg' waits with pending Add in the same goroutine"
+	wg.Done()
+}
+
+func GoodMainFlowAddGoroutineDone() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		doWork()
+	}()
+	wg.Wait()
+}
+
+func GoodMainFlowDoneWithGoroutineWaiter() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		wg.Wait()
+		doWork()
+	}()
+	doWork()
+	wg.Done()
+}
+
+func GoodMainFlowWaitGroupPassedToHelper() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	startWaiter(&wg)
+	wg.Done()
+}
+
+func startWaiter(wg *sync.WaitGroup) {
+	go wg.Wait()
+}
+
+func doWork() {}
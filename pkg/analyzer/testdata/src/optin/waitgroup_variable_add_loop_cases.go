@@ -0,0 +1,79 @@
+package optin
+
+import "sync"
+
+// ========== variable-add-in-loop (GCL2021) ==========
+//
+// Adding a non-constant count inside a loop that releases a fixed number of
+// Dones per iteration almost never balances: wg.Add(i) adds 0+1+...+n-1.
+
+func BadAddLoopIndex(n int) {
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(i) // want "waitgroup 'wg' variable Add count in loop unlikely to balance Done"
+		go func() {
+			defer wg.Done()
+			_ = i
+		}()
+	}
+	wg.Wait()
+}
+
+func BadAddRangeIndex(items []string) {
+	var wg sync.WaitGroup
+	for i, item := range items {
+		wg.Add(i + 1) // want "waitgroup 'wg' variable Add count in loop unlikely to balance Done"
+		go func() {
+			defer wg.Done()
+			_ = item
+		}()
+	}
+	wg.Wait()
+}
+
+func BadAddLoopIndexDoneInline(n int) {
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(i) // want "waitgroup 'wg' variable Add count in loop unlikely to balance Done"
+		wg.Done()
+	}
+	wg.Wait()
+}
+
+func GoodAddConstantInLoop(n int) {
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = i
+		}()
+	}
+	wg.Wait()
+}
+
+// The Dones come from a nested loop, one per batch item, so the count is not
+// fixed per iteration of the outer loop.
+func GoodAddBatchLenWithNestedDones(batches [][]int) {
+	var wg sync.WaitGroup
+	for _, batch := range batches {
+		wg.Add(len(batch))
+		for _, item := range batch {
+			go func() {
+				defer wg.Done()
+				_ = item
+			}()
+		}
+	}
+	wg.Wait()
+}
+
+// Without a Done in the loop the release is someone else's business.
+func GoodAddVariableHandedOff(batches [][]int, process func([]int, *sync.WaitGroup)) {
+	var wg sync.WaitGroup
+	for _, batch := range batches {
+		wg.Add(len(batch))
+		go process(batch, &wg)
+	}
+	wg.Wait()
+}
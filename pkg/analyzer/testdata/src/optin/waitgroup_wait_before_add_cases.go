@@ -0,0 +1,50 @@
+package optin
+
+import "sync"
+
+// With no Add at all, only the Wait-without-Add report applies.
+func BadLeadingWaitNoAdds() {
+	var wg sync.WaitGroup
+	wg.Wait() // want "waitgroup 'wg' Wait called without any Add"
+}
+
+func BadWaitThenAdd() {
+	var wg sync.WaitGroup
+	wg.Wait() // want "waitgroup 'wg' Wait before any Add \\(returns immediately\\)"
+	wg.Add(1) // want "waitgroup 'wg' Add called after Wait"
+	wg.Done()
+}
+
+func BadWaitThenGo() {
+	var wg sync.WaitGroup
+	wg.Wait()        // want "waitgroup 'wg' Wait before any Add \\(returns immediately\\)"
+	wg.Go(func() {}) // want "waitgroup 'wg' Go called after Wait"
+}
+
+func GoodAddBeforeWait() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+	}()
+	wg.Wait()
+}
+
+func addWorker(wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+	}()
+}
+
+func GoodWaitAfterHandOff() {
+	var wg sync.WaitGroup
+	addWorker(&wg)
+	wg.Wait()
+}
+
+func GoodWaitAfterGo() {
+	var wg sync.WaitGroup
+	wg.Go(func() {})
+	wg.Wait()
+}
@@ -0,0 +1,28 @@
+package packagelevel
+
+import "sync"
+
+var (
+	configMu  sync.Mutex
+	registry  = map[string]int{}
+	startupMu sync.RWMutex
+)
+
+// The mutexes are declared at package scope, above in this file and in
+// globals.go, and locked by init() functions that run before main.
+
+func init() {
+	packageMu.Lock() // want "mutex 'packageMu' is locked but not unlocked"
+	registry["boot"] = 1
+}
+
+func init() {
+	startupMu.RLock() // want "rwmutex 'startupMu' is rlocked but not runlocked"
+	_ = registry["boot"]
+}
+
+func init() {
+	configMu.Lock()
+	defer configMu.Unlock()
+	registry["config"] = 2
+}
@@ -0,0 +1,11 @@
+package skiptests
+
+import "sync"
+
+// Run by skip_tests_test.go with -skip-tests: this file is not a test file,
+// so its diagnostics are still reported.
+
+func BadLockInSourceFile() {
+	var mu sync.Mutex
+	mu.Lock() // want "mutex 'mu' is locked but not unlocked"
+}
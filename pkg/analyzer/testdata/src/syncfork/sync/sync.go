@@ -0,0 +1,8 @@
+// Package sync stands in for a drop-in replacement of the standard sync
+// package, such as a fork or an internal mirror.
+package sync
+
+type Mutex struct{ state int32 }
+
+func (m *Mutex) Lock()   {}
+func (m *Mutex) Unlock() {}
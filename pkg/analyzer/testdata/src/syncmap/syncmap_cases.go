@@ -0,0 +1,85 @@
+package syncmap
+
+import "sync"
+
+// ========== syncmap-non-atomic-update (GCL8001) ==========
+//
+// Load and Store are each atomic, but a Load followed by a Store on the same
+// key is not: a concurrent Store in between is overwritten.
+
+// --- Bad: racy read-modify-write ---
+
+func BadCounterIncrement(m *sync.Map, key string) {
+	v, _ := m.Load(key)
+	n, _ := v.(int)
+	m.Store(key, n+1) // want "sync.Map 'm' non-atomic read-modify-write"
+}
+
+var hits sync.Map
+
+func BadPackageMapConstantKey() {
+	v, ok := hits.Load("home")
+	if !ok {
+		v = 0
+	}
+	hits.Store("home", v.(int)+1) // want "sync.Map 'hits' non-atomic read-modify-write"
+}
+
+type cache struct {
+	entries sync.Map
+}
+
+func (c *cache) BadFieldCheckThenStore(key string, build func() any) any {
+	if v, ok := c.entries.Load(key); ok {
+		return v
+	}
+	v := build()
+	c.entries.Store(key, v) // want "sync.Map 'c.entries' non-atomic read-modify-write"
+	return v
+}
+
+// --- Good: atomic forms, different keys, or serialized updates ---
+
+func GoodLoadOrStore(c *cache, key string, build func() any) any {
+	v, _ := c.entries.LoadOrStore(key, build())
+	return v
+}
+
+func GoodCompareAndSwapLoop(m *sync.Map, key string) {
+	for {
+		v, loaded := m.LoadOrStore(key, 1)
+		if !loaded || m.CompareAndSwap(key, v, v.(int)+1) {
+			return
+		}
+	}
+}
+
+func GoodDifferentKeys(m *sync.Map, from, to string) {
+	v, _ := m.Load(from)
+	m.Store(to, v)
+}
+
+func GoodStoreBeforeLoad(m *sync.Map, key string) any {
+	m.Store(key, 1)
+	v, _ := m.Load(key)
+	return v
+}
+
+func GoodSerializedByMutex(mu *sync.Mutex, m *sync.Map, key string) {
+	mu.Lock()
+	defer mu.Unlock()
+	v, _ := m.Load(key)
+	n, _ := v.(int)
+	m.Store(key, n+1)
+}
+
+// A plain map with the same method names is not a sync.Map.
+type plainMap map[string]int
+
+func (p plainMap) Load(k string) (int, bool) { v, ok := p[k]; return v, ok }
+func (p plainMap) Store(k string, v int)     { p[k] = v }
+
+func GoodNotASyncMap(p plainMap, key string) {
+	v, _ := p.Load(key)
+	p.Store(key, v+1)
+}
@@ -23,6 +23,11 @@ func processWork(wg sync.WaitGroup) { // want "waitgroup 'wg' is copied by value
 	// process work
 }
 
+func inspectWork(wg *sync.WaitGroup, snapshot sync.WaitGroup) { // want "waitgroup 'snapshot' is copied by value"
+	defer wg.Done()
+	_ = &snapshot
+}
+
 func runWithCallback(done func()) {
 	defer done()
 	// run with callback
@@ -146,3 +151,7 @@ func (t readerToken) Close() error {
 	t.owner.pendingReaders.Done()
 	return nil
 }
+
+func runIgnoringCallback(done func()) {
+	// the callback is never called
+}
@@ -261,6 +261,20 @@ func EdgeCaseAddAfterWaitMainFlow() {
 	wg.Done()
 }
 
+// Both empty Waits precede the same Adds; each Add is reported once.
+func EdgeCaseAddAfterRepeatedWaits() {
+	var wg sync.WaitGroup
+
+	wg.Wait()
+	wg.Wait()
+	wg.Add(1) // want "waitgroup 'wg' Add called after Wait"
+	wg.Done()
+	go func() {
+		wg.Add(1) // want "waitgroup 'wg' Add called after Wait" "waitgroup 'wg' Add called inside goroutine, may race with Wait"
+		wg.Done()
+	}()
+}
+
 // Edge case where Wait is called without any Adds
 func EdgeCaseNoAddNoDoneNoGoroutine() {
 	var wg sync.WaitGroup
@@ -355,3 +369,48 @@ func BadWaitGroupGoRecoverInNestedFunction() {
 	})
 	wg.Wait()
 }
+
+// ---------- Missing Wait Patterns ----------
+
+// Workers are started but the function returns without waiting for them.
+func BadAddWithoutWait() {
+	var wg sync.WaitGroup
+	wg.Add(1) // want "waitgroup 'wg' has Add but no Wait \\(goroutine result may be lost\\)"
+	go func() {
+		defer wg.Done()
+	}()
+}
+
+func BadAddWithoutWaitInLoop(items []int) {
+	var wg sync.WaitGroup
+	for range items {
+		wg.Add(1) // want "waitgroup 'wg' has Add but no Wait \\(goroutine result may be lost\\)"
+		go func() {
+			defer wg.Done()
+		}()
+	}
+}
+
+// The Wait is deferred, so it still runs before the function returns.
+func GoodAddWithDeferredWait() {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+	}()
+}
+
+// The WaitGroup is handed to a helper that owns the Wait.
+func GoodAddWithWaitInHelper() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+	}()
+	waitFor(&wg)
+}
+
+func waitFor(wg *sync.WaitGroup) {
+	wg.Wait()
+}
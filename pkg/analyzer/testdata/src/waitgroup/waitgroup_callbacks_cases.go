@@ -16,7 +16,33 @@ func GoodWaitGroupPassedToGoroutine() {
 func BadWaitGroupPassedAsValue() {
 	var wg sync.WaitGroup
 	wg.Add(1)
-	go processWork(wg) // want "waitgroup 'wg' is copied by value"
+	go processWork(wg) // want "waitgroup 'wg' passed by value; Done on copy has no effect"
+	wg.Wait()
+}
+
+// The same copy through a function literal started as a goroutine.
+func BadWaitGroupPassedAsValueToFuncLiteral() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func(wg sync.WaitGroup) { // want "waitgroup 'wg' is copied by value"
+		defer wg.Done()
+	}(wg) // want "waitgroup 'wg' passed by value; Done on copy has no effect"
+	wg.Wait()
+}
+
+// A synchronous call releasing a copy leaves the original counter at one.
+func BadWaitGroupPassedAsValueToSyncCall() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	processWork(wg) // want "waitgroup 'wg' passed by value; Done on copy has no effect"
+	wg.Wait()
+}
+
+// A callee that never calls Done on its copy gets the generic copy message.
+func BadWaitGroupPassedAsValueWithoutDone() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go inspectWork(&wg, wg) // want "waitgroup 'wg' copied into goroutine by value"
 	wg.Wait()
 }
 
@@ -86,6 +112,21 @@ func GoodWaitGroupMethodPassed() {
 	wg.Wait()
 }
 
+// The callee never calls the Done it is handed, so Wait blocks forever.
+func BadWaitGroupMethodPassedButIgnored() {
+	var wg sync.WaitGroup
+	wg.Add(1) // want "waitgroup 'wg' has Add without corresponding Done"
+	go runIgnoringCallback(wg.Done)
+	wg.Wait()
+}
+
+func BadWaitGroupMethodPassedButIgnoredSync() {
+	var wg sync.WaitGroup
+	wg.Add(1) // want "waitgroup 'wg' has Add without corresponding Done"
+	runIgnoringCallback(wg.Done)
+	wg.Wait()
+}
+
 type callbackOwner struct {
 	wg sync.WaitGroup
 }
@@ -121,6 +121,68 @@ func GoodReuseWaitGroup() {
 	wg.Wait()
 }
 
+// Reusing the WaitGroup inside the loop: each iteration Adds before it
+// Waits, so every Wait has work to wait for.
+func GoodWaitInLoopWithAdd(batches [][]int) {
+	var wg sync.WaitGroup
+	for _, batch := range batches {
+		for range batch {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+			}()
+		}
+		wg.Wait()
+	}
+}
+
+// The loop body always returns, so the Wait runs once.
+func GoodWaitInLoopThatExits() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+	}()
+	for {
+		wg.Wait()
+		return
+	}
+}
+
+// Go counts the goroutine itself, so the loop refills the counter.
+func GoodWaitInLoopWithGo(rounds int) {
+	var wg sync.WaitGroup
+	for i := 0; i < rounds; i++ {
+		wg.Go(func() {})
+		wg.Wait()
+	}
+}
+
+// After the first iteration the counter is zero: every later Wait returns at
+// once and the loop spins.
+func BadWaitInLoopWithoutAdd(handle func()) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+	}()
+	for {
+		wg.Wait() // want "waitgroup 'wg' Wait in loop without Add \\(no progress\\)"
+		handle()
+	}
+}
+
+func BadWaitInRangeLoopWithoutAdd(jobs []int) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+	}()
+	for range jobs {
+		wg.Wait() // want "waitgroup 'wg' Wait in loop without Add \\(no progress\\)"
+	}
+}
+
 type TwoPhaseBench struct {
 	wg      sync.WaitGroup
 	barrier sync.RWMutex
@@ -161,6 +223,32 @@ func (m *MyStruct) DoWork() {
 	m.wg.Wait()
 }
 
+// A field WaitGroup is keyed by its selector, so Add and Done on m.wg are
+// balanced within the method like a local group.
+type Server struct {
+	wg sync.WaitGroup
+}
+
+func (s *Server) BadFieldAddWithoutDoneInMethod() {
+	s.wg.Add(1) // want "waitgroup 's.wg' has Add without corresponding Done"
+	go func() {}()
+	s.wg.Wait()
+}
+
+func BadFieldAddWithoutDoneOnLocalStruct() {
+	s := &Server{}
+	s.wg.Add(1) // want "waitgroup 's.wg' has Add without corresponding Done"
+	go func() {}()
+	s.wg.Wait()
+}
+
+func GoodFieldAddDoneOnLocalStruct() {
+	s := &Server{}
+	s.wg.Add(1)
+	go func() { defer s.wg.Done() }()
+	s.wg.Wait()
+}
+
 // Add in one method, Done in a sibling method launched as a goroutine.
 // The linter must not flag Add when Done lives in another method of the same struct.
 type StructWithSiblingMethods struct {
@@ -195,6 +283,47 @@ func (l *StructWithConnHandler) handleConn() {
 	defer l.connWG.Done()
 }
 
+// A go statement on a method is resolved within the package: a method that
+// never calls Done leaves the Add unmatched, whatever the receiver is named.
+type StructWithIdleWorker struct {
+	wg sync.WaitGroup
+}
+
+func (s *StructWithIdleWorker) BadAddGoroutineMethodWithoutDone() {
+	s.wg.Add(1) // want "waitgroup 's.wg' has Add without corresponding Done"
+	go s.idle()
+	s.wg.Wait()
+}
+
+func (w *StructWithIdleWorker) idle() {}
+
+func (w *StructWithIdleWorker) finish() {
+	defer w.wg.Done()
+}
+
+func (s *StructWithIdleWorker) GoodGoroutineMethodWithRenamedReceiver() {
+	s.wg.Add(1)
+	go s.finish()
+	s.wg.Wait()
+}
+
+type StructOwningWorker struct {
+	worker StructWithIdleWorker
+}
+
+func (o *StructOwningWorker) GoodGoroutineMethodOnNestedField() {
+	o.worker.wg.Add(1)
+	go o.worker.finish()
+	o.worker.wg.Wait()
+}
+
+func GoodGoroutineMethodOnLocalStruct() {
+	var w StructWithIdleWorker
+	w.wg.Add(1)
+	go w.finish()
+	w.wg.Wait()
+}
+
 // ========== EDGE CASES ==========
 
 // ---------- Complex Control Flow ----------
@@ -366,7 +495,7 @@ func UnflaggedTypeSwitchMissingDone() {
 // Bad: Add(3) but only 2 goroutines with Done
 func BadAddMoreThanGoroutines() {
 	var wg sync.WaitGroup
-	wg.Add(3) // want "waitgroup 'wg' has Add without corresponding Done"
+	wg.Add(3) // want "waitgroup 'wg' Add\\(3\\) exceeds spawned goroutines"
 	go func() { defer wg.Done() }()
 	go func() { defer wg.Done() }()
 	wg.Wait()
@@ -382,6 +511,40 @@ func GoodAddMatchesGoroutines() {
 	wg.Wait()
 }
 
+// Bad: the one worker may skip Done, but even when it calls it Add(3) needs
+// two more
+func BadAddExceedsConditionalDoneGoroutine(ok bool) {
+	var wg sync.WaitGroup
+	wg.Add(3) // want "waitgroup 'wg' Add\\(3\\) exceeds spawned goroutines"
+	go func() {
+		if ok {
+			wg.Done()
+		}
+	}()
+	wg.Wait()
+}
+
+// Good: the main flow's Done makes up for the missing goroutine
+func GoodAddCoveredByMainFlowDone() {
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() { defer wg.Done() }()
+	wg.Done()
+	wg.Wait()
+}
+
+// Good: a Done per item cannot be counted statically
+func GoodAddWithDoneInWorkerLoop(items []int) {
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		for range items {
+			wg.Done()
+		}
+	}()
+	wg.Wait()
+}
+
 // ---------- If/Else Done Patterns ----------
 
 // Good: Both branches of if/else call Done in goroutine
@@ -654,6 +817,246 @@ func GoodWaitGroupLifecycleManagedByCloser() {
 	_ = reader.Close()
 }
 
+// ========== SHADOWED WAITGROUP TESTS ==========
+
+// Bad: the inner wg shadows the outer one and is never released; the outer
+// wg is balanced
+func BadShadowedInnerWaitGroup() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+	}()
+	{
+		var wg sync.WaitGroup
+		wg.Add(1) // want "waitgroup 'wg' has Add without corresponding Done"
+		wg.Wait()
+	}
+	wg.Wait()
+}
+
+// Bad: the outer wg is never released; the inner shadowing wg is balanced
+// and its Done must not be credited to the outer one
+func BadShadowedOuterWaitGroup(items []int) {
+	var wg sync.WaitGroup
+	wg.Add(1) // want "waitgroup 'wg' has Add without corresponding Done"
+	for range items {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+		}()
+		wg.Wait()
+	}
+	wg.Wait()
+}
+
+// Good: outer and inner shadowing wg are each balanced
+func GoodShadowedWaitGroups() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+	}()
+	if true {
+		wg := sync.WaitGroup{}
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+		}()
+		go func() {
+			defer wg.Done()
+		}()
+		wg.Wait()
+	}
+	wg.Wait()
+}
+
+// Good: a Done on another outer WaitGroup inside the shadowing block still
+// balances that WaitGroup's Add
+func GoodOuterWaitGroupReleasedInShadowingBlock() {
+	var wg, other sync.WaitGroup
+	other.Add(1)
+	{
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer other.Done()
+		}()
+		wg.Wait()
+	}
+	other.Wait()
+	_ = &wg
+}
+
+// ========== CONDITIONAL ADD TESTS ==========
+
+// Bad: when cond is false Add is skipped but Done still runs
+func BadConditionalAddUnconditionalDone(cond bool) {
+	var wg sync.WaitGroup
+	if cond {
+		wg.Add(1)
+	}
+	wg.Done() // want "waitgroup 'wg' Done may exceed Add \\(negative counter\\) when Add is conditional"
+	wg.Wait()
+}
+
+// Bad: the goroutine always runs, the Add it balances does not
+func BadConditionalAddUnconditionalGoroutine(jobs []int) {
+	var wg sync.WaitGroup
+	if len(jobs) > 0 {
+		wg.Add(1)
+	}
+	go func() {
+		defer wg.Done() // want "waitgroup 'wg' Done may exceed Add \\(negative counter\\) when Add is conditional"
+		_ = jobs
+	}()
+	wg.Wait()
+}
+
+// Good: Add and the goroutine that balances it share the condition
+func GoodConditionalAddWithGoroutine(jobs []int) {
+	var wg sync.WaitGroup
+	if len(jobs) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = jobs
+		}()
+	}
+	wg.Wait()
+}
+
+// Good: an unconditional Add covers the unconditional Done
+func GoodUnconditionalAddCoversDone(cond bool) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	if cond {
+		wg.Add(1)
+		go func() { defer wg.Done() }()
+	}
+	go func() { defer wg.Done() }()
+	wg.Wait()
+}
+
+// ========== UNREACHABLE WAIT TESTS ==========
+
+// Bad: the panic always runs, so the goroutine is never waited for
+func BadWaitAfterPanic() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+	}()
+	panic("not implemented")
+	wg.Wait() // want "waitgroup 'wg' Wait unreachable"
+}
+
+// Bad: the panic precedes the block that holds the Wait
+func BadWaitInBlockAfterPanic(items []int) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_ = items
+	}()
+	panic("unsupported")
+	if len(items) > 0 {
+		wg.Wait() // want "waitgroup 'wg' Wait unreachable"
+	}
+}
+
+// Good: the panic only runs when the condition holds
+func GoodWaitAfterConditionalPanic(items []int) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_ = items
+	}()
+	if items == nil {
+		panic("nil items")
+	}
+	wg.Wait()
+}
+
+// Good: the panic follows the Wait
+func GoodPanicAfterWait() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+	}()
+	wg.Wait()
+	panic("done")
+}
+
+// ========== DONE AFTER WAIT TESTS ==========
+
+// Bad: Wait has already drained the counter, so the Done drives it negative
+func BadDoneAfterWait() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+	}()
+	wg.Wait()
+	wg.Done() // want "waitgroup 'wg' Done after Wait"
+}
+
+// Bad: the Add counts the trailing Done, so Wait never returns
+func BadDoneAfterWaitPaddedAdd() {
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+	}()
+	wg.Wait()
+	wg.Done() // want "waitgroup 'wg' Done after Wait"
+}
+
+// Good: a new Add re-arms the group before the Done
+func GoodDoneAfterWaitWithNewAdd() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+	}()
+	wg.Wait()
+	wg.Add(1)
+	wg.Done()
+}
+
+// ---------- Add in defer ----------
+
+// The deferred Add raises the counter only on return, after the worker it
+// was meant to cover has started and Wait has run.
+func BadDeferredAddBeforeWorker() {
+	var wg sync.WaitGroup
+	defer wg.Add(1) // want "waitgroup 'wg' Add in defer \\(runs at function exit\\)"
+	wg.Wait()       // want "waitgroup 'wg' Wait called without any Add"
+}
+
+func BadDeferredAddInWorkerLoop(jobs []int) {
+	var wg sync.WaitGroup
+	for range jobs {
+		defer wg.Add(1) // want "waitgroup 'wg' Add in defer \\(runs at function exit\\)"
+		wg.Go(func() {})
+	}
+	wg.Wait()
+}
+
+// Good: the Add itself runs before the goroutine starts.
+func GoodAddBeforeDeferredWait() {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+	}()
+}
+
 // ========== COMMENT FILTERING TESTS ==========
 
 // Test that commented code is properly ignored by the linter.
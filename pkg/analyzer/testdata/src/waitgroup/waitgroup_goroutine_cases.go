@@ -21,6 +21,57 @@ func GoodLoopAddDone() {
 	wg.Wait()
 }
 
+// The per-iteration goroutine releases the group in both branches, so every
+// Done it holds is conditional yet one always runs.
+func GoodLoopGoroutineDoneInBothBranches(ok bool) {
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			if ok {
+				wg.Done()
+			} else {
+				wg.Done()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// The deferred Done counts for its iteration alongside a second goroutine
+// whose Done calls are all conditional.
+func GoodLoopDeferDoneNextToBranchedDone(ok bool) {
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+		}()
+		go func() {
+			if ok {
+				wg.Done()
+			} else {
+				wg.Done()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// Bad: the only Done in the goroutine sits in one branch.
+func BadLoopGoroutineDoneInOneBranch(ok bool) {
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1) // want "waitgroup 'wg' has Add without corresponding Done"
+		go func() {
+			if ok {
+				wg.Done()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
 func BadAddCountMismatchForLoopGoroutines() {
 	var wg sync.WaitGroup
 	wg.Add(1) // want "waitgroup 'wg' Add count 1 does not match 5 goroutines launched"
@@ -73,6 +124,33 @@ func GoodConstCountMatchesMultipleForLoopGoroutines() {
 	wg.Wait()
 }
 
+// The table-driven test idiom: one Add(len(cases)) covers a goroutine per
+// case, whether the table is a literal or built by a helper.
+func GoodAddLenOfTableCases() {
+	cases := []struct{ name string }{{"a"}, {"b"}, {"c"}}
+	var wg sync.WaitGroup
+	wg.Add(len(cases))
+	for _, tc := range cases {
+		go func() {
+			defer wg.Done()
+			_ = tc.name
+		}()
+	}
+	wg.Wait()
+}
+
+func GoodAddLenOfLoadedCases(load func() []string) {
+	cases := load()
+	var wg sync.WaitGroup
+	wg.Add(len(cases))
+	for range cases {
+		go func() {
+			defer wg.Done()
+		}()
+	}
+	wg.Wait()
+}
+
 // GoodReuseWaitGroupAcrossWaitPhases reuses one WaitGroup across two
 // Add/launch/Wait phases separated by a Wait(). The loop-count check (GCL2007)
 // must stop counting worker goroutines at the intermediate wg.Wait(): the
@@ -494,6 +572,38 @@ func UnflaggedConditionalDone() {
 	wg.Wait()
 }
 
+// Not flagged, for the same reason as UnflaggedConditionalDone: an else-if
+// chain with no final else skips every Done when no condition matches, so the
+// Done is present but not guaranteed. Regression guard.
+func UnflaggedElseIfChainDone(first, second bool) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		if first {
+			wg.Done()
+		} else if second {
+			wg.Done()
+		}
+	}()
+	wg.Wait()
+}
+
+// A final else closes the chain, so one of the Done calls always runs.
+func GoodElseIfChainWithFinalElseDone(first, second bool) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		if first {
+			wg.Done()
+		} else if second {
+			wg.Done()
+		} else {
+			wg.Done()
+		}
+	}()
+	wg.Wait()
+}
+
 // Not flagged: an event-driven Done inside a loop. Whether every Add is matched
 // depends on runtime events the linter cannot see; this is the canonical
 // producer/consumer shape used by correct code (e.g. the kubernetes and vitess
@@ -590,6 +700,37 @@ func GoodAddDoneWithPanicRecovery() {
 	wg.Wait()
 }
 
+// Bad: Done only runs when the goroutine panics
+func BadDoneOnlyInRecoverWithoutPanic() {
+	var wg sync.WaitGroup
+	wg.Add(1) // want "waitgroup 'wg' has Add without corresponding Done"
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				wg.Done()
+			}
+		}()
+		doSomething()
+	}()
+	wg.Wait()
+}
+
+// Good: the deferred function calls Done on both paths
+func GoodDoneInRecoverAndAfter() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				_ = r
+			}
+			wg.Done()
+		}()
+		doSomething()
+	}()
+	wg.Wait()
+}
+
 func GoodIntegerRangeFanout() {
 	var wg sync.WaitGroup
 	wg.Add(2000)
@@ -970,6 +1111,91 @@ func GoodWorkerDoneOnCloseOfLocalChannel() {
 	wg.Wait()
 }
 
+// Cancellation returns before Done: the worker releases the group only when
+// it receives a value, so once ctx is cancelled Wait never returns.
+func BadWorkerReturnsOnContextCancellationWithoutDone(ctx context.Context, ch <-chan int) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		select {
+		case <-ctx.Done(): // want "waitgroup 'wg' Done skipped when the goroutine is cancelled"
+			return
+		case v := <-ch:
+			_ = v
+			wg.Done()
+		}
+	}()
+	wg.Wait()
+}
+
+func BadWorkerLoopReturnsOnContextCancellationWithoutDone(ctx context.Context, jobs <-chan int) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done(): // want "waitgroup 'wg' Done skipped when the goroutine is cancelled"
+				return
+			case j, ok := <-jobs:
+				if !ok {
+					wg.Done()
+					return
+				}
+				_ = j
+			}
+		}
+	}()
+	wg.Wait()
+}
+
+// A default case that calls Done does not cover the cancellation case that
+// returns first.
+func BadWorkerDefaultDoneSkippedOnContextCancellation(ctx context.Context) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		select {
+		case <-ctx.Done(): // want "waitgroup 'wg' Done skipped when the goroutine is cancelled"
+			return
+		default:
+			wg.Done()
+		}
+	}()
+	wg.Wait()
+}
+
+// Good: the deferred Done runs on the cancellation return as well.
+func GoodWorkerDefersDoneBeforeContextSelect(ctx context.Context, ch <-chan int) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		select {
+		case <-ctx.Done():
+			return
+		case v := <-ch:
+			_ = v
+		}
+	}()
+	wg.Wait()
+}
+
+// Good: the cancellation case only leaves the select, and Done follows it.
+func GoodWorkerBreaksOutOfContextSelectBeforeDone(ctx context.Context, ch <-chan int) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		select {
+		case <-ctx.Done():
+			break
+		case v := <-ch:
+			_ = v
+		}
+		wg.Done()
+	}()
+	wg.Wait()
+}
+
 // Good: a condition-less for always enters its body, so a Done the body
 // guarantees before any conditional exit runs at least once.
 func GoodDoneGuaranteedInInfiniteLoop() {
@@ -1048,3 +1274,285 @@ func GoodAddNonConstVarMatchesGoroutines() {
 	}
 	wg.Wait()
 }
+
+// ---------- Wait While Locked Patterns ----------
+
+func BadWaitWhileMutexHeld() {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	counter := 0
+
+	mu.Lock()
+	wg.Add(1)
+	go func() { // want "mutex 'mu' goroutine started while lock is held and also tries to acquire it before parent unlocks"
+		defer wg.Done()
+		mu.Lock()
+		counter++
+		mu.Unlock()
+	}()
+	wg.Wait() // want "waitgroup 'wg' Wait called while mutex 'mu' held"
+	mu.Unlock()
+}
+
+func BadWaitWhileMutexHeldByDefer() {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	mu.Lock()
+	defer mu.Unlock()
+	wg.Add(1)
+	go func() { // want "mutex 'mu' goroutine started while lock is held and also tries to acquire it before parent unlocks"
+		defer wg.Done()
+		mu.Lock()
+		defer mu.Unlock()
+	}()
+	wg.Wait() // want "waitgroup 'wg' Wait called while mutex 'mu' held"
+}
+
+func BadWaitWhileRWMutexRLockedAndWorkerWrites() {
+	var rw sync.RWMutex
+	var wg sync.WaitGroup
+
+	rw.RLock()
+	wg.Add(1)
+	go func() { // want "rwmutex 'rw' goroutine started while read lock is held and also tries to acquire write lock before parent runlocks"
+		defer wg.Done()
+		rw.Lock()
+		rw.Unlock()
+	}()
+	wg.Wait() // want "waitgroup 'wg' Wait called while rwmutex 'rw' rlocked"
+	rw.RUnlock()
+}
+
+func GoodWaitAfterUnlock() {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	counter := 0
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		mu.Lock()
+		counter++
+		mu.Unlock()
+	}()
+	wg.Wait()
+
+	mu.Lock()
+	_ = counter
+	mu.Unlock()
+}
+
+func GoodWaitWhileHeldWorkerDoesNotLock() {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	mu.Lock()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+	}()
+	wg.Wait()
+	mu.Unlock()
+}
+
+func GoodWaitWhileRLockedWorkerReads() {
+	var rw sync.RWMutex
+	var wg sync.WaitGroup
+
+	rw.RLock()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		rw.RLock()
+		rw.RUnlock()
+	}()
+	wg.Wait()
+	rw.RUnlock()
+}
+
+// ---------- Add After Goroutine Start Patterns ----------
+
+// Bad: the goroutine may call Done before the Add lands.
+func BadAddAfterGoroutineStarted() {
+	var wg sync.WaitGroup
+	go func() {
+		defer wg.Done()
+		doSomething()
+	}()
+	wg.Add(1) // want "waitgroup 'wg' Add after goroutine started \\(race\\)"
+	wg.Wait()
+}
+
+func BadAddAfterGoroutineStartedInLoop(n int) {
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+			doSomething()
+		}()
+		wg.Add(1) // want "waitgroup 'wg' Add after goroutine started \\(race\\)"
+	}
+	wg.Wait()
+}
+
+func GoodAddBeforeGoroutineStarted() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		doSomething()
+	}()
+	wg.Wait()
+}
+
+// The second Add follows a goroutine, but that goroutine is already counted
+// by the first Add.
+func GoodAddPerGoroutineInSequence() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		doSomething()
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		doSomething()
+	}()
+	wg.Wait()
+}
+
+// The goroutine cannot reach Done before it receives from start, which is
+// only sent after the Add.
+func GoodAddAfterGoroutineGatedByChannel() {
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	go func() {
+		defer wg.Done()
+		<-start
+	}()
+	wg.Add(1)
+	close(start)
+	wg.Wait()
+}
+
+// ---------- time.AfterFunc Callbacks ----------
+
+// The callback runs on its own goroutine, so its Add races the Wait.
+func BadAfterFuncAddInside() {
+	var wg sync.WaitGroup
+	time.AfterFunc(time.Millisecond, func() {
+		wg.Add(1) // want "waitgroup 'wg' Add called inside goroutine, may race with Wait"
+		defer wg.Done()
+	})
+	wg.Wait()
+}
+
+func GoodAfterFuncDone() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	t := time.AfterFunc(time.Millisecond, func() {
+		defer wg.Done()
+	})
+	wg.Wait()
+	t.Stop()
+}
+
+// ---------- Wait in Place of Done ----------
+
+// The worker Waits where it should release the counter, so neither it nor
+// the caller ever returns.
+func BadGoroutineWaitsInsteadOfDone(results chan<- int) {
+	var wg sync.WaitGroup
+	wg.Add(1) // want "waitgroup 'wg' has Add without corresponding Done"
+	go func() {
+		results <- 1
+		wg.Wait() // want "waitgroup 'wg' Wait inside goroutine without Done"
+	}()
+	wg.Wait()
+}
+
+// A closer goroutine Waits for the workers, which call Done themselves.
+func GoodCloserGoroutineWaitsWhileCallerWaits() {
+	var wg sync.WaitGroup
+	results := make(chan int)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		results <- 1
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+	for range results {
+	}
+	wg.Wait()
+}
+
+// ---------- Done Repeated in a Worker Loop ----------
+
+// One Add, but the worker calls Done on every job it receives.
+func BadDoneInRangeLoop(jobs <-chan int) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		for range jobs {
+			wg.Done() // want "waitgroup 'wg' Done called repeatedly in loop"
+		}
+	}()
+	wg.Wait()
+}
+
+func BadDoneInInfiniteLoop() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		for {
+			wg.Done() // want "waitgroup 'wg' Done called repeatedly in loop"
+		}
+	}()
+	wg.Wait()
+}
+
+// The Add covers every job, one Done per iteration.
+func GoodDoneInLoopMatchingAdd(jobs []int) {
+	var wg sync.WaitGroup
+	wg.Add(len(jobs))
+	go func() {
+		for range jobs {
+			wg.Done()
+		}
+	}()
+	wg.Wait()
+}
+
+// The loop stops after its first Done.
+func GoodDoneInLoopThenBreak(jobs <-chan int) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		for range jobs {
+			wg.Done()
+			break
+		}
+	}()
+	wg.Wait()
+}
+
+func signalReady(ch chan struct{}) { close(ch) }
+
+// The channel the worker waits on is closed through a helper, so the Done is
+// reached; the blocked-receive guess must not report it.
+func GoodWaitOnChannelClosedByHelper() {
+	var wg sync.WaitGroup
+	ready := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		<-ready
+		defer wg.Done()
+	}()
+	signalReady(ready)
+	wg.Wait()
+}
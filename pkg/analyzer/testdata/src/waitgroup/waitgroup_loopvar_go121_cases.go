@@ -0,0 +1,75 @@
+//go:build go1.21
+
+package waitgroup
+
+import "sync"
+
+// ========== PRE-GO 1.22 LOOP VARIABLES ==========
+//
+// The go1.21 constraint gives this file the old loop semantics, where every
+// iteration shares one loop variable. Capturing it is a data bug, but each
+// goroutine still releases the group once, so the Add/Done balance holds.
+
+func consumeLoopValue(int) {}
+
+func GoodRangeLoopCapturesSharedIndex(xs []int) {
+	var wg sync.WaitGroup
+	for i := range xs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			consumeLoopValue(xs[i])
+		}()
+	}
+	wg.Wait()
+}
+
+func GoodRangeLoopShadowsLoopVariables(xs []int) {
+	var wg sync.WaitGroup
+	for i, x := range xs {
+		i, x := i, x
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			consumeLoopValue(i + x)
+		}()
+	}
+	wg.Wait()
+}
+
+func GoodRangeLoopPassesValueAsArgument(xs []int) {
+	var wg sync.WaitGroup
+	for _, x := range xs {
+		wg.Add(1)
+		go func(x int) {
+			defer wg.Done()
+			consumeLoopValue(x)
+		}(x)
+	}
+	wg.Wait()
+}
+
+func GoodForLoopCapturesSharedCounter(n int) {
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			consumeLoopValue(i)
+		}()
+	}
+	wg.Wait()
+}
+
+// Bad: the shared variable does not change the count; a goroutine without
+// Done still leaves its Add unmatched.
+func BadRangeLoopCapturesIndexWithoutDone(xs []int) {
+	var wg sync.WaitGroup
+	for i := range xs {
+		wg.Add(1) // want "waitgroup 'wg' has Add without corresponding Done"
+		go func() {
+			consumeLoopValue(xs[i])
+		}()
+	}
+	wg.Wait()
+}
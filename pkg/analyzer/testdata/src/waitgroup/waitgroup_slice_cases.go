@@ -0,0 +1,81 @@
+package waitgroup
+
+import "sync"
+
+// ---------- Slices and Arrays of WaitGroups ----------
+
+// Elements are folded into one 'wgs[]' name because the index is dynamic,
+// so only an Add with no Done on any element is reported.
+func BadSliceWaitGroupsMissingDone(n int) {
+	wgs := make([]sync.WaitGroup, n)
+	for i := range wgs {
+		wgs[i].Add(1) // want "waitgroup 'wgs\\[\\]' has Add without corresponding Done"
+		go func() {
+			println(i)
+		}()
+	}
+	for i := range wgs {
+		wgs[i].Wait()
+	}
+}
+
+func BadArrayWaitGroupsMissingDone() {
+	var wgs [4]sync.WaitGroup
+	for i := 0; i < len(wgs); i++ {
+		wgs[i].Add(1) // want "waitgroup 'wgs\\[\\]' has Add without corresponding Done"
+	}
+	wgs[0].Wait()
+}
+
+func GoodSliceWaitGroupsDoneInGoroutine(n int) {
+	wgs := make([]sync.WaitGroup, n)
+	for i := range wgs {
+		wgs[i].Add(1)
+		go func() {
+			defer wgs[i].Done()
+			println(i)
+		}()
+	}
+	for i := range wgs {
+		wgs[i].Wait()
+	}
+}
+
+func GoodSliceWaitGroupsGo(n int) {
+	wgs := make([]sync.WaitGroup, n)
+	for i := range wgs {
+		wgs[i].Go(func() {
+			println(i)
+		})
+	}
+	for i := range wgs {
+		wgs[i].Wait()
+	}
+}
+
+// The slice is handed to a helper that may call Done, so it is not judged.
+func GoodSliceWaitGroupsPassedOn(n int) {
+	wgs := make([]sync.WaitGroup, n)
+	for i := range wgs {
+		wgs[i].Add(1)
+	}
+	finishSliceWaitGroups(wgs)
+}
+
+func finishSliceWaitGroups(wgs []sync.WaitGroup) {
+	for i := range wgs {
+		wgs[i].Done()
+	}
+}
+
+// Pointer elements alias a WaitGroup tracked under its own name, so the Add
+// through the slice is not judged apart from the Done on wg.
+func GoodPointerSliceAliasesWaitGroup() {
+	var wg sync.WaitGroup
+	ptrs := []*sync.WaitGroup{&wg}
+	ptrs[0].Add(1)
+	go func() {
+		defer wg.Done()
+	}()
+	wg.Wait()
+}
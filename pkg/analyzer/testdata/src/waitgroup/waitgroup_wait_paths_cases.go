@@ -0,0 +1,112 @@
+package waitgroup
+
+import (
+	"errors"
+	"sync"
+)
+
+// ========== WAIT NOT GUARANTEED (GCL2023) ==========
+
+// The Wait only runs when wait is true; otherwise the function returns while
+// the worker is still running.
+func BadConditionalWait(wait bool) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		doSomething()
+	}()
+	if wait {
+		wg.Wait() // want "waitgroup 'wg' Wait not guaranteed on all return paths"
+	}
+}
+
+// An early return added after the workers were launched skips the Wait.
+func BadEarlyReturnBeforeWait(jobs []int, check func() error) error {
+	var wg sync.WaitGroup
+	for range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			doSomething()
+		}()
+	}
+	if err := check(); err != nil {
+		return err
+	}
+	wg.Wait() // want "waitgroup 'wg' Wait not guaranteed on all return paths"
+	return nil
+}
+
+// A switch without default may match no case and skip the Wait.
+func BadWaitOnlyInSwitchCase(mode int) {
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		doSomething()
+	})
+	switch mode {
+	case 1:
+		wg.Wait() // want "waitgroup 'wg' Wait not guaranteed on all return paths"
+	case 2:
+		wg.Wait()
+	}
+}
+
+// Both branches wait.
+func GoodWaitInEveryBranch(fast bool) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		doSomething()
+	}()
+	if fast {
+		wg.Wait()
+		return
+	}
+	wg.Wait()
+	doSomething()
+}
+
+// Returns before the first Add leave no worker behind.
+func GoodReturnBeforeAdd(check func() error) error {
+	if err := check(); err != nil {
+		return err
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+	}()
+	wg.Wait()
+	return nil
+}
+
+// The early return hands back the Add with a Done before any worker starts.
+func GoodEarlyReturnUndoesAdd(skip bool) error {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	if skip {
+		wg.Done()
+		return errors.New("skipped")
+	}
+	go func() {
+		defer wg.Done()
+	}()
+	wg.Wait()
+	return nil
+}
+
+// A deferred Wait runs on every return.
+func GoodDeferredWait(check func() error) error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+	}()
+	if err := check(); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package waitgroupfix
+
+import "sync"
+
+// The goroutine started after the Add never calls Done: the fix defers it.
+func BadGoroutineMissingDone() {
+	var wg sync.WaitGroup
+	wg.Add(1) // want "waitgroup 'wg' has Add without corresponding Done"
+	go func() {
+		println("work")
+	}()
+	wg.Wait()
+}
+
+func BadLoopGoroutineMissingDone(n int) {
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1) // want "waitgroup 'wg' has Add without corresponding Done"
+		go func() {
+			println(i)
+		}()
+	}
+	wg.Wait()
+}
+
+// A one-line goroutine gets the diagnostic but no fix.
+func BadOneLineGoroutineMissingDone() {
+	var wg sync.WaitGroup
+	wg.Add(1) // want "waitgroup 'wg' has Add without corresponding Done"
+	go func() { println("work") }()
+	wg.Wait()
+}
+
+// An Add(2) would stay one short with a single deferred Done: no fix.
+func BadAddTwoGoroutineMissingDone() {
+	var wg sync.WaitGroup
+	wg.Add(2) // want "waitgroup 'wg' has Add without corresponding Done"
+	go func() {
+		println("work")
+	}()
+	wg.Wait()
+}
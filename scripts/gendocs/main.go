@@ -176,6 +176,9 @@ func genPage(c analyzer.Check) string {
 	fmt.Fprintf(&b, "| Code      | `%s` |\n", c.Code)
 	fmt.Fprintf(&b, "| Slug      | `%s` |\n", c.Slug)
 	fmt.Fprintf(&b, "| Primitive | %s |\n", codeSpans(c.Primitive))
+	if c.OptIn {
+		fmt.Fprintf(&b, "| Default   | off — enable with `-enable %s` |\n", c.Code)
+	}
 
 	fmt.Fprintf(&b, "\n## Why it matters\n\n%s\n", c.Why)
 